@@ -7,9 +7,11 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
 	"time"
 
+	"github.com/abhilashdk2016/golang-ecommerce/internal/auth/oidc"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/config"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/database"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/events"
@@ -42,6 +44,11 @@ import (
 // @in header
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token.
+
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key issued under /admin/api-keys for server-to-server integrations.
 func main() {
 	log := logger.New()
 	cfg, err := config.Load()
@@ -75,12 +82,73 @@ func main() {
 
 	userRepo := repository.NewUserRepository(db)
 	cartRepo := repository.NewCartRepository(db)
+	loginThrottleRepo := repository.NewLoginThrottleRepository(db)
+	roleRepo := repository.NewRoleRepository(db)
+	apiKeyRepo := repository.NewAPIKeyRepository(db)
+	auditLogRepo := repository.NewAuditLogRepository(db)
+	searchAnalyticsRepo := repository.NewSearchAnalyticsRepository(db)
+
+	auditService := services.NewAuditService(auditLogRepo)
+	authService := services.NewAuthService(cfg, eventPublisher, userRepo, cartRepo, loginThrottleRepo, roleRepo, auditService)
+	rbacService := services.NewRBACService(roleRepo, userRepo, auditService)
+	apiKeyService := services.NewAPIKeyService(apiKeyRepo, userRepo, roleRepo, auditService)
+	oidcProviders := make([]*oidc.Client, 0, len(cfg.OIDC.Providers))
+	for _, provider := range cfg.OIDC.Providers {
+		oidcProviders = append(oidcProviders, oidc.NewClient(oidc.ProviderConfig{
+			Name:         provider.Name,
+			IssuerURL:    provider.IssuerURL,
+			AuthURL:      provider.AuthURL,
+			TokenURL:     provider.TokenURL,
+			UserInfoURL:  provider.UserInfoURL,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  strings.TrimRight(cfg.OIDC.RedirectBaseURL, "/") + "/" + provider.Name + "/callback",
+			Scopes:       provider.Scopes,
+		}, nil))
+	}
+	oidcService := services.NewOIDCService(cfg, oidcProviders, authService, userRepo, cartRepo)
+	var pubSub interfaces.PubSub
+	if cfg.PubSub.Backend == "postgres" {
+		pubSub = providers.NewPostgresPubSub(ctx, db, database.DSN(&cfg.Database))
+	} else {
+		pubSub = providers.NewMemoryPubSub()
+	}
+
+	var searchIndex interfaces.SearchIndex
+	switch cfg.Search.Backend {
+	case "opensearch":
+		openSearchIndex := providers.NewOpenSearchIndex(&cfg.Search)
+		if err := openSearchIndex.CreateIndex(ctx); err != nil {
+			log.Fatal().Err(err).Msg("failed to create search index")
+		}
+		searchIndex = openSearchIndex
+	case "memory":
+		searchIndex = providers.NewMemorySearchIndex()
+	default:
+		searchIndex = providers.NewPostgresSearchIndex(db)
+	}
+
+	// The postgres index reads the products table, so only the others need
+	// to be kept in sync
+	if cfg.Search.Backend == "opensearch" || cfg.Search.Backend == "memory" {
+		searchIndexer := services.NewSearchIndexer(db, searchIndex, pubSub)
+		go func() {
+			if err := searchIndexer.Run(ctx); err != nil {
+				log.Error().Err(err).Msg("failed to run search indexer")
+			}
+		}()
+	}
+
+	productService := services.NewProductService(db, pubSub, searchIndex, auditService)
+	userService := services.NewUserService(db, auditService)
+	orderService := services.NewOrderService(cfg, db, pubSub)
+	cartService := services.NewCartService(db, pubSub)
+	privacyService := services.NewPrivacyService(db, eventPublisher, cartService, orderService)
+	searchAnalyticsService := services.NewSearchAnalyticsService(searchAnalyticsRepo)
+	searchTuningService := services.NewSearchTuningService(db, pubSub, auditService)
 
-	authService := services.NewAuthService(cfg, eventPublisher, userRepo, cartRepo)
-	productService := services.NewProductService(db)
-	userService := services.NewUserService(db)
-	orderService := services.NewOrderService(db)
-	cartService := services.NewCartService(db)
+	go privacyService.Run(ctx)
+	go searchAnalyticsService.Run(ctx)
 
 	var uploadProvider interfaces.UploadProvider
 	if cfg.Upload.UploadProvider == "s3" {
@@ -90,15 +158,30 @@ func main() {
 	}
 	uploadService := services.NewUploadService(uploadProvider)
 
+	var rateLimitStore interfaces.RateLimitStore
+	if cfg.RateLimit.Store == "postgres" {
+		rateLimitStore = providers.NewPostgresRateLimitStore(db)
+	} else {
+		rateLimitStore = providers.NewMemoryRateLimitStore()
+	}
+
 	srv := server.New(
 		cfg,
 		&log,
+		rateLimitStore,
 		authService,
+		oidcService,
+		rbacService,
+		apiKeyService,
+		auditService,
+		privacyService,
 		productService,
 		userService,
 		uploadService,
 		cartService,
 		orderService,
+		searchAnalyticsService,
+		searchTuningService,
 	)
 	router := srv.SetupRoutes()
 
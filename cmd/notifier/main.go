@@ -90,6 +90,16 @@ func processMessage(msg *message.Message, emailNotifier *notifications.EmailNoti
 	switch eventType {
 	case notifications.UserLoggedIn:
 		return handleUserLoggedIn(msg, emailNotifier)
+	case notifications.EmailVerificationRequested:
+		return handleEmailVerificationRequested(msg, emailNotifier)
+	case notifications.AccountLocked:
+		return handleAccountLocked(msg, emailNotifier)
+	case notifications.AccountDeleted:
+		return handleAccountDeleted(msg, emailNotifier)
+	case notifications.EmailChangeRequested:
+		return handleEmailChangeRequested(msg, emailNotifier)
+	case notifications.EmailChanged:
+		return handleEmailChanged(msg, emailNotifier)
 	default:
 		log.Printf("Unknown event type: %s", eventType)
 		return nil
@@ -102,12 +112,72 @@ func handleUserLoggedIn(msg *message.Message, emailNotifier *notifications.Email
 		return err
 	}
 
-	userName := user.FirstName + " " + user.LastName
-	if userName == " " {
-		userName = "User"
-	}
+	userName := displayName(user.FirstName, user.LastName)
 
 	log.Printf("Sending login notification to %s", user.Email)
 
 	return emailNotifier.SendLoginNotification(user.Email, userName)
 }
+
+func handleEmailVerificationRequested(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.EmailVerificationMessage
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	log.Printf("Sending email verification to %s", payload.Email)
+
+	return emailNotifier.SendEmailVerification(payload.Email, displayName(payload.FirstName, payload.LastName), payload.VerifyURL)
+}
+
+func handleAccountLocked(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.AccountLockedMessage
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	log.Printf("Sending account locked notification to %s", payload.Email)
+
+	return emailNotifier.SendAccountLockedNotification(payload.Email, displayName(payload.FirstName, payload.LastName), payload.LockedUntil)
+}
+
+func handleAccountDeleted(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.AccountDeletedMessage
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	log.Printf("Sending account deleted notification to %s", payload.Email)
+
+	return emailNotifier.SendAccountDeletedNotification(payload.Email, displayName(payload.FirstName, payload.LastName), payload.DeletedAt)
+}
+
+func handleEmailChangeRequested(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.EmailChangeMessage
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	log.Printf("Sending email change confirmation to %s", payload.Email)
+
+	return emailNotifier.SendEmailChangeConfirmation(payload.Email, displayName(payload.FirstName, payload.LastName), payload.ConfirmURL)
+}
+
+func handleEmailChanged(msg *message.Message, emailNotifier *notifications.EmailNotifier) error {
+	var payload notifications.EmailChangedMessage
+	if err := json.Unmarshal(msg.Payload, &payload); err != nil {
+		return err
+	}
+
+	log.Printf("Sending email changed notification to %s", payload.Email)
+
+	return emailNotifier.SendEmailChangedNotification(payload.Email, displayName(payload.FirstName, payload.LastName), payload.NewEmail)
+}
+
+func displayName(firstName, lastName string) string {
+	userName := firstName + " " + lastName
+	if userName == " " {
+		userName = "User"
+	}
+	return userName
+}
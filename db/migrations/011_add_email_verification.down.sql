@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS verification_tokens;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS email_verified_at;
+
@@ -0,0 +1,27 @@
+ALTER TABLE users
+    ADD COLUMN email_verified_at timestamp with time zone;
+
+-- Treat accounts created before verification existed as verified
+UPDATE
+    users
+SET
+    email_verified_at = created_at;
+
+CREATE TABLE verification_tokens(
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash varchar(64) UNIQUE NOT NULL,
+    purpose varchar(50) NOT NULL,
+    email varchar(255) NOT NULL,
+    expires_at timestamp with time zone NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone
+);
+
+CREATE INDEX idx_verification_tokens_user_id ON verification_tokens(user_id);
+
+CREATE INDEX idx_verification_tokens_purpose ON verification_tokens(purpose);
+
+CREATE INDEX idx_verification_tokens_deleted_at ON verification_tokens(deleted_at);
+
@@ -0,0 +1,17 @@
+ALTER TABLE users
+    ADD COLUMN two_factor_enabled boolean DEFAULT FALSE,
+    ADD COLUMN two_factor_secret varchar(64);
+
+CREATE TABLE recovery_codes(
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash varchar(64) NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone
+);
+
+CREATE INDEX idx_recovery_codes_user_id ON recovery_codes(user_id);
+
+CREATE INDEX idx_recovery_codes_deleted_at ON recovery_codes(deleted_at);
+
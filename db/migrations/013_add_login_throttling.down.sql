@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS login_throttles;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS last_failed_login_at,
+    DROP COLUMN IF EXISTS failed_login_attempts;
+
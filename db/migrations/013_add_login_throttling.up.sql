@@ -0,0 +1,17 @@
+ALTER TABLE users
+    ADD COLUMN failed_login_attempts integer DEFAULT 0,
+    ADD COLUMN last_failed_login_at timestamp with time zone,
+    ADD COLUMN locked_until timestamp with time zone;
+
+CREATE TABLE login_throttles(
+    id serial PRIMARY KEY,
+    ip_address varchar(45) UNIQUE NOT NULL,
+    failed_attempts integer DEFAULT 0,
+    last_failed_at timestamp with time zone,
+    locked_until timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_login_throttles_ip_address ON login_throttles(ip_address);
+
@@ -0,0 +1,16 @@
+CREATE TABLE user_identities(
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    provider varchar(50) NOT NULL,
+    subject varchar(255) NOT NULL,
+    email varchar(255),
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    deleted_at timestamp with time zone,
+    UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
+
+CREATE INDEX idx_user_identities_deleted_at ON user_identities(deleted_at);
+
@@ -0,0 +1,57 @@
+CREATE TABLE roles(
+    id serial PRIMARY KEY,
+    name varchar(50) UNIQUE NOT NULL,
+    description text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE permissions(
+    id serial PRIMARY KEY,
+    name varchar(100) UNIQUE NOT NULL,
+    description text,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE role_permissions(
+    role_id integer NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission_id integer NOT NULL REFERENCES permissions(id) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission_id)
+);
+
+CREATE TABLE user_roles(
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role_id integer NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    PRIMARY KEY (user_id, role_id)
+);
+
+CREATE INDEX idx_user_roles_role_id ON user_roles(role_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('products:write', 'Create, update and delete products and product images'),
+    ('categories:write', 'Create, update and delete categories'),
+    ('orders:read', 'View all customer orders'),
+    ('orders:write', 'Update customer orders'),
+    ('users:read', 'View user accounts'),
+    ('users:write', 'Manage user accounts'),
+    ('roles:manage', 'Assign and remove staff roles');
+
+INSERT INTO roles(name, description) VALUES
+    ('admin', 'Full access to every administrative feature'),
+    ('catalog_editor', 'Manages the product catalogue'),
+    ('support', 'Handles customer accounts and orders');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r CROSS JOIN permissions p WHERE r.name = 'admin';
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name IN ('products:write', 'categories:write')
+WHERE r.name = 'catalog_editor';
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name IN ('orders:read', 'orders:write', 'users:read')
+WHERE r.name = 'support';
+
+-- Existing admins keep full access
+INSERT INTO user_roles(user_id, role_id)
+SELECT u.id, r.id FROM users u CROSS JOIN roles r WHERE u.role = 'admin' AND r.name = 'admin';
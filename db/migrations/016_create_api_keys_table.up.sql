@@ -0,0 +1,23 @@
+CREATE TABLE api_keys(
+    id serial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_by_id integer REFERENCES users(id) ON DELETE SET NULL,
+    name varchar(100) NOT NULL,
+    prefix varchar(16) NOT NULL,
+    key_hash varchar(64) UNIQUE NOT NULL,
+    scopes jsonb NOT NULL DEFAULT '[]',
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_api_keys_user_id ON api_keys(user_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('api_keys:manage', 'Create, list and revoke API keys');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'api_keys:manage'
+WHERE r.name = 'admin';
@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'users:impersonate';
+
+DROP TABLE IF EXISTS user_impersonations;
@@ -0,0 +1,20 @@
+CREATE TABLE user_impersonations(
+    id serial PRIMARY KEY,
+    admin_id integer NOT NULL REFERENCES users(id),
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason text NOT NULL,
+    ip_address varchar(45),
+    expires_at timestamp with time zone NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_user_impersonations_admin_id ON user_impersonations(admin_id);
+
+CREATE INDEX idx_user_impersonations_user_id ON user_impersonations(user_id);
+
+INSERT INTO permissions(name, description) VALUES
+    ('users:impersonate', 'Sign in as a customer for support purposes');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'users:impersonate'
+WHERE r.name = 'admin';
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_users_pending_deletion;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS deletion_requested_at,
+    DROP COLUMN IF EXISTS anonymized_at;
@@ -0,0 +1,6 @@
+ALTER TABLE users
+    ADD COLUMN deletion_requested_at timestamp with time zone,
+    ADD COLUMN anonymized_at timestamp with time zone;
+
+CREATE INDEX idx_users_pending_deletion ON users(deletion_requested_at)
+    WHERE deletion_requested_at IS NOT NULL AND anonymized_at IS NULL;
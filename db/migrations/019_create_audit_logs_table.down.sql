@@ -0,0 +1,3 @@
+DELETE FROM permissions WHERE name = 'audit_logs:read';
+
+DROP TABLE IF EXISTS audit_logs;
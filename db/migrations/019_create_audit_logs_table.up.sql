@@ -0,0 +1,26 @@
+CREATE TABLE audit_logs(
+    id bigserial PRIMARY KEY,
+    actor_id integer REFERENCES users(id) ON DELETE SET NULL,
+    action varchar(100) NOT NULL,
+    entity_type varchar(50) NOT NULL,
+    entity_id varchar(50),
+    before jsonb,
+    after jsonb,
+    ip_address varchar(45),
+    user_agent text,
+    request_id varchar(64),
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_logs_actor_id ON audit_logs(actor_id, created_at);
+
+CREATE INDEX idx_audit_logs_entity ON audit_logs(entity_type, entity_id, created_at);
+
+CREATE INDEX idx_audit_logs_created_at ON audit_logs(created_at);
+
+INSERT INTO permissions(name, description) VALUES
+    ('audit_logs:read', 'View the audit log');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'audit_logs:read'
+WHERE r.name = 'admin';
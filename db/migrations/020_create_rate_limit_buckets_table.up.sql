@@ -0,0 +1,10 @@
+-- Buckets are recreated on demand, so the table does not need to survive a crash
+CREATE UNLOGGED TABLE rate_limit_buckets(
+    key varchar(255) PRIMARY KEY,
+    tokens double precision NOT NULL,
+    allowed boolean NOT NULL DEFAULT true,
+    updated_at timestamp with time zone NOT NULL,
+    expires_at timestamp with time zone NOT NULL
+);
+
+CREATE INDEX idx_rate_limit_buckets_expires_at ON rate_limit_buckets(expires_at);
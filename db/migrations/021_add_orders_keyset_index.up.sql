@@ -0,0 +1,2 @@
+-- Supports keyset pagination of a user's orders, newest first
+CREATE INDEX idx_orders_user_id_created_at_id ON orders(user_id, created_at DESC, id DESC);
@@ -0,0 +1,20 @@
+DROP INDEX IF EXISTS idx_products_listing_category_price;
+
+DROP INDEX IF EXISTS idx_products_listing_best_selling;
+
+DROP INDEX IF EXISTS idx_products_listing_name;
+
+DROP INDEX IF EXISTS idx_products_listing_newest;
+
+DROP INDEX IF EXISTS idx_products_listing_price;
+
+DROP INDEX IF EXISTS idx_products_attributes;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS attributes,
+    DROP COLUMN IF EXISTS sales_count;
+
+DROP INDEX IF EXISTS idx_categories_parent_id;
+
+ALTER TABLE categories
+    DROP COLUMN IF EXISTS parent_id;
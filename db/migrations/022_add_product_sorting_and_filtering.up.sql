@@ -0,0 +1,44 @@
+-- Category tree
+ALTER TABLE categories
+    ADD COLUMN parent_id integer REFERENCES categories(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_categories_parent_id ON categories(parent_id);
+
+-- Filterable attributes and the denormalised sales counter used for sorting
+ALTER TABLE products
+    ADD COLUMN attributes jsonb NOT NULL DEFAULT '{}',
+    ADD COLUMN sales_count integer NOT NULL DEFAULT 0;
+
+UPDATE
+    products p
+SET
+    sales_count = sold.quantity
+FROM (
+    SELECT
+        product_id,
+        SUM(quantity) AS quantity
+    FROM
+        order_items
+    GROUP BY
+        product_id) sold
+WHERE
+    sold.product_id = p.id;
+
+CREATE INDEX idx_products_attributes ON products USING GIN(attributes jsonb_path_ops);
+
+-- One index per catalogue sort order, each ending in id to match keyset
+-- pagination, restricted to the rows listings can return
+CREATE INDEX idx_products_listing_price ON products(price, id)
+    WHERE is_active AND deleted_at IS NULL;
+
+CREATE INDEX idx_products_listing_newest ON products(created_at DESC, id DESC)
+    WHERE is_active AND deleted_at IS NULL;
+
+CREATE INDEX idx_products_listing_name ON products(name, id)
+    WHERE is_active AND deleted_at IS NULL;
+
+CREATE INDEX idx_products_listing_best_selling ON products(sales_count DESC, id DESC)
+    WHERE is_active AND deleted_at IS NULL;
+
+CREATE INDEX idx_products_listing_category_price ON products(category_id, price)
+    WHERE is_active AND deleted_at IS NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS idx_categories_name_trgm;
+
+DROP INDEX IF EXISTS idx_products_name_trgm;
+
+DROP EXTENSION IF EXISTS pg_trgm;
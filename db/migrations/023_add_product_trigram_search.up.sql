@@ -0,0 +1,11 @@
+-- Trigram matching for typo-tolerant search and autocomplete
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Serve both the similarity fallback (<%) and prefix suggestions (ILIKE)
+CREATE INDEX idx_products_name_trgm ON products USING GIN (name gin_trgm_ops)
+WHERE
+    is_active = TRUE AND deleted_at IS NULL;
+
+CREATE INDEX idx_categories_name_trgm ON categories USING GIN (name gin_trgm_ops)
+WHERE
+    is_active = TRUE AND deleted_at IS NULL;
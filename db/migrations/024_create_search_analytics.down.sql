@@ -0,0 +1,9 @@
+DELETE FROM permissions WHERE name = 'search_analytics:read';
+
+DROP TABLE IF EXISTS search_query_daily_stats;
+
+DROP TABLE IF EXISTS search_events;
+
+DROP TABLE IF EXISTS search_results;
+
+DROP TABLE IF EXISTS search_queries;
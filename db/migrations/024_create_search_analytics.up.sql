@@ -0,0 +1,48 @@
+-- Individual searches, kept for a limited time and rolled up daily
+CREATE TABLE search_queries(
+    id bigserial PRIMARY KEY,
+    query varchar(200) NOT NULL,
+    result_count integer NOT NULL,
+    user_id integer REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_search_queries_created_at ON search_queries(created_at);
+
+CREATE INDEX idx_search_queries_user_id ON search_queries(user_id);
+
+-- Products a search returned, on the first page and any later pages, so
+-- events can only name products the search actually showed
+CREATE TABLE search_results(
+    search_query_id bigint NOT NULL REFERENCES search_queries(id) ON DELETE CASCADE,
+    product_id integer NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    PRIMARY KEY (search_query_id, product_id)
+);
+
+-- Clicks and add-to-cart actions on the results of a search
+CREATE TABLE search_events(
+    id bigserial PRIMARY KEY,
+    search_query_id bigint NOT NULL REFERENCES search_queries(id) ON DELETE CASCADE,
+    product_id integer NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    type varchar(20) NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_search_events_search_query_id ON search_events(search_query_id, type);
+
+CREATE TABLE search_query_daily_stats(
+    day date NOT NULL,
+    query varchar(200) NOT NULL,
+    searches integer NOT NULL DEFAULT 0,
+    zero_result_searches integer NOT NULL DEFAULT 0,
+    clicked_searches integer NOT NULL DEFAULT 0,
+    add_to_cart_searches integer NOT NULL DEFAULT 0,
+    PRIMARY KEY (day, query)
+);
+
+INSERT INTO permissions(name, description) VALUES
+    ('search_analytics:read', 'View search analytics reports');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'search_analytics:read'
+WHERE r.name = 'admin';
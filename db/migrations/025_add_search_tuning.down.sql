@@ -0,0 +1,8 @@
+DELETE FROM permissions WHERE name = 'search:manage';
+
+DROP TABLE IF EXISTS search_pins;
+
+DROP TABLE IF EXISTS search_synonym_sets;
+
+ALTER TABLE products
+    DROP COLUMN IF EXISTS search_boost;
@@ -0,0 +1,29 @@
+-- Manual relevance adjustment; each point doubles (or halves, when
+-- negative) a product's relevance score
+ALTER TABLE products
+    ADD COLUMN search_boost double precision NOT NULL DEFAULT 0 CHECK (search_boost BETWEEN -10 AND 10);
+
+-- Terms in a set are interchangeable in search queries
+CREATE TABLE search_synonym_sets(
+    id serial PRIMARY KEY,
+    terms jsonb NOT NULL DEFAULT '[]',
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Products listed first, by position, for a normalised query
+CREATE TABLE search_pins(
+    id serial PRIMARY KEY,
+    query varchar(200) NOT NULL,
+    product_id integer NOT NULL REFERENCES products(id) ON DELETE CASCADE,
+    position integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (query, product_id)
+);
+
+INSERT INTO permissions(name, description) VALUES
+    ('search:manage', 'Manage search synonyms, boosts and pinned results');
+
+INSERT INTO role_permissions(role_id, permission_id)
+SELECT r.id, p.id FROM roles r JOIN permissions p ON p.name = 'search:manage'
+WHERE r.name = 'admin';
@@ -0,0 +1,2 @@
+-- API keys can be issued for other users only when they are service accounts
+ALTER TABLE users ADD COLUMN is_service_account boolean NOT NULL DEFAULT false;
@@ -0,0 +1,12 @@
+-- Social login accounts are created with a random password the user never
+-- learns. Track whether a password was actually chosen so it can be required
+-- for sensitive operations.
+ALTER TABLE users ADD COLUMN password_set boolean NOT NULL DEFAULT false;
+
+-- Accounts created through social login got their identity in the same
+-- request; identities linked to an existing account came later
+UPDATE users u SET password_set = true
+WHERE NOT EXISTS (
+    SELECT 1 FROM user_identities i
+    WHERE i.user_id = u.id AND i.created_at < u.created_at + interval '1 minute'
+);
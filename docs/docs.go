@@ -24,33 +24,32 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/login": {
-            "post": {
-                "description": "Authenticate user with email and password",
-                "consumes": [
-                    "application/json"
+        "/admin/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "List API keys, optionally filtered by owner (requires api_keys:manage)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "User login",
+                "summary": "List API keys",
                 "parameters": [
                     {
-                        "description": "User login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.LoginRequest"
-                        }
+                        "type": "integer",
+                        "description": "Owner user ID",
+                        "name": "user_id",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "API keys retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -60,65 +59,37 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.APIKeyResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Invalid credentials",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/logout": {
-            "post": {
-                "description": "Invalidate refresh token and logout user",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication"
-                ],
-                "summary": "User logout",
-                "parameters": [
-                    {
-                        "description": "Refresh token to invalidate",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RefreshTokenRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Logout successful",
+                    "400": {
+                        "description": "Invalid user ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     }
                 }
-            }
-        },
-        "/auth/refresh": {
+            },
             "post": {
-                "description": "Get a new access token using refresh token",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Issue an API key for the caller or for a service account. Scopes must be permissions both the caller and the owner hold. Keys are not accepted for credential changes, account deletion or checkout. The key is only returned once. (requires api_keys:manage)",
                 "consumes": [
                     "application/json"
                 ],
@@ -126,23 +97,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Create API key",
                 "parameters": [
                     {
-                        "description": "Refresh token",
+                        "description": "API key details",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RefreshTokenRequest"
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateAPIKeyRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Token refreshed successfully",
+                    "201": {
+                        "description": "API key created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -152,15 +123,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreatedAPIKeyResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Invalid refresh token",
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission, scope the caller lacks or owner not a service account",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -168,51 +145,51 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/register": {
-            "post": {
-                "description": "Create a new user account with email and password",
-                "consumes": [
-                    "application/json"
+        "/admin/api-keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Revoke an API key so it can no longer be used (requires api_keys:manage)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "Admin"
                 ],
-                "summary": "Register a new user",
+                "summary": "Revoke API key",
                 "parameters": [
                     {
-                        "description": "User registration data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RegisterRequest"
-                        }
+                        "type": "integer",
+                        "description": "API key ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User registered successfully",
+                    "200": {
+                        "description": "API key revoked successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data or user already exists",
+                        "description": "Invalid API key ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "API key not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -220,48 +197,103 @@ const docTemplate = `{
                 }
             }
         },
-        "/cart": {
+        "/admin/audit-logs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve current user's shopping cart with all items",
+                "description": "Paginated audit trail of admin and security actions, newest first (requires audit_logs:read)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Admin"
+                ],
+                "summary": "List audit logs",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User who performed the action",
+                        "name": "actor_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Action, e.g. product.update",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity type, e.g. product",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Entity ID",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of time range (RFC 3339)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End of time range, exclusive (RFC 3339)",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user's cart",
                 "responses": {
                     "200": {
-                        "description": "Cart retrieved successfully",
+                        "description": "Audit logs retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuditLogResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Cart not found",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -269,48 +301,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/cart/items": {
-            "post": {
+        "/admin/orders": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add a product to the user's shopping cart",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Paginated list of every customer's orders, newest first, optionally for one customer or status (requires orders:read)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Admin"
                 ],
-                "summary": "Add item to cart",
+                "summary": "List all orders",
                 "parameters": [
                     {
-                        "description": "Item to add to cart",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AddToCartRequest"
-                        }
+                        "type": "integer",
+                        "description": "Customer ID",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pending",
+                            "confirmed",
+                            "shipped",
+                            "delivered",
+                            "cancelled"
+                        ],
+                        "type": "string",
+                        "description": "Order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item added to cart successfully",
+                        "description": "Orders retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                                            }
                                         }
                                     }
                                 }
@@ -318,13 +374,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request data or insufficient stock",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -332,45 +388,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/cart/items/{id}": {
-            "put": {
+        "/admin/orders/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update the quantity of an item in the user's cart",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieve an order of any customer (requires orders:read)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Cart"
+                    "Admin"
                 ],
-                "summary": "Update cart item quantity",
+                "summary": "Get any order",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Cart Item ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New quantity",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateCartItemRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Cart item updated successfully",
+                        "description": "Order retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -380,7 +424,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
                                         }
                                     }
                                 }
@@ -388,54 +432,95 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request data or insufficient stock",
+                        "description": "Invalid order ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/admin/orders/{id}/status": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Remove an item from the user's shopping cart",
+                "description": "Move an order to a new status. Subscribers to the order are notified. (requires orders:write)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Cart"
+                    "Admin"
                 ],
-                "summary": "Remove item from cart",
+                "summary": "Update order status",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Cart Item ID",
+                        "description": "Order ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateOrderStatusRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Item removed from cart successfully",
+                        "description": "Order status updated successfully",
                         "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "400": {
-                        "description": "Invalid cart item ID",
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -443,19 +528,24 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories": {
+        "/admin/roles": {
             "get": {
-                "description": "Retrieve all active categories",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all roles with their permissions (requires roles:manage)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Get all categories",
+                "summary": "List roles",
                 "responses": {
                     "200": {
-                        "description": "Categories retrieved successfully",
+                        "description": "Roles retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -467,7 +557,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RoleResponse"
                                             }
                                         }
                                     }
@@ -475,45 +565,39 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/search/boosts": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product category (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the products whose search ranking is boosted or buried, most boosted first (requires search:manage)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
-                ],
-                "summary": "Create a new category",
-                "parameters": [
-                    {
-                        "description": "Category data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateCategoryRequest"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "List product search boosts",
                 "responses": {
-                    "201": {
-                        "description": "Category created successfully",
+                    "200": {
+                        "description": "Product boosts retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -523,27 +607,18 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductBoostResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
                     "403": {
-                        "description": "Admin access required",
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -551,14 +626,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/categories/{id}": {
+        "/admin/search/boosts/{product_id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing category (Admin only)",
+                "description": "Boost (positive) or bury (negative) a product in search results. Each point doubles or halves its relevance score; 0 clears the adjustment. (requires search:manage)",
                 "consumes": [
                     "application/json"
                 ],
@@ -566,30 +641,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Categories"
+                    "Admin"
                 ],
-                "summary": "Update a category",
+                "summary": "Set product search boost",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
+                        "description": "Product ID",
+                        "name": "product_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Category update data",
+                        "description": "Boost score between -10 and 10",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateCategoryRequest"
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductBoostRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Category updated successfully",
+                        "description": "Product boost updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -599,7 +674,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductBoostResponse"
                                         }
                                     }
                                 }
@@ -612,61 +687,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
                     "403": {
-                        "description": "Admin access required",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Delete a category (Admin only)",
-                "tags": [
-                    "Categories"
-                ],
-                "summary": "Delete a category",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Category ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Category deleted successfully",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid category ID",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "403": {
-                        "description": "Admin access required",
+                    "404": {
+                        "description": "Product not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -674,44 +702,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders": {
+        "/admin/search/pins": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve paginated list of user's orders",
+                "description": "List pinned results, optionally for a single query (requires search:manage)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get user's orders",
+                "summary": "List pinned search results",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "query",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Orders retrieved successfully",
+                        "description": "Search pins retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse"
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                                 },
                                 {
                                     "type": "object",
@@ -719,7 +739,7 @@ const docTemplate = `{
                                         "data": {
                                             "type": "array",
                                             "items": {
-                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchPinResponse"
                                             }
                                         }
                                     }
@@ -727,14 +747,14 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -747,17 +767,31 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create an order from the current user's cart",
+                "description": "Pin a product to the top of the results for a query. Pins apply to the exact query, ignoring case and spacing, and are ordered by position. Pinned products still have to match the search filters. (requires search:manage)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
+                ],
+                "summary": "Pin search result",
+                "parameters": [
+                    {
+                        "description": "Pin details",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchPinRequest"
+                        }
+                    }
                 ],
-                "summary": "Create an order",
                 "responses": {
                     "201": {
-                        "description": "Order created successfully",
+                        "description": "Search pin created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -767,7 +801,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchPinResponse"
                                         }
                                     }
                                 }
@@ -775,13 +809,25 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Cart is empty or insufficient stock",
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Product already pinned for the query",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -789,25 +835,25 @@ const docTemplate = `{
                 }
             }
         },
-        "/orders/{id}": {
-            "get": {
+        "/admin/search/pins/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information about a specific order",
+                "description": "Remove a pinned search result (requires search:manage)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Orders"
+                    "Admin"
                 ],
-                "summary": "Get order by ID",
+                "summary": "Unpin search result",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Order ID",
+                        "description": "Search pin ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -815,37 +861,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Order retrieved successfully",
+                        "description": "Search pin deleted successfully",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid order ID",
+                        "description": "Invalid search pin ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
                     "404": {
-                        "description": "Order not found",
+                        "description": "Search pin not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -853,93 +887,94 @@ const docTemplate = `{
                 }
             }
         },
-        "/products": {
+        "/admin/search/report": {
             "get": {
-                "description": "Retrieve paginated list of active products",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Top queries, queries that found nothing, and click-through and add-to-cart rates over a range of UTC days (requires search_analytics:read). Stats are refreshed hourly.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Admin"
                 ],
-                "summary": "Get all products",
+                "summary": "Search analytics report",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
+                        "type": "string",
+                        "description": "First day (YYYY-MM-DD), defaults to 29 days before to",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Last day (YYYY-MM-DD), defaults to today",
+                        "name": "to",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
+                        "default": 20,
+                        "description": "Queries per list, at most 100",
                         "name": "limit",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Products retrieved successfully",
+                        "description": "Search report retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse"
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "array",
-                                            "items": {
-                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
-                                            }
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchReport"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "400": {
+                        "description": "Invalid query parameters",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/admin/search/synonyms": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new product (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List the sets of terms that search treats as equivalent (requires search:manage)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
-                ],
-                "summary": "Create a new product",
-                "parameters": [
-                    {
-                        "description": "Product data",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateProductRequest"
-                        }
-                    }
+                    "Admin"
                 ],
+                "summary": "List search synonym sets",
                 "responses": {
-                    "201": {
-                        "description": "Product created successfully",
+                    "200": {
+                        "description": "Synonym sets retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -949,56 +984,55 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SynonymSetResponse"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
                     "403": {
-                        "description": "Admin access required",
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     }
                 }
-            }
-        },
-        "/products/{id}": {
-            "get": {
-                "description": "Retrieve detailed information about a specific product",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a set of equivalent terms. A search for any term, or a phrase containing one, also matches the others. (requires search:manage)",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Admin"
                 ],
-                "summary": "Get a product by ID",
+                "summary": "Create search synonym set",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Synonym terms",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SynonymSetRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Product retrieved successfully",
+                    "201": {
+                        "description": "Synonym set created successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1008,7 +1042,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SynonymSetResponse"
                                         }
                                     }
                                 }
@@ -1016,26 +1050,28 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID",
+                        "description": "Invalid request data",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "404": {
-                        "description": "Product not found",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/search/synonyms/{id}": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing product (Admin only)",
+                "description": "Replace the terms of a synonym set (requires search:manage)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1043,30 +1079,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Admin"
                 ],
-                "summary": "Update a product",
+                "summary": "Update search synonym set",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Synonym set ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Product update data",
+                        "description": "Synonym terms",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateProductRequest"
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SynonymSetRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product updated successfully",
+                        "description": "Synonym set updated successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1076,7 +1112,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SynonymSetResponse"
                                         }
                                     }
                                 }
@@ -1089,14 +1125,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "403": {
-                        "description": "Admin access required",
+                    "404": {
+                        "description": "Synonym set not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -1109,15 +1145,18 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete a product (Admin only)",
+                "description": "Remove a synonym set (requires search:manage)",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Products"
+                    "Admin"
                 ],
-                "summary": "Delete a product",
+                "summary": "Delete search synonym set",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Product ID",
+                        "description": "Synonym set ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1125,25 +1164,25 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Product deleted successfully",
+                        "description": "Synonym set deleted successfully",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid product ID",
+                        "description": "Invalid synonym set ID",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "403": {
-                        "description": "Admin access required",
+                    "404": {
+                        "description": "Synonym set not found",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -1151,55 +1190,70 @@ const docTemplate = `{
                 }
             }
         },
-        "/products/{id}/images": {
-            "post": {
+        "/admin/users": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Upload an image for a product (Admin only)",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Paginated user search by name, email or phone with role and status filters (requires users:read)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Products"
+                    "Admin"
                 ],
-                "summary": "Upload product image",
+                "summary": "Search users",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search term",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Account status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Product ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "Image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Image uploaded successfully",
+                        "description": "Users retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
-                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse"
                                 },
                                 {
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "type": "object",
-                                            "additionalProperties": {
-                                                "type": "string"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AdminUserResponse"
                                             }
                                         }
                                     }
@@ -1208,19 +1262,13 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request or file",
-                        "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
                     "403": {
-                        "description": "Admin access required",
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -1228,24 +1276,33 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/profile": {
+        "/admin/users/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get current authenticated user's profile information",
+                "description": "Retrieve a user account with its roles (requires users:read)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "Profile retrieved successfully",
+                        "description": "User retrieved successfully",
                         "schema": {
                             "allOf": [
                                 {
@@ -1255,15 +1312,21 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AdminUserResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
@@ -1275,14 +1338,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/admin/users/{id}/impersonate": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current authenticated user's profile information",
+                "description": "Issue a short-lived access token for a customer account. The reason is recorded for auditing. (requires users:impersonate)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1290,23 +1355,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Update user profile",
+                "summary": "Impersonate a user",
                 "parameters": [
                     {
-                        "description": "Profile update data",
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason for impersonation",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateProfileRequest"
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ImpersonateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Profile updated successfully",
+                        "description": "Impersonation token issued",
                         "schema": {
                             "allOf": [
                                 {
@@ -1316,7 +1388,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ImpersonationResponse"
                                         }
                                     }
                                 }
@@ -1329,99 +1401,3681 @@ const docTemplate = `{
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Missing permission",
                         "schema": {
                             "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AddToCartRequest": {
-            "type": "object",
-            "required": [
-                "product_id",
-                "quantity"
-            ],
-            "properties": {
-                "product_id": {
-                    "type": "integer"
-                },
-                "quantity": {
+        },
+        "/admin/users/{id}/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the roles assigned to a user (requires roles:manage)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Get user roles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Roles retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace all roles of a user. Staff cannot change their own roles. (requires roles:manage)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Set user roles",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role names",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SetUserRolesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Roles updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Grant a role to a user. Staff cannot change their own roles. (requires roles:manage)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Assign role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role to assign",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AssignRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role assigned successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/roles/{role}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke a role from a user. Staff cannot change their own roles. (requires roles:manage)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Remove role",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role name",
+                        "name": "role",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role removed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RoleResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/service-account": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark or unmark a user as a service account. Staff with api_keys:manage can issue API keys for service accounts as well as for themselves. (requires users:write)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Mark a service account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Account type",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateServiceAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AdminUserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/status": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Toggle a user account. Deactivation revokes the user's sessions. (requires users:write)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Activate or deactivate a user",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New status",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateUserStatusRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User status updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AdminUserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Clear a temporary lockout caused by failed login attempts (requires users:write)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Unlock a user account",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Account unlocked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/confirm-email-change": {
+            "post": {
+                "description": "Switch the account to the new email address using the token sent to that address",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Confirm email change",
+                "parameters": [
+                    {
+                        "description": "Confirmation token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email changed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user with email and password. Accounts with 2FA enabled receive a challenge token instead of a token pair.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User login",
+                "parameters": [
+                    {
+                        "description": "User login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Exchange the challenge token returned by login and a TOTP or recovery code for a token pair",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Complete two-factor login",
+                "parameters": [
+                    {
+                        "description": "Challenge token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorLoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid challenge or code",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed login attempts",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Invalidate refresh token and logout user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User logout",
+                "parameters": [
+                    {
+                        "description": "Refresh token to invalidate",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logout successful",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/providers": {
+            "get": {
+                "description": "List the configured OpenID Connect providers",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "List social login providers",
+                "responses": {
+                    "200": {
+                        "description": "Providers retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "Handle the identity provider callback, link or create the account and return a token pair",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Complete social login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid callback",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Login failed",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Account disabled or email address not verified",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Account temporarily locked",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/login": {
+            "get": {
+                "description": "Redirect to the identity provider's authorization page using the authorization code flow with PKCE",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Start social login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to identity provider"
+                    },
+                    "404": {
+                        "description": "Unknown provider",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Get a new access token using refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Create a new user account with email and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register a new user",
+                "parameters": [
+                    {
+                        "description": "User registration data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User registered successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or user already exists",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/resend-verification": {
+            "post": {
+                "description": "Send a new verification email if the account exists and is not yet verified",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Resend verification email",
+                "parameters": [
+                    {
+                        "description": "Account email",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ResendVerificationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Verification email sent",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify-email": {
+            "post": {
+                "description": "Confirm the user's email address using the token sent by email",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "description": "Verification token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.VerifyEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email verified successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve current user's shopping cart with all items",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Get user's cart",
+                "responses": {
+                    "200": {
+                        "description": "Cart retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add a product to the user's shopping cart. Include the search_id of the search the product was found by to count the addition in search analytics.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Add item to cart",
+                "parameters": [
+                    {
+                        "description": "Item to add to cart",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AddToCartRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item added to cart successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock (code OUT_OF_STOCK)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/cart/items/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update the quantity of an item in the user's cart",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Update cart item quantity",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cart Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New quantity",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateCartItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Cart item updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Cart item not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock (code OUT_OF_STOCK)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an item from the user's shopping cart",
+                "tags": [
+                    "Cart"
+                ],
+                "summary": "Remove item from cart",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Cart Item ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Item removed from cart successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cart item ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories": {
+            "get": {
+                "description": "Retrieve all active categories",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Get all categories",
+                "responses": {
+                    "200": {
+                        "description": "Categories retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product category (requires categories:write)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Create a new category",
+                "parameters": [
+                    {
+                        "description": "Category data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Category created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/categories/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing category (requires categories:write)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Update a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Category update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateCategoryRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a category (requires categories:write)",
+                "tags": [
+                    "Categories"
+                ],
+                "summary": "Delete a category",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Category ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Category deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid category ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve paginated list of user's orders, newest first. Passing cursor (empty for the first page) switches to keyset pagination and returns utils.CursorMeta, whose end_cursor is the cursor for the next page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get user's orders",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page's end_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Orders retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid cursor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an order from the current user's cart",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Create an order",
+                "responses": {
+                    "201": {
+                        "description": "Order created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Cart is empty",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Email address not verified, or impersonating or using an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Insufficient stock (code OUT_OF_STOCK)",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/orders/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information about a specific order",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Orders"
+                ],
+                "summary": "Get order by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Order ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Order retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid order ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Order not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products": {
+            "get": {
+                "description": "Retrieve a filtered, sorted and paginated list of active products. Passing cursor (empty for the first page) switches to keyset pagination and returns utils.CursorMeta, whose end_cursor is the cursor for the next page. Cursors are only valid with the sort they were issued for.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get all products",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from a previous page's end_cursor",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "price_asc",
+                            "price_desc",
+                            "newest",
+                            "name",
+                            "best_selling"
+                        ],
+                        "type": "string",
+                        "description": "Sort order, by ID when omitted",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Category, including its subcategories",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products in stock",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute value, e.g. attr[color]=red; repeat for several attributes",
+                        "name": "attr[name]",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Products retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters or cursor",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new product (requires products:write)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Create a new product",
+                "parameters": [
+                    {
+                        "description": "Product data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Product created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}": {
+            "get": {
+                "description": "Retrieve detailed information about a specific product",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Get a product by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Product not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update an existing product (requires products:write)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Update a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Product update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateProductRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a product (requires products:write)",
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Delete a product",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Product deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid product ID",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/products/{id}/images": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Upload an image for a product (requires products:write)",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Upload product image",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Product ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Image uploaded successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "object",
+                                            "additionalProperties": {
+                                                "type": "string"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or file",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Missing permission",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "Search products by relevance. Admin-managed synonyms are expanded, boosted products rank higher and buried ones lower, and products pinned to the query come first, marked as pinned. Typo handling depends on the search backend: the postgres backend returns products with similar names when nothing matches, and the opensearch backend matches words fuzzily. The response includes facet counts by category, price range, stock and attribute over all matching products; each facet ignores the filter on its own dimension. First pages are logged for search analytics and return a search_id to quote when reporting clicks and cart additions; pass it as search_id when fetching later pages so their products can be reported too. A bearer token is optional and links the search to the signed-in user.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Search products",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Filter by category, including its subcategories",
+                        "name": "category_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Minimum price filter",
+                        "name": "min_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "description": "Maximum price filter",
+                        "name": "max_price",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only products in stock",
+                        "name": "in_stock",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attribute value, e.g. attr[color]=red; repeat for several attributes",
+                        "name": "attr[name]",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "search_id returned with the first page, when fetching later pages",
+                        "name": "search_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Search results",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.FacetedResponse"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductSearchResult"
+                                            }
+                                        },
+                                        "facets": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchFacets"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search query or page beyond the first 10000 results",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/clicks": {
+            "post": {
+                "description": "Report that a shopper opened a product from the results of a search, for click-through rates in search analytics. The product must be one the search returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Record a search result click",
+                "parameters": [
+                    {
+                        "description": "Search and product clicked",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchClickRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Click recorded",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Search not found or did not return the product",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "429": {
+                        "description": "Rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/search/suggest": {
+            "get": {
+                "description": "Autocomplete a partial search query with category and product names in which a word starts with the query. Categories are listed first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Products"
+                ],
+                "summary": "Suggest search completions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Partial search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 8,
+                        "description": "Maximum suggestions, at most 20",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Suggestions",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchSuggestion"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid search query",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/disable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Disable two-factor authentication using a TOTP or recovery code",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Disable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "TOTP or recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Two-factor authentication disabled",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/enable": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm the TOTP secret with a code and receive recovery codes",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Enable two-factor authentication",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Two-factor authentication enabled",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RecoveryCodesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/recovery-codes": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace all recovery codes with a new set",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Regenerate recovery codes",
+                "parameters": [
+                    {
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recovery codes regenerated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.RecoveryCodesResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid code",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/2fa/setup": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a TOTP secret and otpauth URI for the current user. 2FA is enabled once a code is confirmed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Set up two-factor authentication",
+                "responses": {
+                    "200": {
+                        "description": "Two-factor setup started",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorSetupResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Two-factor already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/email": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Send a confirmation link to a new email address. The email is only changed once the link is used.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change email",
+                "parameters": [
+                    {
+                        "description": "New email and current password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ChangeEmailRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Confirmation email sent",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data, wrong password or email in use",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Close the current user's account. Sessions are revoked immediately; personal data is anonymised in the background and a confirmation email is sent. Orders are kept for accounting. Accounts with a password must confirm with it; social login accounts confirm with a two-factor code when 2FA is enabled, or otherwise must have signed in within the last five minutes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Delete account",
+                "parameters": [
+                    {
+                        "description": "Password, or for social login accounts a two-factor code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.DeleteAccountRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Account deletion scheduled",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid password or two-factor code",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key, or recent sign-in required",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/me/export": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Download everything stored about the current user (profile, linked accounts, cart and order history) as JSON or as a ZIP archive",
+                "produces": [
+                    "application/json",
+                    "application/zip"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Export personal data",
+                "parameters": [
+                    {
+                        "enum": [
+                            "json",
+                            "zip"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Export format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Data export",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserDataExport"
+                        }
+                    },
+                    "400": {
+                        "description": "Unsupported format",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/password": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the current user's password. Every other session is revoked; pass the current refresh token to keep this session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Current and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ChangePasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or wrong password",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not allowed while impersonating or with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/profile": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get current authenticated user's profile information",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile retrieved successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current authenticated user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Update user profile",
+                "parameters": [
+                    {
+                        "description": "Profile update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateProfileRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_apperror.Code": {
+            "type": "string",
+            "enum": [
+                "INVALID_INPUT",
+                "UNAUTHORIZED",
+                "FORBIDDEN",
+                "NOT_FOUND",
+                "CONFLICT",
+                "OUT_OF_STOCK",
+                "TOO_MANY_REQUESTS",
+                "INTERNAL"
+            ],
+            "x-enum-varnames": [
+                "CodeInvalidInput",
+                "CodeUnauthorized",
+                "CodeForbidden",
+                "CodeNotFound",
+                "CodeConflict",
+                "CodeOutOfStock",
+                "CodeTooManyRequests",
+                "CodeInternal"
+            ]
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.APIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AddToCartRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "quantity"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "search_id": {
+                    "description": "SearchID attributes the addition to the search the product was found by",
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AdminUserResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "email_verified_at": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "is_service_account": {
+                    "type": "boolean"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "locked_until": {
+                    "type": "string"
+                },
+                "phone": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "two_factor_enabled": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AssignRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AttributeFacet": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AttributeValueFacet"
+                    }
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AttributeValueFacet": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.Attributes": {
+            "type": "object",
+            "additionalProperties": {
+                "type": "string"
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuditLogResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor_id": {
+                    "type": "integer"
+                },
+                "after": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "before": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "string"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "ip_address": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "challenge_token": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "two_factor_required": {
+                    "type": "boolean"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartItemResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "product": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "subtotal": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse": {
+            "type": "object",
+            "properties": {
+                "cart_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartItemResponse"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryFacet": {
+            "type": "object",
+            "properties": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ChangeEmailRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_email"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_email": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ChangePasswordRequest": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string"
+                },
+                "new_password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateAPIKeyRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateCategoryRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateProductRequest": {
+            "type": "object",
+            "required": [
+                "attributes",
+                "category_id",
+                "name",
+                "price",
+                "sku"
+            ],
+            "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.Attributes"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
                     "type": "integer",
-                    "minimum": 1
+                    "minimum": 0
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreatedAPIKeyResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.DeleteAccountRequest": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ImpersonateRequest": {
+            "type": "object",
+            "required": [
+                "reason"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ImpersonationResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "impersonator_id": {
+                    "type": "integer"
+                },
+                "user": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.LinkedIdentityResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderItemResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "product": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.AuthResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "created_at": {
                     "type": "string"
                 },
-                "refresh_token": {
+                "id": {
+                    "type": "integer"
+                },
+                "order_items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderItemResponse"
+                    }
+                },
+                "status": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                "total_amount": {
+                    "type": "number"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartItemResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.PriceRangeFacet": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "count": {
+                    "type": "integer"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "min": {
+                    "type": "number"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductBoostRequest": {
+            "type": "object",
+            "required": [
+                "boost"
+            ],
+            "properties": {
+                "boost": {
+                    "type": "number",
+                    "maximum": 10,
+                    "minimum": -10
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductBoostResponse": {
+            "type": "object",
+            "properties": {
+                "boost": {
+                    "type": "number"
+                },
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "product_id": {
                     "type": "integer"
                 },
-                "product": {
-                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                "sku": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductImageResponse": {
+            "type": "object",
+            "properties": {
+                "alt_text": {
+                    "type": "string"
                 },
-                "quantity": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
                     "type": "integer"
                 },
-                "subtotal": {
-                    "type": "number"
+                "is_primary": {
+                    "type": "boolean"
                 },
-                "updated_at": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse": {
             "type": "object",
             "properties": {
-                "cart_items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartItemResponse"
-                    }
+                "attributes": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.Attributes"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                },
+                "category_id": {
+                    "type": "integer"
                 },
                 "created_at": {
                     "type": "string"
                 },
+                "description": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "integer"
                 },
-                "total": {
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductImageResponse"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
                     "type": "number"
                 },
-                "updated_at": {
+                "sales_count": {
+                    "type": "integer"
+                },
+                "sku": {
                     "type": "string"
                 },
-                "user_id": {
+                "stock": {
                     "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductSearchResult": {
             "type": "object",
             "properties": {
+                "attributes": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.Attributes"
+                },
+                "category": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
+                },
+                "category_id": {
+                    "type": "integer"
+                },
                 "created_at": {
                     "type": "string"
                 },
@@ -1431,223 +5085,375 @@ const docTemplate = `{
                 "id": {
                     "type": "integer"
                 },
+                "images": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductImageResponse"
+                    }
+                },
                 "is_active": {
                     "type": "boolean"
                 },
                 "name": {
                     "type": "string"
                 },
+                "pinned": {
+                    "description": "Pinned results are listed first for the query regardless of rank",
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "number"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "sales_count": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "stock": {
+                    "type": "integer"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateCategoryRequest": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.RecoveryCodesResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.RefreshTokenRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string",
+                    "minLength": 8
+                },
+                "phone": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ResendVerificationRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.RoleResponse": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "permissions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchClickRequest": {
+            "type": "object",
+            "required": [
+                "product_id",
+                "search_id"
+            ],
+            "properties": {
+                "product_id": {
+                    "type": "integer"
+                },
+                "search_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchFacets": {
+            "type": "object",
+            "properties": {
+                "attributes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.AttributeFacet"
+                    }
+                },
+                "categories": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryFacet"
+                    }
+                },
+                "in_stock": {
+                    "type": "integer"
+                },
+                "price_ranges": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.PriceRangeFacet"
+                    }
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchPinRequest": {
             "type": "object",
             "required": [
-                "name"
+                "product_id",
+                "query"
             ],
             "properties": {
-                "description": {
-                    "type": "string"
+                "position": {
+                    "type": "integer",
+                    "minimum": 0
                 },
-                "name": {
-                    "type": "string"
+                "product_id": {
+                    "type": "integer"
+                },
+                "query": {
+                    "type": "string",
+                    "maxLength": 200
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.CreateProductRequest": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchPinResponse": {
             "type": "object",
-            "required": [
-                "category_id",
-                "name",
-                "price",
-                "sku"
-            ],
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
+                "created_at": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "id": {
+                    "type": "integer"
                 },
-                "price": {
-                    "type": "number"
+                "position": {
+                    "type": "integer"
                 },
-                "sku": {
-                    "type": "string"
+                "product_id": {
+                    "type": "integer"
                 },
-                "stock": {
-                    "type": "integer",
-                    "minimum": 0
-                }
-            }
-        },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
+                "product_name": {
                     "type": "string"
                 },
-                "password": {
+                "query": {
                     "type": "string"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderItemResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchQueryStats": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
+                "add_to_cart_rate": {
+                    "type": "number"
                 },
-                "id": {
+                "add_to_cart_searches": {
                     "type": "integer"
                 },
-                "price": {
+                "click_through_rate": {
                     "type": "number"
                 },
-                "product": {
-                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse"
+                "clicked_searches": {
+                    "type": "integer"
                 },
-                "quantity": {
+                "query": {
+                    "type": "string"
+                },
+                "searches": {
+                    "type": "integer"
+                },
+                "zero_result_searches": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchReport": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "from": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "to": {
+                    "type": "string"
                 },
-                "order_items": {
+                "top_queries": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderItemResponse"
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchQueryStats"
                     }
                 },
-                "status": {
-                    "type": "string"
+                "totals": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchStats"
                 },
-                "total_amount": {
+                "zero_result_queries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchQueryStats"
+                    }
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchStats": {
+            "type": "object",
+            "properties": {
+                "add_to_cart_rate": {
                     "type": "number"
                 },
-                "updated_at": {
-                    "type": "string"
+                "add_to_cart_searches": {
+                    "type": "integer"
                 },
-                "user_id": {
+                "click_through_rate": {
+                    "type": "number"
+                },
+                "clicked_searches": {
+                    "type": "integer"
+                },
+                "searches": {
+                    "type": "integer"
+                },
+                "zero_result_searches": {
                     "type": "integer"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductImageResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SearchSuggestion": {
             "type": "object",
             "properties": {
-                "alt_text": {
-                    "type": "string"
-                },
-                "created_at": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "is_primary": {
-                    "type": "boolean"
+                "text": {
+                    "type": "string"
                 },
-                "url": {
+                "type": {
                     "type": "string"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductResponse": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SetUserRolesRequest": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SynonymSetRequest": {
+            "type": "object",
+            "required": [
+                "terms"
+            ],
+            "properties": {
+                "terms": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 2,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.SynonymSetResponse": {
             "type": "object",
             "properties": {
-                "category": {
-                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CategoryResponse"
-                },
-                "category_id": {
-                    "type": "integer"
-                },
                 "created_at": {
                     "type": "string"
                 },
-                "description": {
-                    "type": "string"
-                },
                 "id": {
                     "type": "integer"
                 },
-                "images": {
+                "terms": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.ProductImageResponse"
+                        "type": "string"
                     }
                 },
-                "is_active": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "price": {
-                    "type": "number"
-                },
-                "sku": {
-                    "type": "string"
-                },
-                "stock": {
-                    "type": "integer"
-                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.RefreshTokenRequest": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorCodeRequest": {
             "type": "object",
             "required": [
-                "refresh_token"
+                "code"
             ],
             "properties": {
-                "refresh_token": {
+                "code": {
                     "type": "string"
                 }
             }
         },
-        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.RegisterRequest": {
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorLoginRequest": {
             "type": "object",
             "required": [
-                "email",
-                "first_name",
-                "last_name",
-                "password"
+                "challenge_token",
+                "code"
             ],
             "properties": {
-                "email": {
+                "challenge_token": {
                     "type": "string"
                 },
-                "first_name": {
+                "code": {
                     "type": "string"
-                },
-                "last_name": {
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.TwoFactorSetupResponse": {
+            "type": "object",
+            "properties": {
+                "otpauth_uri": {
                     "type": "string"
                 },
-                "password": {
-                    "type": "string",
-                    "minLength": 8
-                },
-                "phone": {
+                "secret": {
                     "type": "string"
                 }
             }
@@ -1678,17 +5484,47 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "parent_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateOrderStatusRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "confirmed",
+                        "shipped",
+                        "delivered",
+                        "cancelled"
+                    ]
                 }
             }
         },
         "github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateProductRequest": {
             "type": "object",
             "required": [
+                "attributes",
                 "category_id",
                 "name",
                 "price"
             ],
             "properties": {
+                "attributes": {
+                    "description": "unchanged when omitted",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.Attributes"
+                        }
+                    ]
+                },
                 "category_id": {
                     "type": "integer"
                 },
@@ -1728,6 +5564,54 @@ const docTemplate = `{
                 }
             }
         },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateServiceAccountRequest": {
+            "type": "object",
+            "required": [
+                "is_service_account"
+            ],
+            "properties": {
+                "is_service_account": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.UpdateUserStatusRequest": {
+            "type": "object",
+            "required": [
+                "is_active"
+            ],
+            "properties": {
+                "is_active": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserDataExport": {
+            "type": "object",
+            "properties": {
+                "cart": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.CartResponse"
+                },
+                "exported_at": {
+                    "type": "string"
+                },
+                "linked_accounts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.LinkedIdentityResponse"
+                    }
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.OrderResponse"
+                    }
+                },
+                "profile": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse"
+                }
+            }
+        },
         "github_com_abhilashdk2016_golang-ecommerce_internal_dto.UserResponse": {
             "type": "object",
             "properties": {
@@ -1737,6 +5621,9 @@ const docTemplate = `{
                 "email": {
                     "type": "string"
                 },
+                "email_verified_at": {
+                    "type": "string"
+                },
                 "first_name": {
                     "type": "string"
                 },
@@ -1755,14 +5642,56 @@ const docTemplate = `{
                 "role": {
                     "type": "string"
                 },
+                "two_factor_enabled": {
+                    "type": "boolean"
+                },
                 "updated_at": {
                     "type": "string"
                 }
             }
         },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_dto.VerifyEmailRequest": {
+            "type": "object",
+            "required": [
+                "token"
+            ],
+            "properties": {
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "github_com_abhilashdk2016_golang-ecommerce_internal_utils.FacetedResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_apperror.Code"
+                },
+                "data": {},
+                "error": {
+                    "type": "string"
+                },
+                "facets": {},
+                "message": {
+                    "type": "string"
+                },
+                "meta": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginationMeta"
+                },
+                "search_id": {
+                    "type": "integer"
+                },
+                "success": {
+                    "type": "boolean"
+                }
+            }
+        },
         "github_com_abhilashdk2016_golang-ecommerce_internal_utils.PaginatedResponse": {
             "type": "object",
             "properties": {
+                "code": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_apperror.Code"
+                },
                 "data": {},
                 "error": {
                     "type": "string"
@@ -1798,6 +5727,9 @@ const docTemplate = `{
         "github_com_abhilashdk2016_golang-ecommerce_internal_utils.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "$ref": "#/definitions/github_com_abhilashdk2016_golang-ecommerce_internal_apperror.Code"
+                },
                 "data": {},
                 "error": {
                     "type": "string"
@@ -1812,6 +5744,12 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key issued under /admin/api-keys for server-to-server integrations.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Type \"Bearer\" followed by a space and JWT token.",
             "type": "apiKey",
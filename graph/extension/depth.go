@@ -0,0 +1,72 @@
+package extension
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errDepthLimitExceeded = "DEPTH_LIMIT_EXCEEDED"
+
+// DepthLimit rejects operations whose selections are nested deeper than
+// MaxDepth. Fragments do not add depth and introspection fields are ignored.
+type DepthLimit struct {
+	MaxDepth int
+}
+
+var _ interface {
+	graphql.OperationContextMutator
+	graphql.HandlerExtension
+} = DepthLimit{}
+
+func (d DepthLimit) ExtensionName() string {
+	return "DepthLimit"
+}
+
+func (d DepthLimit) Validate(schema graphql.ExecutableSchema) error {
+	if d.MaxDepth < 1 {
+		return fmt.Errorf("max depth must be at least 1")
+	}
+	return nil
+}
+
+func (d DepthLimit) MutateOperationContext(ctx context.Context, rc *graphql.OperationContext) *gqlerror.Error {
+	if rc.Operation == nil {
+		return nil
+	}
+
+	if depth := selectionDepth(rc.Operation.SelectionSet); depth > d.MaxDepth {
+		err := gqlerror.Errorf("operation has depth %d, which exceeds the limit of %d", depth, d.MaxDepth)
+		errcode.Set(err, errDepthLimitExceeded)
+		return err
+	}
+
+	return nil
+}
+
+func selectionDepth(selectionSet ast.SelectionSet) int {
+	maxDepth := 0
+	for _, selection := range selectionSet {
+		depth := 0
+		switch selection := selection.(type) {
+		case *ast.Field:
+			if strings.HasPrefix(selection.Name, "__") {
+				continue
+			}
+			depth = 1 + selectionDepth(selection.SelectionSet)
+		case *ast.InlineFragment:
+			depth = selectionDepth(selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if selection.Definition != nil {
+				depth = selectionDepth(selection.Definition.SelectionSet)
+			}
+		}
+		maxDepth = max(maxDepth, depth)
+	}
+	return maxDepth
+}
@@ -0,0 +1,90 @@
+package extension
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/errcode"
+	"github.com/vektah/gqlparser/v2/gqlerror"
+)
+
+const errPersistedQueryNotAllowed = "PERSISTED_QUERY_NOT_ALLOWED"
+
+// PersistedQueries is a fixed set of queries keyed by the hex encoded SHA-256
+// of the query text, the same hash clients send for automatic persisted
+// queries. It implements graphql.Cache so it can back the APQ extension, but
+// Add is a no-op so clients cannot register new queries.
+type PersistedQueries map[string]string
+
+var _ graphql.Cache[string] = PersistedQueries{}
+
+// LoadPersistedQueries reads a JSON object mapping query hashes to queries and
+// checks that every hash matches its query
+func LoadPersistedQueries(path string) (PersistedQueries, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var queries PersistedQueries
+	if err := json.Unmarshal(data, &queries); err != nil {
+		return nil, fmt.Errorf("invalid persisted queries file: %w", err)
+	}
+
+	for hash, query := range queries {
+		if queryHash(query) != hash {
+			return nil, fmt.Errorf("persisted query %s does not match its hash", hash)
+		}
+	}
+
+	return queries, nil
+}
+
+func (q PersistedQueries) Get(ctx context.Context, key string) (string, bool) {
+	query, ok := q[key]
+	return query, ok
+}
+
+func (q PersistedQueries) Add(ctx context.Context, key string, value string) {}
+
+// PersistedQueryAllowList rejects any operation whose query is not one of the
+// registered persisted queries. It must be installed after the APQ extension
+// so that queries sent by hash have already been resolved.
+type PersistedQueryAllowList struct {
+	Queries PersistedQueries
+}
+
+var _ interface {
+	graphql.OperationParameterMutator
+	graphql.HandlerExtension
+} = PersistedQueryAllowList{}
+
+func (a PersistedQueryAllowList) ExtensionName() string {
+	return "PersistedQueryAllowList"
+}
+
+func (a PersistedQueryAllowList) Validate(schema graphql.ExecutableSchema) error {
+	if a.Queries == nil {
+		return fmt.Errorf("PersistedQueryAllowList.Queries can not be nil")
+	}
+	return nil
+}
+
+func (a PersistedQueryAllowList) MutateOperationParameters(ctx context.Context, rawParams *graphql.RawParams) *gqlerror.Error {
+	if _, ok := a.Queries[queryHash(rawParams.Query)]; !ok {
+		err := gqlerror.Errorf("only persisted queries are allowed")
+		errcode.Set(err, errPersistedQueryNotAllowed)
+		return err
+	}
+	return nil
+}
+
+func queryHash(query string) string {
+	sum := sha256.Sum256([]byte(query))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,128 @@
+package loader
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("not found")
+
+// FetchFunc loads the values for a batch of keys. Keys missing from the
+// returned map resolve to ErrNotFound.
+type FetchFunc[K comparable, V any] func(ctx context.Context, keys []K) (map[K]V, error)
+
+// Loader batches and caches lookups by key. Loads issued within the wait
+// window are collected into a single fetch, and every key is fetched at most
+// once for the lifetime of the loader, so a loader must not outlive the
+// GraphQL operation it was created for.
+type Loader[K comparable, V any] struct {
+	fetch    FetchFunc[K, V]
+	wait     time.Duration
+	maxBatch int
+
+	mu    sync.Mutex
+	cache map[K]*result[V]
+	batch *batch[K, V]
+}
+
+type result[V any] struct {
+	done  chan struct{}
+	value V
+	err   error
+}
+
+type batch[K comparable, V any] struct {
+	keys    []K
+	results []*result[V]
+	timer   *time.Timer
+}
+
+func New[K comparable, V any](fetch FetchFunc[K, V], wait time.Duration, maxBatch int) *Loader[K, V] {
+	return &Loader[K, V]{
+		fetch:    fetch,
+		wait:     wait,
+		maxBatch: maxBatch,
+		cache:    make(map[K]*result[V]),
+	}
+}
+
+// Load returns the value for key, waiting for the batch it joins to be fetched
+func (l *Loader[K, V]) Load(ctx context.Context, key K) (V, error) {
+	l.mu.Lock()
+
+	res, ok := l.cache[key]
+	if !ok {
+		res = &result[V]{done: make(chan struct{})}
+		l.cache[key] = res
+		l.enqueue(ctx, key, res)
+	}
+
+	l.mu.Unlock()
+
+	select {
+	case <-res.done:
+		return res.value, res.err
+	case <-ctx.Done():
+		var zero V
+		return zero, ctx.Err()
+	}
+}
+
+// enqueue adds key to the pending batch, starting one if needed. It must be
+// called with l.mu held.
+func (l *Loader[K, V]) enqueue(ctx context.Context, key K, res *result[V]) {
+	if l.batch == nil {
+		b := &batch[K, V]{}
+		b.timer = time.AfterFunc(l.wait, func() {
+			l.mu.Lock()
+			if l.batch == b {
+				l.batch = nil
+			}
+			l.mu.Unlock()
+
+			l.dispatch(ctx, b)
+		})
+		l.batch = b
+	}
+
+	b := l.batch
+	b.keys = append(b.keys, key)
+	b.results = append(b.results, res)
+
+	if l.maxBatch > 0 && len(b.keys) >= l.maxBatch && b.timer.Stop() {
+		l.batch = nil
+		go l.dispatch(ctx, b)
+	}
+}
+
+func (l *Loader[K, V]) dispatch(ctx context.Context, b *batch[K, V]) {
+	values, err := l.fetch(context.WithoutCancel(ctx), b.keys)
+
+	for i, key := range b.keys {
+		res := b.results[i]
+
+		switch value, ok := values[key]; {
+		case err != nil:
+			res.err = err
+		case !ok:
+			res.err = ErrNotFound
+		default:
+			res.value = value
+		}
+
+		close(res.done)
+	}
+
+	if err != nil {
+		// Forget failed keys so a later load in the same operation can retry
+		l.mu.Lock()
+		for i, key := range b.keys {
+			if l.cache[key] == b.results[i] {
+				delete(l.cache, key)
+			}
+		}
+		l.mu.Unlock()
+	}
+}
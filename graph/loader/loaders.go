@@ -0,0 +1,95 @@
+package loader
+
+import (
+	"context"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/services"
+)
+
+const (
+	batchWait    = 2 * time.Millisecond
+	maxBatchSize = 100
+)
+
+type loadersKey struct{}
+
+// Loaders holds the dataloaders for one GraphQL operation
+type Loaders struct {
+	Products   *Loader[uint, *dto.ProductResponse]
+	Categories *Loader[uint, *dto.CategoryResponse]
+	Images     *Loader[uint, []dto.ProductImageResponse]
+	Users      *Loader[uint, *dto.UserResponse]
+}
+
+func NewLoaders(productService services.ProductServiceInterface, userService services.UserServiceInterface) *Loaders {
+	return &Loaders{
+		Products: New(func(ctx context.Context, ids []uint) (map[uint]*dto.ProductResponse, error) {
+			products, err := productService.GetProductsByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[uint]*dto.ProductResponse, len(products))
+			for i := range products {
+				result[products[i].ID] = &products[i]
+			}
+			return result, nil
+		}, batchWait, maxBatchSize),
+
+		Categories: New(func(ctx context.Context, ids []uint) (map[uint]*dto.CategoryResponse, error) {
+			categories, err := productService.GetCategoriesByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[uint]*dto.CategoryResponse, len(categories))
+			for i := range categories {
+				result[categories[i].ID] = &categories[i]
+			}
+			return result, nil
+		}, batchWait, maxBatchSize),
+
+		Images: New(func(ctx context.Context, productIDs []uint) (map[uint][]dto.ProductImageResponse, error) {
+			images, err := productService.GetImagesByProductIDs(productIDs)
+			if err != nil {
+				return nil, err
+			}
+
+			// A product without images is not an error
+			result := make(map[uint][]dto.ProductImageResponse, len(productIDs))
+			for _, id := range productIDs {
+				result[id] = images[id]
+				if result[id] == nil {
+					result[id] = []dto.ProductImageResponse{}
+				}
+			}
+			return result, nil
+		}, batchWait, maxBatchSize),
+
+		Users: New(func(ctx context.Context, ids []uint) (map[uint]*dto.UserResponse, error) {
+			users, err := userService.GetUsersByIDs(ids)
+			if err != nil {
+				return nil, err
+			}
+
+			result := make(map[uint]*dto.UserResponse, len(users))
+			for i := range users {
+				result[users[i].ID] = &users[i]
+			}
+			return result, nil
+		}, batchWait, maxBatchSize),
+	}
+}
+
+// WithLoaders attaches loaders to ctx for the duration of a GraphQL operation
+func WithLoaders(ctx context.Context, loaders *Loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, loaders)
+}
+
+// FromContext returns the loaders attached to ctx, or nil if there are none
+func FromContext(ctx context.Context) *Loaders {
+	loaders, _ := ctx.Value(loadersKey{}).(*Loaders)
+	return loaders
+}
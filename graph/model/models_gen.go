@@ -3,9 +3,41 @@
 package model
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
 )
 
+type AdminOrderConnection struct {
+	Edges    []*AdminOrderEdge `json:"edges"`
+	PageInfo *PageInfo         `json:"pageInfo"`
+}
+
+type AdminOrderEdge struct {
+	Node *dto.OrderResponse `json:"node"`
+}
+
+type AdminUserConnection struct {
+	Edges    []*AdminUserEdge `json:"edges"`
+	PageInfo *PageInfo        `json:"pageInfo"`
+}
+
+type AdminUserEdge struct {
+	Node *dto.AdminUserResponse `json:"node"`
+}
+
+type AuditLogConnection struct {
+	Edges    []*AuditLogEdge `json:"edges"`
+	PageInfo *PageInfo       `json:"pageInfo"`
+}
+
+type AuditLogEdge struct {
+	Node *dto.AuditLogResponse `json:"node"`
+}
+
 type Mutation struct {
 }
 
@@ -15,14 +47,20 @@ type OrderConnection struct {
 }
 
 type OrderEdge struct {
-	Node *dto.OrderResponse `json:"node"`
+	Cursor string             `json:"cursor"`
+	Node   *dto.OrderResponse `json:"node"`
 }
 
+// page and total_pages are null when paging by cursor
 type PageInfo struct {
-	Page       int `json:"page"`
-	Limit      int `json:"limit"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Page            *int    `json:"page,omitempty"`
+	Limit           int     `json:"limit"`
+	Total           int     `json:"total"`
+	TotalPages      *int    `json:"total_pages,omitempty"`
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
 }
 
 type ProductConnection struct {
@@ -31,8 +69,97 @@ type ProductConnection struct {
 }
 
 type ProductEdge struct {
+	Cursor string               `json:"cursor"`
+	Node   *dto.ProductResponse `json:"node"`
+}
+
+type ProductFilterInput struct {
+	CategoryID *string        `json:"categoryId,omitempty"`
+	MinPrice   *float64       `json:"minPrice,omitempty"`
+	MaxPrice   *float64       `json:"maxPrice,omitempty"`
+	InStock    *bool          `json:"inStock,omitempty"`
+	Attributes dto.Attributes `json:"attributes,omitempty"`
+}
+
+type ProductSearchConnection struct {
+	Edges    []*ProductSearchEdge `json:"edges"`
+	PageInfo *PageInfo            `json:"pageInfo"`
+	// Counts over all matching products. Each facet ignores the filter on its own dimension.
+	Facets *dto.SearchFacets `json:"facets"`
+	// Identifies the logged search, for recordSearchClick and addToCart. Set on first pages, and on later pages given a valid searchId.
+	SearchID *string `json:"search_id,omitempty"`
+}
+
+type ProductSearchEdge struct {
 	Node *dto.ProductResponse `json:"node"`
+	Rank float64              `json:"rank"`
+	// Set for products pinned to the query, which come before ranked results
+	Pinned bool `json:"pinned"`
 }
 
 type Query struct {
 }
+
+type Subscription struct {
+}
+
+type ProductSort string
+
+const (
+	ProductSortPriceAsc    ProductSort = "PRICE_ASC"
+	ProductSortPriceDesc   ProductSort = "PRICE_DESC"
+	ProductSortNewest      ProductSort = "NEWEST"
+	ProductSortName        ProductSort = "NAME"
+	ProductSortBestSelling ProductSort = "BEST_SELLING"
+)
+
+var AllProductSort = []ProductSort{
+	ProductSortPriceAsc,
+	ProductSortPriceDesc,
+	ProductSortNewest,
+	ProductSortName,
+	ProductSortBestSelling,
+}
+
+func (e ProductSort) IsValid() bool {
+	switch e {
+	case ProductSortPriceAsc, ProductSortPriceDesc, ProductSortNewest, ProductSortName, ProductSortBestSelling:
+		return true
+	}
+	return false
+}
+
+func (e ProductSort) String() string {
+	return string(e)
+}
+
+func (e *ProductSort) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = ProductSort(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid ProductSort", str)
+	}
+	return nil
+}
+
+func (e ProductSort) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *ProductSort) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e ProductSort) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,80 @@
+package resolver
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/graph"
+	"github.com/abhilashdk2016/golang-ecommerce/graph/model"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+)
+
+// NewComplexityRoot weights paginated lists by the requested limit and other
+// lists by listSize, so the cost of nested collections grows with the number
+// of rows they can return
+func NewComplexityRoot(listSize int) graph.ComplexityRoot {
+	var c graph.ComplexityRoot
+
+	paginated := func(childComplexity int, limit *int) int {
+		size := 10
+		if limit != nil && *limit > 0 {
+			size = *limit
+		}
+		return 1 + childComplexity*size
+	}
+	list := func(childComplexity int) int {
+		return 1 + childComplexity*listSize
+	}
+
+	connection := func(childComplexity int, limit, first, last *int) int {
+		switch {
+		case first != nil:
+			return paginated(childComplexity, first)
+		case last != nil:
+			return paginated(childComplexity, last)
+		}
+		return paginated(childComplexity, limit)
+	}
+
+	c.Query.Products = func(childComplexity int, page *int, limit *int, first *int, after *string, last *int, before *string, sort *model.ProductSort, filter *model.ProductFilterInput) int {
+		return connection(childComplexity, limit, first, last)
+	}
+	c.Query.Orders = func(childComplexity int, page *int, limit *int, first *int, after *string, last *int, before *string) int {
+		return connection(childComplexity, limit, first, last)
+	}
+	c.Query.Users = func(childComplexity int, query *string, role *string, isActive *bool, page *int, limit *int) int {
+		return paginated(childComplexity, limit)
+	}
+	c.Query.SearchProducts = func(childComplexity int, query string, categoryID *string, minPrice *float64, maxPrice *float64, inStock *bool, attributes dto.Attributes, page *int, limit *int, searchID *string) int {
+		return paginated(childComplexity, limit)
+	}
+	c.Query.SearchSuggestions = func(childComplexity int, query string, limit *int) int {
+		return paginated(childComplexity, limit)
+	}
+	c.Query.AuditLogs = func(childComplexity int, actorID *string, action *string, entityType *string, entityID *string, from *time.Time, to *time.Time, page *int, limit *int) int {
+		return paginated(childComplexity, limit)
+	}
+	c.Query.SearchReport = func(childComplexity int, from *time.Time, to *time.Time, limit *int) int {
+		return paginated(childComplexity, limit)
+	}
+	c.Query.APIKeys = func(childComplexity int, userID *string) int {
+		return list(childComplexity)
+	}
+	c.Query.Categories = list
+	c.Query.Roles = list
+	c.Query.UserRoles = func(childComplexity int, userID string) int {
+		return list(childComplexity)
+	}
+	c.Query.SearchSynonymSets = list
+	c.Query.ProductSearchBoosts = list
+	c.Query.SearchPins = func(childComplexity int, query *string) int {
+		return list(childComplexity)
+	}
+
+	c.Product.Images = list
+	c.Cart.CartItems = list
+	c.Order.OrderItems = list
+	c.UserDataExport.Orders = list
+	c.UserDataExport.LinkedAccounts = list
+
+	return c
+}
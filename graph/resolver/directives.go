@@ -0,0 +1,108 @@
+package resolver
+
+import (
+	"context"
+	"slices"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+)
+
+var (
+	errTwoFactorRequired = apperror.Forbidden("two-factor authentication required for staff access")
+	errSessionRequired   = apperror.Forbidden("API keys cannot be used for this operation")
+	errImpersonating     = apperror.Forbidden("not allowed while impersonating")
+)
+
+// Auth implements the @auth directive. The field resolves only for
+// authenticated requests.
+func (r *Resolver) Auth(ctx context.Context, obj any, next graphql.Resolver) (any, error) {
+	if _, err := GetUserIDFromContext(ctx); err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	return next(ctx)
+}
+
+// Session implements the @session directive. Like @auth it requires an
+// authenticated request, but API keys and impersonation tokens are rejected.
+func (r *Resolver) Session(ctx context.Context, obj any, next graphql.Resolver) (any, error) {
+	if _, err := GetUserIDFromContext(ctx); err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	if _, ok := ctx.Value(utils.APIKeyScopesKey).([]string); ok {
+		return nil, errSessionRequired
+	}
+
+	if IsImpersonatingFromContext(ctx) {
+		return nil, errImpersonating
+	}
+
+	return next(ctx)
+}
+
+// HasRole implements the @hasRole directive. Like @hasPermission it is never
+// satisfied by impersonation tokens or before required two-factor
+// authentication. Roles are loaded once per operation.
+func (r *Resolver) HasRole(ctx context.Context, obj any, next graphql.Resolver, role string) (any, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	if IsImpersonatingFromContext(ctx) {
+		return nil, ErrForbidden
+	}
+
+	roles, err := r.userRoleNames(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !slices.Contains(roles, role) {
+		return nil, ErrForbidden
+	}
+
+	if pending, _ := ctx.Value(utils.TwoFactorPendingKey).(bool); pending {
+		return nil, errTwoFactorRequired
+	}
+
+	return next(ctx)
+}
+
+// HasPermission implements the @hasPermission directive. The field resolves
+// only when one of the user's roles grants the permission, the API key (if
+// any) is scoped for it and the session has completed any required two-factor
+// authentication.
+func (r *Resolver) HasPermission(ctx context.Context, obj any, next graphql.Resolver, permission string) (any, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	// Impersonation tokens never carry staff permissions
+	if IsImpersonatingFromContext(ctx) {
+		return nil, ErrForbidden
+	}
+
+	allowed, err := r.rbacService.HasPermission(userID, permission)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, ErrForbidden
+	}
+
+	if scopes, ok := ctx.Value(utils.APIKeyScopesKey).([]string); ok && !slices.Contains(scopes, permission) {
+		return nil, ErrForbidden
+	}
+
+	if pending, _ := ctx.Value(utils.TwoFactorPendingKey).(bool); pending {
+		return nil, errTwoFactorRequired
+	}
+
+	return next(ctx)
+}
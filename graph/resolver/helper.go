@@ -2,18 +2,73 @@ package resolver
 
 import (
 	"context"
-	"errors"
-
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/abhilashdk2016/golang-ecommerce/graph/loader"
+	"github.com/abhilashdk2016/golang-ecommerce/graph/model"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
 )
 
 var (
-	ErrUnauthorized = errors.New("unauthorized")
+	ErrUnauthorized = apperror.Unauthorized("unauthorized")
+	ErrForbidden    = apperror.Forbidden("forbidden")
 )
 
-const (
-	adminRole = "admin"
-)
+type roleCacheKey struct{}
+
+type roleCache struct {
+	once  sync.Once
+	roles []string
+	err   error
+}
+
+// WithRoleCache prepares ctx to cache the user's roles for the duration of a
+// GraphQL operation, since @hasRole fields may be resolved many times and
+// concurrently within one response
+func WithRoleCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, roleCacheKey{}, &roleCache{})
+}
+
+func (r *Resolver) userRoleNames(ctx context.Context, userID uint) ([]string, error) {
+	load := func() ([]string, error) {
+		roles, err := r.rbacService.GetUserRoles(userID)
+		if err != nil {
+			return nil, err
+		}
+
+		names := make([]string, len(roles))
+		for i, role := range roles {
+			names[i] = role.Name
+		}
+		return names, nil
+	}
+
+	cache, ok := ctx.Value(roleCacheKey{}).(*roleCache)
+	if !ok {
+		return load()
+	}
+
+	cache.once.Do(func() {
+		cache.roles, cache.err = load()
+	})
+	return cache.roles, cache.err
+}
+
+// loaders returns the dataloaders for the current operation. Outside of an
+// operation prepared by the server a fresh set is returned, which still works
+// but batches nothing across fields.
+func (r *Resolver) loaders(ctx context.Context) *loader.Loaders {
+	if loaders := loader.FromContext(ctx); loaders != nil {
+		return loaders
+	}
+
+	return loader.NewLoaders(r.productService, r.userService)
+}
 
 // GetUserIDFromContext functions to extract user info from GraphQL context
 func GetUserIDFromContext(ctx context.Context) (uint, error) {
@@ -42,13 +97,19 @@ func GetUserRoleFromContext(ctx context.Context) (string, error) {
 	return "", ErrUnauthorized
 }
 
-func IsAdminFromContext(ctx context.Context) bool {
-	role, err := GetUserRoleFromContext(ctx)
-	if err != nil {
-		return false
+// IsImpersonatingFromContext reports whether the request uses an impersonation token
+func IsImpersonatingFromContext(ctx context.Context) bool {
+	impersonatorID, _ := ctx.Value(utils.ImpersonatorIDKey).(uint)
+	return impersonatorID != 0
+}
+
+// GetClientIPFromContext returns the client IP of the HTTP request behind a GraphQL operation
+func GetClientIPFromContext(ctx context.Context) string {
+	if c, ok := ctx.Value(utils.GinContextKey).(*gin.Context); ok {
+		return c.ClientIP()
 	}
 
-	return role == adminRole
+	return ""
 }
 
 func getPagingNumbers(page, limit *int) (pageNumber, pageLimit int) {
@@ -72,3 +133,89 @@ func getPagingNumbers(page, limit *int) (pageNumber, pageLimit int) {
 
 	return p, l
 }
+
+// cursorRequest returns the keyset page request for the given arguments, or
+// nil when none are set and the caller should page by number
+func cursorRequest(first *int, after *string, last *int, before *string) *dto.CursorPageRequest {
+	if first == nil && after == nil && last == nil && before == nil {
+		return nil
+	}
+
+	req := &dto.CursorPageRequest{}
+	if first != nil {
+		req.First = *first
+	}
+	if after != nil {
+		req.After = *after
+	}
+	if last != nil {
+		req.Last = *last
+	}
+	if before != nil {
+		req.Before = *before
+	}
+
+	return req
+}
+
+// productListRequest converts the products query arguments into a listing
+// request. Enum values map to the REST sort keys by lowercasing.
+func (r *Resolver) productListRequest(sort *model.ProductSort, filter *model.ProductFilterInput) (*dto.ProductListRequest, error) {
+	req := &dto.ProductListRequest{}
+	if sort != nil {
+		req.Sort = strings.ToLower(string(*sort))
+	}
+
+	if filter == nil {
+		return req, nil
+	}
+
+	if filter.CategoryID != nil {
+		id, err := r.parseID(*filter.CategoryID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category ID: %w", err)
+		}
+		req.CategoryID = &id
+	}
+	req.MinPrice = filter.MinPrice
+	req.MaxPrice = filter.MaxPrice
+	req.InStock = filter.InStock != nil && *filter.InStock
+	req.Attributes = filter.Attributes
+
+	return req, nil
+}
+
+func pageInfo(meta *utils.PaginationMeta) *model.PageInfo {
+	return &model.PageInfo{
+		Page:            &meta.Page,
+		Limit:           meta.Limit,
+		Total:           int(meta.Total),
+		TotalPages:      &meta.TotalPages,
+		HasNextPage:     meta.Page < meta.TotalPages,
+		HasPreviousPage: meta.Page > 1,
+	}
+}
+
+func cursorPageInfo(meta *utils.CursorMeta) *model.PageInfo {
+	info := &model.PageInfo{
+		Limit:           meta.Limit,
+		Total:           int(meta.Total),
+		HasNextPage:     meta.HasNextPage,
+		HasPreviousPage: meta.HasPreviousPage,
+	}
+	if meta.StartCursor != "" {
+		info.StartCursor = &meta.StartCursor
+		info.EndCursor = &meta.EndCursor
+	}
+
+	return info
+}
+
+func rolePointers(roles []dto.RoleResponse) []*dto.RoleResponse {
+	result := make([]*dto.RoleResponse, len(roles))
+	for i := range roles {
+		result[i] = &roles[i]
+	}
+
+	return result
+}
@@ -3,6 +3,7 @@ package resolver
 import (
 	"strconv"
 
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/services"
 )
 
@@ -11,30 +12,58 @@ import (
 // It serves as dependency injection for your app, add any dependencies you require here.
 
 type Resolver struct {
-	authService    services.AuthServiceInterface
-	userService    services.UserServiceInterface
-	productService services.ProductServiceInterface
-	cartService    services.CartServiceInterface
-	orderService   services.OrderServiceInterface
+	authService            services.AuthServiceInterface
+	oidcService            services.OIDCServiceInterface
+	rbacService            services.RBACServiceInterface
+	apiKeyService          services.APIKeyServiceInterface
+	auditService           services.AuditServiceInterface
+	privacyService         services.PrivacyServiceInterface
+	userService            services.UserServiceInterface
+	productService         services.ProductServiceInterface
+	uploadService          services.UploadServiceInterface
+	cartService            services.CartServiceInterface
+	orderService           services.OrderServiceInterface
+	searchAnalyticsService services.SearchAnalyticsServiceInterface
+	searchTuningService    services.SearchTuningServiceInterface
 }
 
 func NewResolver(authService services.AuthServiceInterface,
+	oidcService services.OIDCServiceInterface,
+	rbacService services.RBACServiceInterface,
+	apiKeyService services.APIKeyServiceInterface,
+	auditService services.AuditServiceInterface,
+	privacyService services.PrivacyServiceInterface,
 	userService services.UserServiceInterface,
 	productService services.ProductServiceInterface,
+	uploadService services.UploadServiceInterface,
 	cartService services.CartServiceInterface,
-	orderService services.OrderServiceInterface) *Resolver {
+	orderService services.OrderServiceInterface,
+	searchAnalyticsService services.SearchAnalyticsServiceInterface,
+	searchTuningService services.SearchTuningServiceInterface) *Resolver {
 
 	return &Resolver{
-		authService:    authService,
-		userService:    userService,
-		productService: productService,
-		cartService:    cartService,
-		orderService:   orderService,
+		authService:            authService,
+		oidcService:            oidcService,
+		rbacService:            rbacService,
+		apiKeyService:          apiKeyService,
+		auditService:           auditService,
+		privacyService:         privacyService,
+		userService:            userService,
+		productService:         productService,
+		uploadService:          uploadService,
+		cartService:            cartService,
+		orderService:           orderService,
+		searchAnalyticsService: searchAnalyticsService,
+		searchTuningService:    searchTuningService,
 	}
 
 }
 
 func (r *Resolver) parseID(id string) (uint, error) {
 	parsed, err := strconv.ParseUint(id, 10, 32)
-	return uint(parsed), err
+	if err != nil {
+		return 0, apperror.Wrap(apperror.CodeInvalidInput, "malformed ID", err)
+	}
+
+	return uint(parsed), nil
 }
@@ -7,14 +7,21 @@ package resolver
 import (
 	"context"
 	"fmt"
+	"log"
+	"slices"
+	"time"
 
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/abhilashdk2016/golang-ecommerce/graph"
 	"github.com/abhilashdk2016/golang-ecommerce/graph/model"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/services"
 )
 
 type mutationResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
+type subscriptionResolver struct{ *Resolver }
 
 // Register is the resolver for the register field.
 func (r *mutationResolver) Register(ctx context.Context, input dto.RegisterRequest) (*dto.AuthResponse, error) {
@@ -27,9 +34,18 @@ func (r *mutationResolver) Register(ctx context.Context, input dto.RegisterReque
 
 // Login is the resolver for the login field.
 func (r *mutationResolver) Login(ctx context.Context, input dto.LoginRequest) (*dto.AuthResponse, error) {
-	response, err := r.authService.Login(&input)
+	response, err := r.authService.Login(ctx, &input, GetClientIPFromContext(ctx))
 	if err != nil {
-		return nil, fmt.Errorf("registration failed: %w", err)
+		return nil, fmt.Errorf("login failed: %w", err)
+	}
+	return response, nil
+}
+
+// VerifyTwoFactorLogin is the resolver for the verifyTwoFactorLogin field.
+func (r *mutationResolver) VerifyTwoFactorLogin(ctx context.Context, input dto.TwoFactorLoginRequest) (*dto.AuthResponse, error) {
+	response, err := r.authService.VerifyTwoFactorLogin(ctx, &input)
+	if err != nil {
+		return nil, fmt.Errorf("two-factor login failed: %w", err)
 	}
 	return response, nil
 }
@@ -38,7 +54,7 @@ func (r *mutationResolver) Login(ctx context.Context, input dto.LoginRequest) (*
 func (r *mutationResolver) RefreshToken(ctx context.Context, input dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
 	response, err := r.authService.RefreshToken(&input)
 	if err != nil {
-		return nil, fmt.Errorf("registration failed: %w", err)
+		return nil, fmt.Errorf("token refresh failed: %w", err)
 	}
 	return response, nil
 }
@@ -47,11 +63,39 @@ func (r *mutationResolver) RefreshToken(ctx context.Context, input dto.RefreshTo
 func (r *mutationResolver) Logout(ctx context.Context, input dto.RefreshTokenRequest) (bool, error) {
 	err := r.authService.Logout(input.RefreshToken)
 	if err != nil {
-		return false, fmt.Errorf("registration failed: %w", err)
+		return false, fmt.Errorf("logout failed: %w", err)
+	}
+	return true, nil
+}
+
+// VerifyEmail is the resolver for the verifyEmail field.
+func (r *mutationResolver) VerifyEmail(ctx context.Context, input dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	user, err := r.authService.VerifyEmail(&input)
+	if err != nil {
+		return nil, fmt.Errorf("email verification failed: %w", err)
+	}
+	return user, nil
+}
+
+// ResendVerificationEmail is the resolver for the resendVerificationEmail field.
+func (r *mutationResolver) ResendVerificationEmail(ctx context.Context, input dto.ResendVerificationRequest) (bool, error) {
+	err := r.authService.ResendVerificationEmail(&input)
+	if err != nil {
+		return false, fmt.Errorf("failed to send verification email: %w", err)
 	}
 	return true, nil
 }
 
+// ConfirmEmailChange is the resolver for the confirmEmailChange field.
+func (r *mutationResolver) ConfirmEmailChange(ctx context.Context, input dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	user, err := r.authService.ConfirmEmailChange(ctx, &input)
+	if err != nil {
+		return nil, fmt.Errorf("email change failed: %w", err)
+	}
+
+	return user, nil
+}
+
 // UpdateProfile is the resolver for the updateProfile field.
 func (r *mutationResolver) UpdateProfile(ctx context.Context, input dto.UpdateProfileRequest) (*dto.UserResponse, error) {
 	userId, err := GetUserIDFromContext(ctx)
@@ -66,13 +110,273 @@ func (r *mutationResolver) UpdateProfile(ctx context.Context, input dto.UpdatePr
 	return user, nil
 }
 
-// CreateCategory is the resolver for the createCategory field.
-func (r *mutationResolver) CreateCategory(ctx context.Context, input dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
-	if !IsAdminFromContext(ctx) {
+// ChangePassword is the resolver for the changePassword field.
+func (r *mutationResolver) ChangePassword(ctx context.Context, input dto.ChangePasswordRequest) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	if err := r.authService.ChangePassword(ctx, userID, &input); err != nil {
+		return false, fmt.Errorf("failed to change password: %w", err)
+	}
+
+	return true, nil
+}
+
+// ChangeEmail is the resolver for the changeEmail field.
+func (r *mutationResolver) ChangeEmail(ctx context.Context, input dto.ChangeEmailRequest) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	if err := r.authService.RequestEmailChange(userID, &input); err != nil {
+		return false, fmt.Errorf("failed to change email: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetupTwoFactor is the resolver for the setupTwoFactor field.
+func (r *mutationResolver) SetupTwoFactor(ctx context.Context) (*dto.TwoFactorSetupResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	response, err := r.authService.SetupTwoFactor(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up two-factor authentication: %w", err)
+	}
+
+	return response, nil
+}
+
+// EnableTwoFactor is the resolver for the enableTwoFactor field.
+func (r *mutationResolver) EnableTwoFactor(ctx context.Context, input dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	response, err := r.authService.EnableTwoFactor(ctx, userID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	return response, nil
+}
+
+// DisableTwoFactor is the resolver for the disableTwoFactor field.
+func (r *mutationResolver) DisableTwoFactor(ctx context.Context, input dto.TwoFactorCodeRequest) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	if err := r.authService.DisableTwoFactor(ctx, userID, &input); err != nil {
+		return false, fmt.Errorf("failed to disable two-factor authentication: %w", err)
+	}
+
+	return true, nil
+}
+
+// RegenerateRecoveryCodes is the resolver for the regenerateRecoveryCodes field.
+func (r *mutationResolver) RegenerateRecoveryCodes(ctx context.Context, input dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	response, err := r.authService.RegenerateRecoveryCodes(userID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate recovery codes: %w", err)
+	}
+
+	return response, nil
+}
+
+// DeleteAccount is the resolver for the deleteAccount field.
+func (r *mutationResolver) DeleteAccount(ctx context.Context, input dto.DeleteAccountRequest) (bool, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return false, ErrUnauthorized
+	}
+
+	if err := r.privacyService.RequestAccountDeletion(ctx, userID, &input); err != nil {
+		return false, fmt.Errorf("failed to delete account: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetUserActive is the resolver for the setUserActive field.
+func (r *mutationResolver) SetUserActive(ctx context.Context, id string, isActive bool) (*dto.AdminUserResponse, error) {
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	userID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := r.userService.SetUserActive(ctx, actorID, userID, &dto.UpdateUserStatusRequest{IsActive: &isActive})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user status: %w", err)
+	}
+
+	return user, nil
+}
+
+// SetServiceAccount is the resolver for the setServiceAccount field.
+func (r *mutationResolver) SetServiceAccount(ctx context.Context, id string, isServiceAccount bool) (*dto.AdminUserResponse, error) {
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	userID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := r.userService.SetServiceAccount(ctx, actorID, userID, &dto.UpdateServiceAccountRequest{IsServiceAccount: &isServiceAccount})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	return user, nil
+}
+
+// UnlockUser is the resolver for the unlockUser field.
+func (r *mutationResolver) UnlockUser(ctx context.Context, id string) (bool, error) {
+	userID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	if err := r.authService.UnlockAccount(ctx, userID); err != nil {
+		return false, fmt.Errorf("failed to unlock user: %w", err)
+	}
+
+	return true, nil
+}
+
+// ImpersonateUser is the resolver for the impersonateUser field.
+func (r *mutationResolver) ImpersonateUser(ctx context.Context, id, reason string) (*dto.ImpersonationResponse, error) {
+	adminID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	userID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	response, err := r.authService.ImpersonateUser(ctx, adminID, userID, &dto.ImpersonateRequest{Reason: reason}, GetClientIPFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to impersonate user: %w", err)
+	}
+
+	return response, nil
+}
+
+// SetUserRoles is the resolver for the setUserRoles field.
+func (r *mutationResolver) SetUserRoles(ctx context.Context, userID string, roles []string) ([]*dto.RoleResponse, error) {
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	result, err := r.rbacService.SetUserRoles(ctx, actorID, id, &dto.SetUserRolesRequest{Roles: roles})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update roles: %w", err)
+	}
+
+	return rolePointers(result), nil
+}
+
+// AssignRole is the resolver for the assignRole field.
+func (r *mutationResolver) AssignRole(ctx context.Context, userID, role string) ([]*dto.RoleResponse, error) {
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	roles, err := r.rbacService.AssignRole(ctx, actorID, id, &dto.AssignRoleRequest{Role: role})
+	if err != nil {
+		return nil, fmt.Errorf("failed to assign role: %w", err)
+	}
+
+	return rolePointers(roles), nil
+}
+
+// RemoveRole is the resolver for the removeRole field.
+func (r *mutationResolver) RemoveRole(ctx context.Context, userID, role string) ([]*dto.RoleResponse, error) {
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	roles, err := r.rbacService.RemoveRole(ctx, actorID, id, role)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove role: %w", err)
+	}
+
+	return rolePointers(roles), nil
+}
+
+// CreateAPIKey is the resolver for the createAPIKey field.
+func (r *mutationResolver) CreateAPIKey(ctx context.Context, input dto.CreateAPIKeyRequest) (*dto.CreatedAPIKeyResponse, error) {
+	actorID, err := GetUserIDFromContext(ctx)
+	if err != nil {
 		return nil, ErrUnauthorized
 	}
 
-	category, err := r.productService.CreateCategory(&input)
+	key, err := r.apiKeyService.CreateAPIKey(ctx, actorID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create API key: %w", err)
+	}
+
+	return key, nil
+}
+
+// RevokeAPIKey is the resolver for the revokeAPIKey field.
+func (r *mutationResolver) RevokeAPIKey(ctx context.Context, id string) (bool, error) {
+	keyID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid API key ID: %w", err)
+	}
+
+	if err := r.apiKeyService.RevokeAPIKey(ctx, keyID); err != nil {
+		return false, fmt.Errorf("failed to revoke API key: %w", err)
+	}
+
+	return true, nil
+}
+
+// CreateCategory is the resolver for the createCategory field.
+func (r *mutationResolver) CreateCategory(ctx context.Context, input dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+	category, err := r.productService.CreateCategory(ctx, &input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create category: %w", err)
 	}
@@ -82,16 +386,12 @@ func (r *mutationResolver) CreateCategory(ctx context.Context, input dto.CreateC
 
 // UpdateCategory is the resolver for the updateCategory field.
 func (r *mutationResolver) UpdateCategory(ctx context.Context, id string, input dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
-	if !IsAdminFromContext(ctx) {
-		return nil, ErrUnauthorized
-	}
-
 	categoryId, err := r.parseID(id)
 	if err != nil {
 		return nil, fmt.Errorf("invalid category id: %w", err)
 	}
 
-	category, err := r.productService.UpdateCategory(categoryId, &input)
+	category, err := r.productService.UpdateCategory(ctx, categoryId, &input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update category: %w", err)
 	}
@@ -101,16 +401,12 @@ func (r *mutationResolver) UpdateCategory(ctx context.Context, id string, input
 
 // DeleteCategory is the resolver for the deleteCategory field.
 func (r *mutationResolver) DeleteCategory(ctx context.Context, id string) (bool, error) {
-	if !IsAdminFromContext(ctx) {
-		return false, ErrUnauthorized
-	}
-
 	categoryId, err := r.parseID(id)
 	if err != nil {
 		return false, fmt.Errorf("invalid category id: %w", err)
 	}
 
-	err = r.productService.DeleteCategory(categoryId)
+	err = r.productService.DeleteCategory(ctx, categoryId)
 	if err != nil {
 		return false, fmt.Errorf("failed to update category: %w", err)
 	}
@@ -120,11 +416,7 @@ func (r *mutationResolver) DeleteCategory(ctx context.Context, id string) (bool,
 
 // CreateProduct is the resolver for the createProduct field.
 func (r *mutationResolver) CreateProduct(ctx context.Context, input dto.CreateProductRequest) (*dto.ProductResponse, error) {
-	if !IsAdminFromContext(ctx) {
-		return nil, ErrUnauthorized
-	}
-
-	product, err := r.productService.CreateProduct(&input)
+	product, err := r.productService.CreateProduct(ctx, &input)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create product: %w", err)
 	}
@@ -134,37 +426,150 @@ func (r *mutationResolver) CreateProduct(ctx context.Context, input dto.CreatePr
 
 // UpdateProduct is the resolver for the updateProduct field.
 func (r *mutationResolver) UpdateProduct(ctx context.Context, id string, input dto.UpdateProductRequest) (*dto.ProductResponse, error) {
-	if !IsAdminFromContext(ctx) {
-		return nil, ErrUnauthorized
+	productID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	product, err := r.productService.UpdateProduct(ctx, productID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product: %w", err)
+	}
+
+	return product, nil
+}
+
+// DeleteProduct is the resolver for the deleteProduct field.
+func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (bool, error) {
+	productID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	err = r.productService.DeleteProduct(ctx, productID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete product: %w", err)
+	}
+
+	return true, nil
+}
+
+// UploadProductImage is the resolver for the uploadProductImage field.
+func (r *mutationResolver) UploadProductImage(ctx context.Context, productID string, file graphql.Upload) (*dto.ProductResponse, error) {
+	id, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	url, err := r.uploadService.UploadProductImage(id, file.Filename, file.File)
+	if err != nil {
+		return nil, fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	if err := r.productService.AddProductImage(ctx, id, url, file.Filename); err != nil {
+		return nil, fmt.Errorf("failed to save image record: %w", err)
+	}
+
+	product, err := r.productService.FindProduct(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
 
-	productID, err := r.parseID(id)
+	return product, nil
+}
+
+// CreateSearchSynonymSet is the resolver for the createSearchSynonymSet field.
+func (r *mutationResolver) CreateSearchSynonymSet(ctx context.Context, input dto.SynonymSetRequest) (*dto.SynonymSetResponse, error) {
+	set, err := r.searchTuningService.CreateSynonymSet(ctx, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create synonym set: %w", err)
+	}
+
+	return set, nil
+}
+
+// UpdateSearchSynonymSet is the resolver for the updateSearchSynonymSet field.
+func (r *mutationResolver) UpdateSearchSynonymSet(ctx context.Context, id string, input dto.SynonymSetRequest) (*dto.SynonymSetResponse, error) {
+	setID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid synonym set ID: %w", err)
+	}
+
+	set, err := r.searchTuningService.UpdateSynonymSet(ctx, setID, &input)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update synonym set: %w", err)
+	}
+
+	return set, nil
+}
+
+// DeleteSearchSynonymSet is the resolver for the deleteSearchSynonymSet field.
+func (r *mutationResolver) DeleteSearchSynonymSet(ctx context.Context, id string) (bool, error) {
+	setID, err := r.parseID(id)
+	if err != nil {
+		return false, fmt.Errorf("invalid synonym set ID: %w", err)
+	}
+
+	if err := r.searchTuningService.DeleteSynonymSet(ctx, setID); err != nil {
+		return false, fmt.Errorf("failed to delete synonym set: %w", err)
+	}
+
+	return true, nil
+}
+
+// SetProductSearchBoost is the resolver for the setProductSearchBoost field.
+func (r *mutationResolver) SetProductSearchBoost(ctx context.Context, productID string, boost float64) (*dto.ProductBoostResponse, error) {
+	productIDValue, err := r.parseID(productID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid product ID: %w", err)
+	}
+
+	result, err := r.searchTuningService.SetProductBoost(ctx, productIDValue, &dto.ProductBoostRequest{Boost: &boost})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update product boost: %w", err)
+	}
+
+	return result, nil
+}
+
+// CreateSearchPin is the resolver for the createSearchPin field.
+func (r *mutationResolver) CreateSearchPin(ctx context.Context, input dto.SearchPinRequest) (*dto.SearchPinResponse, error) {
+	pin, err := r.searchTuningService.CreateSearchPin(ctx, &input)
 	if err != nil {
-		return nil, fmt.Errorf("invalid product ID: %w", err)
+		return nil, fmt.Errorf("failed to create search pin: %w", err)
 	}
 
-	product, err := r.productService.UpdateProduct(productID, &input)
+	return pin, nil
+}
+
+// DeleteSearchPin is the resolver for the deleteSearchPin field.
+func (r *mutationResolver) DeleteSearchPin(ctx context.Context, id string) (bool, error) {
+	pinID, err := r.parseID(id)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update product: %w", err)
+		return false, fmt.Errorf("invalid search pin ID: %w", err)
 	}
 
-	return product, nil
+	if err := r.searchTuningService.DeleteSearchPin(ctx, pinID); err != nil {
+		return false, fmt.Errorf("failed to delete search pin: %w", err)
+	}
+
+	return true, nil
 }
 
-// DeleteProduct is the resolver for the deleteProduct field.
-func (r *mutationResolver) DeleteProduct(ctx context.Context, id string) (bool, error) {
-	if !IsAdminFromContext(ctx) {
-		return false, ErrUnauthorized
+// RecordSearchClick is the resolver for the recordSearchClick field.
+func (r *mutationResolver) RecordSearchClick(ctx context.Context, searchID, productID string) (bool, error) {
+	searchQueryID, err := r.parseID(searchID)
+	if err != nil {
+		return false, fmt.Errorf("invalid search ID: %w", err)
 	}
 
-	productID, err := r.parseID(id)
+	productIDValue, err := r.parseID(productID)
 	if err != nil {
 		return false, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	err = r.productService.DeleteProduct(productID)
-	if err != nil {
-		return false, fmt.Errorf("failed to delete product: %w", err)
+	if err := r.searchAnalyticsService.RecordEvent(searchQueryID, productIDValue, models.SearchEventClick); err != nil {
+		return false, fmt.Errorf("failed to record click: %w", err)
 	}
 
 	return true, nil
@@ -182,6 +587,12 @@ func (r *mutationResolver) AddToCart(ctx context.Context, input dto.AddToCartReq
 		return nil, fmt.Errorf("failed to add to cart: %w", err)
 	}
 
+	if input.SearchID != nil {
+		if err := r.searchAnalyticsService.RecordEvent(*input.SearchID, input.ProductID, models.SearchEventAddToCart); err != nil {
+			log.Printf("Failed to record search add to cart: %v", err)
+		}
+	}
+
 	return cart, nil
 }
 
@@ -240,6 +651,21 @@ func (r *mutationResolver) CreateOrder(ctx context.Context) (*dto.OrderResponse,
 	return order, nil
 }
 
+// UpdateOrderStatus is the resolver for the updateOrderStatus field.
+func (r *mutationResolver) UpdateOrderStatus(ctx context.Context, id, status string) (*dto.OrderResponse, error) {
+	orderID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	order, err := r.orderService.UpdateOrderStatus(orderID, &dto.UpdateOrderStatusRequest{Status: status})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	return order, nil
+}
+
 // Me is the resolver for the me field.
 func (r *queryResolver) Me(ctx context.Context) (*dto.UserResponse, error) {
 	userId, err := GetUserIDFromContext(ctx)
@@ -255,29 +681,47 @@ func (r *queryResolver) Me(ctx context.Context) (*dto.UserResponse, error) {
 }
 
 // Products is the resolver for the products field.
-func (r *queryResolver) Products(ctx context.Context, page, limit *int) (*model.ProductConnection, error) {
-	p, l := getPagingNumbers(page, limit)
-
-	products, meta, err := r.productService.GetProducts(p, l)
+func (r *queryResolver) Products(ctx context.Context, page, limit, first *int, after *string, last *int, before *string, sort *model.ProductSort, filter *model.ProductFilterInput) (*model.ProductConnection, error) {
+	req, err := r.productListRequest(sort, filter)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get products: %w", err)
+		return nil, err
+	}
+
+	var products []dto.ProductResponse
+	var info *model.PageInfo
+
+	if cursorReq := cursorRequest(first, after, last, before); cursorReq != nil {
+		result, meta, err := r.productService.ListProductsByCursor(req, cursorReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get products: %w", err)
+		}
+		products, info = result, cursorPageInfo(meta)
+	} else {
+		req.Page, req.Limit = getPagingNumbers(page, limit)
+
+		result, meta, err := r.productService.ListProducts(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get products: %w", err)
+		}
+		products, info = result, pageInfo(meta)
 	}
 
 	edges := make([]*model.ProductEdge, len(products)) // allocate enough memory for all the products
-	for i, product := range products {
+	for i := range products {
 		edges[i] = &model.ProductEdge{
-			Node: &product,
+			Cursor: services.ProductCursor(req.Sort, &products[i]),
+			Node:   &products[i],
 		}
 	}
 
+	if len(edges) > 0 && info.StartCursor == nil {
+		info.StartCursor = &edges[0].Cursor
+		info.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
 	return &model.ProductConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			Page:       meta.Page,
-			Limit:      meta.Limit,
-			Total:      int(meta.Total),
-			TotalPages: meta.TotalPages,
-		},
+		Edges:    edges,
+		PageInfo: info,
 	}, nil
 }
 
@@ -288,7 +732,7 @@ func (r *queryResolver) Product(ctx context.Context, id string) (*dto.ProductRes
 		return nil, fmt.Errorf("invalid product ID: %w", err)
 	}
 
-	product, err := r.productService.GetProduct(productID)
+	product, err := r.productService.FindProduct(productID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get product: %w", err)
 	}
@@ -296,6 +740,90 @@ func (r *queryResolver) Product(ctx context.Context, id string) (*dto.ProductRes
 	return product, nil
 }
 
+// SearchProducts is the resolver for the searchProducts field.
+func (r *queryResolver) SearchProducts(ctx context.Context, query string, categoryID *string, minPrice, maxPrice *float64, inStock *bool, attributes dto.Attributes, page, limit *int, searchID *string) (*model.ProductSearchConnection, error) {
+	req := dto.SearchProductsRequest{Query: query}
+	req.MinPrice, req.MaxPrice = minPrice, maxPrice
+	req.InStock = inStock != nil && *inStock
+	req.Attributes = attributes
+	req.Page, req.Limit = getPagingNumbers(page, limit)
+
+	if categoryID != nil {
+		id, err := r.parseID(*categoryID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid category ID: %w", err)
+		}
+		req.CategoryID = &id
+	}
+
+	results, meta, err := r.productService.SearchProducts(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	edges := make([]*model.ProductSearchEdge, len(results))
+	for i := range results {
+		edges[i] = &model.ProductSearchEdge{
+			Node:   &results[i].ProductResponse,
+			Rank:   float64(results[i].Rank),
+			Pinned: results[i].Pinned,
+		}
+	}
+
+	connection := &model.ProductSearchConnection{
+		Edges:    edges,
+		PageInfo: pageInfo(meta),
+	}
+
+	// Only first pages are logged, and later pages added to their results,
+	// as over REST
+	var loggedID uint
+	if meta.Page == 1 {
+		loggedID = r.searchAnalyticsService.RecordSearch(ctx, query, meta.Total, services.SearchResultIDs(results))
+	} else if searchID != nil {
+		id, err := r.parseID(*searchID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid search ID: %w", err)
+		}
+		loggedID = r.searchAnalyticsService.RecordResults(id, query, services.SearchResultIDs(results))
+	}
+
+	if loggedID != 0 {
+		id := fmt.Sprintf("%d", loggedID)
+		connection.SearchID = &id
+	}
+
+	// Facets take several extra queries, so only count them when asked for
+	if slices.Contains(graphql.CollectAllFields(ctx), "facets") {
+		connection.Facets, err = r.productService.SearchFacets(ctx, &req)
+		if err != nil {
+			return nil, fmt.Errorf("search failed: %w", err)
+		}
+	}
+
+	return connection, nil
+}
+
+// SearchSuggestions is the resolver for the searchSuggestions field.
+func (r *queryResolver) SearchSuggestions(ctx context.Context, query string, limit *int) ([]*dto.SearchSuggestion, error) {
+	req := dto.SearchSuggestRequest{Query: query}
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	suggestions, err := r.productService.SuggestProducts(ctx, &req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get suggestions: %w", err)
+	}
+
+	result := make([]*dto.SearchSuggestion, len(suggestions))
+	for i := range suggestions {
+		result[i] = &suggestions[i]
+	}
+
+	return result, nil
+}
+
 // Categories is the resolver for the categories field.
 func (r *queryResolver) Categories(ctx context.Context) ([]*dto.CategoryResponse, error) {
 	categories, err := r.productService.GetCategories()
@@ -313,6 +841,41 @@ func (r *queryResolver) Categories(ctx context.Context) ([]*dto.CategoryResponse
 	return result, nil
 }
 
+// Category is the resolver for the category field.
+func (r *queryResolver) Category(ctx context.Context, id string) (*dto.CategoryResponse, error) {
+	categoryID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid category ID: %w", err)
+	}
+
+	category, err := r.productService.GetCategory(categoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+// OidcProviders is the resolver for the oidcProviders field.
+func (r *queryResolver) OidcProviders(ctx context.Context) ([]string, error) {
+	return r.oidcService.Providers(), nil
+}
+
+// MyDataExport is the resolver for the myDataExport field.
+func (r *queryResolver) MyDataExport(ctx context.Context) (*dto.UserDataExport, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	export, err := r.privacyService.ExportUserData(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to export data: %w", err)
+	}
+
+	return export, nil
+}
+
 // Cart is the resolver for the cart field.
 func (r *queryResolver) Cart(ctx context.Context) (*dto.CartResponse, error) {
 	userID, err := GetUserIDFromContext(ctx)
@@ -329,34 +892,47 @@ func (r *queryResolver) Cart(ctx context.Context) (*dto.CartResponse, error) {
 }
 
 // Orders is the resolver for the orders field.
-func (r *queryResolver) Orders(ctx context.Context, page, limit *int) (*model.OrderConnection, error) {
+func (r *queryResolver) Orders(ctx context.Context, page, limit, first *int, after *string, last *int, before *string) (*model.OrderConnection, error) {
 	userID, err := GetUserIDFromContext(ctx)
 	if err != nil {
 		return nil, ErrUnauthorized
 	}
 
-	p, l := getPagingNumbers(page, limit)
+	var orders []dto.OrderResponse
+	var info *model.PageInfo
 
-	orders, meta, err := r.orderService.GetOrders(userID, p, l)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get orders: %w", err)
+	if req := cursorRequest(first, after, last, before); req != nil {
+		result, meta, err := r.orderService.ListOrdersByCursor(userID, req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get orders: %w", err)
+		}
+		orders, info = result, cursorPageInfo(meta)
+	} else {
+		p, l := getPagingNumbers(page, limit)
+
+		result, meta, err := r.orderService.ListOrders(userID, p, l)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get orders: %w", err)
+		}
+		orders, info = result, pageInfo(meta)
 	}
 
 	edges := make([]*model.OrderEdge, len(orders))
-	for i, order := range orders {
+	for i := range orders {
 		edges[i] = &model.OrderEdge{
-			Node: &order,
+			Cursor: services.OrderCursor(&orders[i]),
+			Node:   &orders[i],
 		}
 	}
 
+	if len(edges) > 0 && info.StartCursor == nil {
+		info.StartCursor = &edges[0].Cursor
+		info.EndCursor = &edges[len(edges)-1].Cursor
+	}
+
 	return &model.OrderConnection{
-		Edges: edges,
-		PageInfo: &model.PageInfo{
-			Page:       meta.Page,
-			Limit:      meta.Limit,
-			Total:      int(meta.Total),
-			TotalPages: meta.TotalPages,
-		},
+		Edges:    edges,
+		PageInfo: info,
 	}, nil
 }
 
@@ -372,7 +948,59 @@ func (r *queryResolver) Order(ctx context.Context, id string) (*dto.OrderRespons
 		return nil, fmt.Errorf("invalid order ID: %w", err)
 	}
 
-	order, err := r.orderService.GetOrder(userID, orderID)
+	order, err := r.orderService.FindOrder(userID, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	return order, nil
+}
+
+// AllOrders is the resolver for the allOrders field.
+func (r *queryResolver) AllOrders(ctx context.Context, userID, status *string, page, limit *int) (*model.AdminOrderConnection, error) {
+	p, l := getPagingNumbers(page, limit)
+
+	req := dto.AdminOrderListRequest{
+		Page:  p,
+		Limit: l,
+	}
+	if userID != nil {
+		id, err := r.parseID(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		req.UserID = &id
+	}
+	if status != nil {
+		req.Status = *status
+	}
+
+	orders, meta, err := r.orderService.ListAllOrders(&req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	edges := make([]*model.AdminOrderEdge, len(orders))
+	for i := range orders {
+		edges[i] = &model.AdminOrderEdge{
+			Node: &orders[i],
+		}
+	}
+
+	return &model.AdminOrderConnection{
+		Edges:    edges,
+		PageInfo: pageInfo(meta),
+	}, nil
+}
+
+// AnyOrder is the resolver for the anyOrder field.
+func (r *queryResolver) AnyOrder(ctx context.Context, id string) (*dto.OrderResponse, error) {
+	orderID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	order, err := r.orderService.FindAnyOrder(orderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get order: %w", err)
 	}
@@ -380,8 +1008,260 @@ func (r *queryResolver) Order(ctx context.Context, id string) (*dto.OrderRespons
 	return order, nil
 }
 
+// Users is the resolver for the users field.
+func (r *queryResolver) Users(ctx context.Context, query, role *string, isActive *bool, page, limit *int) (*model.AdminUserConnection, error) {
+	p, l := getPagingNumbers(page, limit)
+
+	req := dto.AdminUserSearchRequest{
+		IsActive: isActive,
+		Page:     p,
+		Limit:    l,
+	}
+	if query != nil {
+		req.Query = *query
+	}
+	if role != nil {
+		req.Role = *role
+	}
+
+	users, meta, err := r.userService.SearchUsers(&req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get users: %w", err)
+	}
+
+	edges := make([]*model.AdminUserEdge, len(users))
+	for i := range users {
+		edges[i] = &model.AdminUserEdge{
+			Node: &users[i],
+		}
+	}
+
+	return &model.AdminUserConnection{
+		Edges:    edges,
+		PageInfo: pageInfo(meta),
+	}, nil
+}
+
+// User is the resolver for the user field.
+func (r *queryResolver) User(ctx context.Context, id string) (*dto.AdminUserResponse, error) {
+	userID, err := r.parseID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	user, err := r.userService.GetUser(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
+// Roles is the resolver for the roles field.
+func (r *queryResolver) Roles(ctx context.Context) ([]*dto.RoleResponse, error) {
+	roles, err := r.rbacService.GetRoles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get roles: %w", err)
+	}
+
+	return rolePointers(roles), nil
+}
+
+// UserRoles is the resolver for the userRoles field.
+func (r *queryResolver) UserRoles(ctx context.Context, userID string) ([]*dto.RoleResponse, error) {
+	id, err := r.parseID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	roles, err := r.rbacService.GetUserRoles(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user roles: %w", err)
+	}
+
+	return rolePointers(roles), nil
+}
+
+// APIKeys is the resolver for the apiKeys field.
+func (r *queryResolver) APIKeys(ctx context.Context, userID *string) ([]*dto.APIKeyResponse, error) {
+	var ownerID uint
+	if userID != nil {
+		id, err := r.parseID(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+		ownerID = id
+	}
+
+	keys, err := r.apiKeyService.ListAPIKeys(ownerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	result := make([]*dto.APIKeyResponse, len(keys))
+	for i := range keys {
+		result[i] = &keys[i]
+	}
+
+	return result, nil
+}
+
+// AuditLogs is the resolver for the auditLogs field.
+func (r *queryResolver) AuditLogs(ctx context.Context, actorID, action, entityType, entityID *string, from, to *time.Time, page, limit *int) (*model.AuditLogConnection, error) {
+	req := dto.AuditLogSearchRequest{
+		From: from,
+		To:   to,
+	}
+
+	if actorID != nil {
+		id, err := r.parseID(*actorID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid actor ID: %w", err)
+		}
+		req.ActorID = &id
+	}
+	if action != nil {
+		req.Action = *action
+	}
+	if entityType != nil {
+		req.EntityType = *entityType
+	}
+	if entityID != nil {
+		req.EntityID = *entityID
+	}
+	if page != nil {
+		req.Page = *page
+	}
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	logs, meta, err := r.auditService.ListAuditLogs(&req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit logs: %w", err)
+	}
+
+	edges := make([]*model.AuditLogEdge, len(logs))
+	for i := range logs {
+		edges[i] = &model.AuditLogEdge{
+			Node: &logs[i],
+		}
+	}
+
+	return &model.AuditLogConnection{
+		Edges:    edges,
+		PageInfo: pageInfo(meta),
+	}, nil
+}
+
+// SearchReport is the resolver for the searchReport field.
+func (r *queryResolver) SearchReport(ctx context.Context, from, to *time.Time, limit *int) (*dto.SearchReport, error) {
+	req := dto.SearchReportRequest{
+		From: from,
+		To:   to,
+	}
+	if limit != nil {
+		req.Limit = *limit
+	}
+
+	report, err := r.searchAnalyticsService.GetSearchReport(&req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search report: %w", err)
+	}
+
+	return report, nil
+}
+
+// SearchSynonymSets is the resolver for the searchSynonymSets field.
+func (r *queryResolver) SearchSynonymSets(ctx context.Context) ([]*dto.SynonymSetResponse, error) {
+	sets, err := r.searchTuningService.ListSynonymSets()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get synonym sets: %w", err)
+	}
+
+	result := make([]*dto.SynonymSetResponse, len(sets))
+	for i := range sets {
+		result[i] = &sets[i]
+	}
+
+	return result, nil
+}
+
+// ProductSearchBoosts is the resolver for the productSearchBoosts field.
+func (r *queryResolver) ProductSearchBoosts(ctx context.Context) ([]*dto.ProductBoostResponse, error) {
+	boosts, err := r.searchTuningService.ListProductBoosts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product boosts: %w", err)
+	}
+
+	result := make([]*dto.ProductBoostResponse, len(boosts))
+	for i := range boosts {
+		result[i] = &boosts[i]
+	}
+
+	return result, nil
+}
+
+// SearchPins is the resolver for the searchPins field.
+func (r *queryResolver) SearchPins(ctx context.Context, query *string) ([]*dto.SearchPinResponse, error) {
+	var req dto.SearchPinListRequest
+	if query != nil {
+		req.Query = *query
+	}
+
+	pins, err := r.searchTuningService.ListSearchPins(&req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search pins: %w", err)
+	}
+
+	result := make([]*dto.SearchPinResponse, len(pins))
+	for i := range pins {
+		result[i] = &pins[i]
+	}
+
+	return result, nil
+}
+
+// OrderStatusChanged is the resolver for the orderStatusChanged field.
+func (r *subscriptionResolver) OrderStatusChanged(ctx context.Context, orderID string) (<-chan *dto.OrderResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	id, err := r.parseID(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid order ID: %w", err)
+	}
+
+	orders, err := r.orderService.WatchOrder(ctx, userID, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to order: %w", err)
+	}
+
+	return orders, nil
+}
+
+// CartUpdated is the resolver for the cartUpdated field.
+func (r *subscriptionResolver) CartUpdated(ctx context.Context) (<-chan *dto.CartResponse, error) {
+	userID, err := GetUserIDFromContext(ctx)
+	if err != nil {
+		return nil, ErrUnauthorized
+	}
+
+	carts, err := r.cartService.WatchCart(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to cart: %w", err)
+	}
+
+	return carts, nil
+}
+
 // Mutation returns graph.MutationResolver implementation.
 func (r *Resolver) Mutation() graph.MutationResolver { return &mutationResolver{r} }
 
 // Query returns graph.QueryResolver implementation.
 func (r *Resolver) Query() graph.QueryResolver { return &queryResolver{r} }
+
+// Subscription returns graph.SubscriptionResolver implementation.
+func (r *Resolver) Subscription() graph.SubscriptionResolver { return &subscriptionResolver{r} }
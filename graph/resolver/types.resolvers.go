@@ -12,15 +12,56 @@ import (
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
 )
 
+type aPIKeyResolver struct{ *Resolver }
+type adminUserResolver struct{ *Resolver }
+type auditLogResolver struct{ *Resolver }
 type cartResolver struct{ *Resolver }
 type cartItemResolver struct{ *Resolver }
 type categoryResolver struct{ *Resolver }
+type categoryFacetResolver struct{ *Resolver }
+type createdAPIKeyResolver struct{ *Resolver }
+type impersonationResolver struct{ *Resolver }
 type orderResolver struct{ *Resolver }
 type orderItemResolver struct{ *Resolver }
 type productResolver struct{ *Resolver }
 type productImageResolver struct{ *Resolver }
+type productSearchBoostResolver struct{ *Resolver }
+type roleResolver struct{ *Resolver }
+type searchPinResolver struct{ *Resolver }
+type searchSuggestionResolver struct{ *Resolver }
+type searchSynonymSetResolver struct{ *Resolver }
 type userResolver struct{ *Resolver }
 
+// ID is the resolver for the id field.
+func (r *aPIKeyResolver) ID(ctx context.Context, obj *dto.APIKeyResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// UserID is the resolver for the user_id field.
+func (r *aPIKeyResolver) UserID(ctx context.Context, obj *dto.APIKeyResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.UserID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *adminUserResolver) ID(ctx context.Context, obj *dto.AdminUserResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *auditLogResolver) ID(ctx context.Context, obj *dto.AuditLogResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ActorID is the resolver for the actor_id field.
+func (r *auditLogResolver) ActorID(ctx context.Context, obj *dto.AuditLogResponse) (*string, error) {
+	if obj.ActorID == nil {
+		return nil, nil
+	}
+
+	id := fmt.Sprintf("%d", *obj.ActorID)
+	return &id, nil
+}
+
 // ID is the resolver for the id field.
 func (r *cartResolver) ID(ctx context.Context, obj *dto.CartResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -41,6 +82,36 @@ func (r *categoryResolver) ID(ctx context.Context, obj *dto.CategoryResponse) (s
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// ParentID is the resolver for the parent_id field.
+func (r *categoryResolver) ParentID(ctx context.Context, obj *dto.CategoryResponse) (*string, error) {
+	if obj.ParentID == nil {
+		return nil, nil
+	}
+
+	id := fmt.Sprintf("%d", *obj.ParentID)
+	return &id, nil
+}
+
+// CategoryID is the resolver for the category_id field.
+func (r *categoryFacetResolver) CategoryID(ctx context.Context, obj *dto.CategoryFacet) (string, error) {
+	return fmt.Sprintf("%d", obj.CategoryID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *createdAPIKeyResolver) ID(ctx context.Context, obj *dto.CreatedAPIKeyResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// UserID is the resolver for the user_id field.
+func (r *createdAPIKeyResolver) UserID(ctx context.Context, obj *dto.CreatedAPIKeyResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.UserID), nil
+}
+
+// ImpersonatorID is the resolver for the impersonator_id field.
+func (r *impersonationResolver) ImpersonatorID(ctx context.Context, obj *dto.ImpersonationResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ImpersonatorID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *orderResolver) ID(ctx context.Context, obj *dto.OrderResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -51,11 +122,31 @@ func (r *orderResolver) UserID(ctx context.Context, obj *dto.OrderResponse) (str
 	return fmt.Sprintf("%d", obj.UserID), nil
 }
 
+// User is the resolver for the user field.
+func (r *orderResolver) User(ctx context.Context, obj *dto.OrderResponse) (*dto.UserResponse, error) {
+	user, err := r.loaders(ctx).Users.Load(ctx, obj.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	return user, nil
+}
+
 // ID is the resolver for the id field.
 func (r *orderItemResolver) ID(ctx context.Context, obj *dto.OrderItemResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// Product is the resolver for the product field.
+func (r *orderItemResolver) Product(ctx context.Context, obj *dto.OrderItemResponse) (*dto.ProductResponse, error) {
+	product, err := r.loaders(ctx).Products.Load(ctx, obj.ProductID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product: %w", err)
+	}
+
+	return product, nil
+}
+
 // ID is the resolver for the id field.
 func (r *productResolver) ID(ctx context.Context, obj *dto.ProductResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
@@ -66,16 +157,85 @@ func (r *productResolver) CategoryID(ctx context.Context, obj *dto.ProductRespon
 	return fmt.Sprintf("%d", obj.CategoryID), nil
 }
 
+// InStock is the resolver for the in_stock field.
+func (r *productResolver) InStock(ctx context.Context, obj *dto.ProductResponse) (bool, error) {
+	return obj.Stock > 0, nil
+}
+
+// Category is the resolver for the category field.
+func (r *productResolver) Category(ctx context.Context, obj *dto.ProductResponse) (*dto.CategoryResponse, error) {
+	category, err := r.loaders(ctx).Categories.Load(ctx, obj.CategoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+
+	return category, nil
+}
+
+// Images is the resolver for the images field.
+func (r *productResolver) Images(ctx context.Context, obj *dto.ProductResponse) ([]*dto.ProductImageResponse, error) {
+	images, err := r.loaders(ctx).Images.Load(ctx, obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get product images: %w", err)
+	}
+
+	result := make([]*dto.ProductImageResponse, len(images))
+	for i := range images {
+		result[i] = &images[i]
+	}
+
+	return result, nil
+}
+
 // ID is the resolver for the id field.
 func (r *productImageResolver) ID(ctx context.Context, obj *dto.ProductImageResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// ProductID is the resolver for the product_id field.
+func (r *productSearchBoostResolver) ProductID(ctx context.Context, obj *dto.ProductBoostResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *roleResolver) ID(ctx context.Context, obj *dto.RoleResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *searchPinResolver) ID(ctx context.Context, obj *dto.SearchPinResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ProductID is the resolver for the product_id field.
+func (r *searchPinResolver) ProductID(ctx context.Context, obj *dto.SearchPinResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ProductID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *searchSuggestionResolver) ID(ctx context.Context, obj *dto.SearchSuggestion) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
+// ID is the resolver for the id field.
+func (r *searchSynonymSetResolver) ID(ctx context.Context, obj *dto.SynonymSetResponse) (string, error) {
+	return fmt.Sprintf("%d", obj.ID), nil
+}
+
 // ID is the resolver for the id field.
 func (r *userResolver) ID(ctx context.Context, obj *dto.UserResponse) (string, error) {
 	return fmt.Sprintf("%d", obj.ID), nil
 }
 
+// APIKey returns graph.APIKeyResolver implementation.
+func (r *Resolver) APIKey() graph.APIKeyResolver { return &aPIKeyResolver{r} }
+
+// AdminUser returns graph.AdminUserResolver implementation.
+func (r *Resolver) AdminUser() graph.AdminUserResolver { return &adminUserResolver{r} }
+
+// AuditLog returns graph.AuditLogResolver implementation.
+func (r *Resolver) AuditLog() graph.AuditLogResolver { return &auditLogResolver{r} }
+
 // Cart returns graph.CartResolver implementation.
 func (r *Resolver) Cart() graph.CartResolver { return &cartResolver{r} }
 
@@ -85,6 +245,15 @@ func (r *Resolver) CartItem() graph.CartItemResolver { return &cartItemResolver{
 // Category returns graph.CategoryResolver implementation.
 func (r *Resolver) Category() graph.CategoryResolver { return &categoryResolver{r} }
 
+// CategoryFacet returns graph.CategoryFacetResolver implementation.
+func (r *Resolver) CategoryFacet() graph.CategoryFacetResolver { return &categoryFacetResolver{r} }
+
+// CreatedAPIKey returns graph.CreatedAPIKeyResolver implementation.
+func (r *Resolver) CreatedAPIKey() graph.CreatedAPIKeyResolver { return &createdAPIKeyResolver{r} }
+
+// Impersonation returns graph.ImpersonationResolver implementation.
+func (r *Resolver) Impersonation() graph.ImpersonationResolver { return &impersonationResolver{r} }
+
 // Order returns graph.OrderResolver implementation.
 func (r *Resolver) Order() graph.OrderResolver { return &orderResolver{r} }
 
@@ -97,5 +266,26 @@ func (r *Resolver) Product() graph.ProductResolver { return &productResolver{r}
 // ProductImage returns graph.ProductImageResolver implementation.
 func (r *Resolver) ProductImage() graph.ProductImageResolver { return &productImageResolver{r} }
 
+// ProductSearchBoost returns graph.ProductSearchBoostResolver implementation.
+func (r *Resolver) ProductSearchBoost() graph.ProductSearchBoostResolver {
+	return &productSearchBoostResolver{r}
+}
+
+// Role returns graph.RoleResolver implementation.
+func (r *Resolver) Role() graph.RoleResolver { return &roleResolver{r} }
+
+// SearchPin returns graph.SearchPinResolver implementation.
+func (r *Resolver) SearchPin() graph.SearchPinResolver { return &searchPinResolver{r} }
+
+// SearchSuggestion returns graph.SearchSuggestionResolver implementation.
+func (r *Resolver) SearchSuggestion() graph.SearchSuggestionResolver {
+	return &searchSuggestionResolver{r}
+}
+
+// SearchSynonymSet returns graph.SearchSynonymSetResolver implementation.
+func (r *Resolver) SearchSynonymSet() graph.SearchSynonymSetResolver {
+	return &searchSynonymSetResolver{r}
+}
+
 // User returns graph.UserResolver implementation.
 func (r *Resolver) User() graph.UserResolver { return &userResolver{r} }
@@ -0,0 +1,142 @@
+package apperror
+
+import (
+	"errors"
+	"net/http"
+
+	"gorm.io/gorm"
+)
+
+// Code identifies a class of failure. Codes are part of the public API and
+// are returned to REST and GraphQL clients, so existing values must not change.
+type Code string
+
+const (
+	CodeInvalidInput    Code = "INVALID_INPUT"
+	CodeUnauthorized    Code = "UNAUTHORIZED"
+	CodeForbidden       Code = "FORBIDDEN"
+	CodeNotFound        Code = "NOT_FOUND"
+	CodeConflict        Code = "CONFLICT"
+	CodeOutOfStock      Code = "OUT_OF_STOCK"
+	CodeTooManyRequests Code = "TOO_MANY_REQUESTS"
+	CodeInternal        Code = "INTERNAL"
+)
+
+// Error is a failure that is safe to report to clients. Message is shown as
+// is; the wrapped error, if any, is only meant for logs.
+type Error struct {
+	Code    Code
+	Message string
+	Err     error
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+func Wrap(code Code, message string, err error) *Error {
+	return &Error{Code: code, Message: message, Err: err}
+}
+
+func InvalidInput(message string) *Error {
+	return New(CodeInvalidInput, message)
+}
+
+func Unauthorized(message string) *Error {
+	return New(CodeUnauthorized, message)
+}
+
+func Forbidden(message string) *Error {
+	return New(CodeForbidden, message)
+}
+
+func NotFound(message string) *Error {
+	return New(CodeNotFound, message)
+}
+
+func Conflict(message string) *Error {
+	return New(CodeConflict, message)
+}
+
+func OutOfStock(message string) *Error {
+	return New(CodeOutOfStock, message)
+}
+
+func TooManyRequests(message string) *Error {
+	return New(CodeTooManyRequests, message)
+}
+
+// From classifies err. Domain errors anywhere in the chain are returned as
+// is, and well-known database errors are translated. Anything else is
+// reported as an internal error that keeps err for logging.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return Wrap(CodeNotFound, "resource not found", err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return Wrap(CodeConflict, "resource already exists", err)
+	case errors.Is(err, gorm.ErrForeignKeyViolated):
+		return Wrap(CodeInvalidInput, "referenced resource does not exist", err)
+	}
+
+	return Wrap(CodeInternal, "internal server error", err)
+}
+
+// CodeOf returns the code of the domain error behind err
+func CodeOf(err error) Code {
+	return From(err).Code
+}
+
+// HTTPStatus returns the status code REST responses use for code
+func HTTPStatus(code Code) int {
+	switch code {
+	case CodeInvalidInput:
+		return http.StatusBadRequest
+	case CodeUnauthorized:
+		return http.StatusUnauthorized
+	case CodeForbidden:
+		return http.StatusForbidden
+	case CodeNotFound:
+		return http.StatusNotFound
+	case CodeConflict, CodeOutOfStock:
+		return http.StatusConflict
+	case CodeTooManyRequests:
+		return http.StatusTooManyRequests
+	}
+
+	return http.StatusInternalServerError
+}
+
+// CodeForStatus returns the code matching an HTTP status, for responses that
+// are not built from a domain error
+func CodeForStatus(status int) Code {
+	switch status {
+	case http.StatusBadRequest:
+		return CodeInvalidInput
+	case http.StatusUnauthorized:
+		return CodeUnauthorized
+	case http.StatusForbidden:
+		return CodeForbidden
+	case http.StatusNotFound:
+		return CodeNotFound
+	case http.StatusConflict:
+		return CodeConflict
+	case http.StatusTooManyRequests:
+		return CodeTooManyRequests
+	}
+
+	return CodeInternal
+}
@@ -0,0 +1,230 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Clock skew tolerated when checking ID token times
+const idTokenLeeway = time.Minute
+
+// Signing keys are fetched again for an unknown key ID, as providers rotate
+// them, but no more often than this
+const jwksRefreshInterval = time.Minute
+
+// Signature algorithms accepted for ID tokens. HMAC is left out because its
+// key is the client secret, which the provider does not sign with by default.
+var idTokenMethods = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512"}
+
+// IDTokenClaims holds the ID token claims used to identify the user
+type IDTokenClaims struct {
+	Nonce           string `json:"nonce"`
+	AuthorizedParty string `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// VerifyIDToken checks the ID token's signature against the provider's
+// published keys, its issuer, audience and expiry, and that it carries the
+// nonce sent with the authorization request
+func (c *Client) VerifyIDToken(ctx context.Context, rawIDToken, nonce string) (*IDTokenClaims, error) {
+	if rawIDToken == "" {
+		return nil, errors.New("no id token returned")
+	}
+
+	endpoints, err := c.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := jwt.ParseWithClaims(rawIDToken, &IDTokenClaims{}, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		return c.signingKey(ctx, endpoints.JWKSURI, kid)
+	},
+		jwt.WithValidMethods(idTokenMethods),
+		jwt.WithIssuer(endpoints.Issuer),
+		jwt.WithAudience(c.config.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(idTokenLeeway),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	claims, ok := parsed.Claims.(*IDTokenClaims)
+	if !ok || !parsed.Valid {
+		return nil, errors.New("invalid id token")
+	}
+
+	if claims.Subject == "" {
+		return nil, errors.New("invalid id token: no subject")
+	}
+
+	// A token issued to several clients must name this one as its holder
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != c.config.ClientID {
+		return nil, errors.New("invalid id token: issued to another client")
+	}
+
+	if nonce == "" || subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, errors.New("invalid id token: nonce mismatch")
+	}
+
+	return claims, nil
+}
+
+// signingKey returns the provider key with the given ID. A token without a
+// key ID is accepted only while the provider publishes a single key.
+func (c *Client) signingKey(ctx context.Context, jwksURI, kid string) (any, error) {
+	c.keysMu.Lock()
+	defer c.keysMu.Unlock()
+
+	if key, ok := c.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	if !c.keysFetchedAt.IsZero() && time.Since(c.keysFetchedAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown signing key %q", kid)
+	}
+
+	keys, err := c.fetchKeys(ctx, jwksURI)
+	if err != nil {
+		return nil, err
+	}
+	c.keys, c.keysFetchedAt = keys, time.Now()
+
+	if key, ok := c.lookupKey(kid); ok {
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (c *Client) lookupKey(kid string) (any, bool) {
+	if kid == "" && len(c.keys) == 1 {
+		for _, key := range c.keys {
+			return key, true
+		}
+	}
+
+	key, ok := c.keys[kid]
+	return key, ok && kid != ""
+}
+
+// fetchKeys downloads the provider's JSON Web Key Set, skipping keys that are
+// not for signatures or of an unsupported type
+func (c *Client) fetchKeys(ctx context.Context, jwksURI string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, jwksURI, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := c.doJSON(req, &set); err != nil {
+		return nil, fmt.Errorf("jwks request failed: %w", err)
+	}
+
+	keys := make(map[string]any, len(set.Keys))
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		if key, err := jwk.publicKey(); err == nil {
+			keys[jwk.Kid] = key
+		}
+	}
+
+	return keys, nil
+}
+
+func (k *jsonWebKey) publicKey() (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		if !e.IsInt64() || e.Int64() < 3 || e.Int64() > 1<<31-1 {
+			return nil, errors.New("invalid RSA exponent")
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+
+	case "EC":
+		curves := map[string]elliptic.Curve{
+			"P-256": elliptic.P256(),
+			"P-384": elliptic.P384(),
+			"P-521": elliptic.P521(),
+		}
+		curve, ok := curves[k.Crv]
+		if !ok {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("invalid EC key")
+		}
+
+		// ParseUncompressedPublicKey rejects points that are not on the curve
+		return ecdsa.ParseUncompressedPublicKey(curve, slices.Concat([]byte{4}, x, y))
+
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(b) == 0 {
+		return nil, errors.New("empty key parameter")
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
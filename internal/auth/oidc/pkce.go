@@ -0,0 +1,37 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// GenerateCodeVerifier returns a random PKCE code verifier
+func GenerateCodeVerifier() (string, error) {
+	return randomString(32)
+}
+
+// GenerateState returns a random value for the OAuth2 state parameter
+func GenerateState() (string, error) {
+	return randomString(16)
+}
+
+// GenerateNonce returns a random value that binds an ID token to the login
+// that requested it
+func GenerateNonce() (string, error) {
+	return randomString(16)
+}
+
+// CodeChallengeS256 derives the PKCE code challenge for a verifier
+func CodeChallengeS256(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
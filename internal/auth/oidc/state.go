@@ -0,0 +1,47 @@
+package oidc
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// FlowState is kept by the browser between the redirect to the provider and
+// the callback. It is signed so the callback can trust it without server-side
+// storage, which keeps the flow working across API replicas.
+type FlowState struct {
+	Provider     string `json:"provider"`
+	State        string `json:"state"`
+	CodeVerifier string `json:"code_verifier"`
+	Nonce        string `json:"nonce"`
+	jwt.RegisteredClaims
+}
+
+// SignFlowState encodes the flow state as a signed, short-lived token
+func SignFlowState(secret []byte, state FlowState, ttl time.Duration) (string, error) {
+	state.RegisteredClaims = jwt.RegisteredClaims{
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(ttl)),
+		IssuedAt:  jwt.NewNumericDate(time.Now()),
+		Subject:   "oidc_flow",
+	}
+
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, &state).SignedString(secret)
+}
+
+// ParseFlowState validates a token created by SignFlowState
+func ParseFlowState(secret []byte, token string) (*FlowState, error) {
+	parsed, err := jwt.ParseWithClaims(token, &FlowState{}, func(token *jwt.Token) (interface{}, error) {
+		return secret, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}), jwt.WithSubject("oidc_flow"))
+	if err != nil {
+		return nil, err
+	}
+
+	state, ok := parsed.Claims.(*FlowState)
+	if !ok || !parsed.Valid {
+		return nil, errors.New("invalid flow state")
+	}
+
+	return state, nil
+}
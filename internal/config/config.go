@@ -1,25 +1,76 @@
 package config
 
 import (
+	"fmt"
+	"net"
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/joho/godotenv"
 )
 
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	JWT      JWTConfig
-	AWS      AWSConfig
-	Upload   UploadConfig
-	SMTP     SMTPConfig
+	Server    ServerConfig
+	CORS      CORSConfig
+	Security  SecurityConfig
+	GraphQL   GraphQLConfig
+	Database  DatabaseConfig
+	JWT       JWTConfig
+	Auth      AuthConfig
+	OIDC      OIDCConfig
+	RateLimit RateLimitConfig
+	PubSub    PubSubConfig
+	Search    SearchConfig
+	AWS       AWSConfig
+	Upload    UploadConfig
+	SMTP      SMTPConfig
 }
 
+// ServerConfig configures the HTTP server. TrustedProxies lists the
+// addresses or CIDR ranges of reverse proxies whose X-Forwarded-For header
+// is honoured when resolving the client IP; by default none are, so rate
+// limits and login throttling key on the connecting address.
 type ServerConfig struct {
-	Port    string
-	GinMode string
+	Port           string
+	GinMode        string
+	TrustedProxies []string
+}
+
+// CORSConfig controls cross-origin access. An AllowedOrigins entry of "*"
+// allows any origin, but credentials are then never allowed.
+type CORSConfig struct {
+	AllowedOrigins   []string
+	AllowedMethods   []string
+	AllowedHeaders   []string
+	ExposedHeaders   []string
+	AllowCredentials bool
+	MaxAge           time.Duration
+}
+
+// SecurityConfig holds the security headers sent with every response.
+// DocsContentSecurityPolicy replaces ContentSecurityPolicy on the GraphQL
+// playground and API documentation pages, which load scripts from CDNs.
+type SecurityConfig struct {
+	HSTSMaxAge                time.Duration
+	HSTSIncludeSubdomains     bool
+	ContentSecurityPolicy     string
+	DocsContentSecurityPolicy string
+	FrameOptions              string
+	ReferrerPolicy            string
+}
+
+// GraphQLConfig limits the cost of GraphQL operations. ListComplexity is the
+// assumed length of lists that are not paginated. With PersistedQueriesOnly
+// set, only the queries registered in PersistedQueriesFile are executed.
+type GraphQLConfig struct {
+	MaxComplexity        int
+	MaxDepth             int
+	ListComplexity       int
+	Introspection        bool
+	PersistedQueriesOnly bool
+	PersistedQueriesFile string
 }
 
 type DatabaseConfig struct {
@@ -37,6 +88,74 @@ type JWTConfig struct {
 	RefreshTokenExpiresIn time.Duration
 }
 
+type AuthConfig struct {
+	EmailVerificationURL            string
+	EmailChangeURL                  string
+	EmailVerificationExpiresIn      time.Duration
+	RequireVerifiedEmailForLogin    bool
+	RequireVerifiedEmailForCheckout bool
+	TOTPIssuer                      string
+	TwoFactorChallengeExpiresIn     time.Duration
+	RequireAdminTwoFactor           bool
+	MaxFailedLoginAttempts          int
+	MaxFailedLoginAttemptsPerIP     int
+	FailedLoginWindow               time.Duration
+	LoginLockoutBase                time.Duration
+	LoginLockoutMax                 time.Duration
+	ImpersonationTokenExpiresIn     time.Duration
+}
+
+type OIDCConfig struct {
+	RedirectBaseURL string
+	Providers       []OIDCProviderConfig
+}
+
+type OIDCProviderConfig struct {
+	Name         string
+	IssuerURL    string
+	AuthURL      string
+	TokenURL     string
+	UserInfoURL  string
+	ClientID     string
+	ClientSecret string
+	Scopes       []string
+}
+
+type RateLimitConfig struct {
+	Enabled  bool
+	Store    string
+	Policies map[string]RateLimitPolicy
+}
+
+// RateLimitPolicy allows Limit requests per Period, refilled continuously.
+// Key is "ip" to limit per client address or "user" to limit per
+// authenticated user, falling back to the address for anonymous requests.
+type RateLimitPolicy struct {
+	Name   string
+	Limit  int
+	Period time.Duration
+	Key    string
+}
+
+// PubSubConfig selects the pub/sub backend behind GraphQL subscriptions:
+// "memory" for a single instance or "postgres" to deliver across replicas
+type PubSubConfig struct {
+	Backend string
+}
+
+// SearchConfig selects the product search backend: "postgres" to search the
+// products table, "opensearch" for an OpenSearch or Elasticsearch compatible
+// server at URL, or "memory" for a per-process index meant for tests and
+// development. The opensearch and memory indexes are rebuilt on start and then
+// updated from product change events.
+type SearchConfig struct {
+	Backend  string
+	URL      string
+	Index    string
+	Username string
+	Password string
+}
+
 type AWSConfig struct {
 	Region          string
 	AccessKeyID     string
@@ -66,11 +185,72 @@ func Load() (*Config, error) {
 	refreshTokenExpiresIn, _ := time.ParseDuration(getEnv("REFRESH_TOKEN_EXPIRES_IN", "72h"))
 	maxUploadSize, _ := strconv.ParseInt(getEnv("MAX_UPLOAD_SIZE", "10485760"), 10, 64)
 	smtpPort, _ := strconv.Atoi(getEnv("SMTP_PORT", "1025"))
+	emailVerificationExpiresIn, _ := time.ParseDuration(getEnv("EMAIL_VERIFICATION_EXPIRES_IN", "24h"))
+	requireVerifiedEmailForLogin, _ := strconv.ParseBool(getEnv("REQUIRE_VERIFIED_EMAIL_FOR_LOGIN", "false"))
+	requireVerifiedEmailForCheckout, _ := strconv.ParseBool(getEnv("REQUIRE_VERIFIED_EMAIL_FOR_CHECKOUT", "false"))
+	twoFactorChallengeExpiresIn, _ := time.ParseDuration(getEnv("TWO_FACTOR_CHALLENGE_EXPIRES_IN", "5m"))
+	requireAdminTwoFactor, _ := strconv.ParseBool(getEnv("REQUIRE_ADMIN_TWO_FACTOR", "false"))
+	maxFailedLoginAttempts, _ := strconv.Atoi(getEnv("MAX_FAILED_LOGIN_ATTEMPTS", "5"))
+	maxFailedLoginAttemptsPerIP, _ := strconv.Atoi(getEnv("MAX_FAILED_LOGIN_ATTEMPTS_PER_IP", "20"))
+	failedLoginWindow, _ := time.ParseDuration(getEnv("FAILED_LOGIN_WINDOW", "15m"))
+	loginLockoutBase, _ := time.ParseDuration(getEnv("LOGIN_LOCKOUT_BASE", "1m"))
+	loginLockoutMax, _ := time.ParseDuration(getEnv("LOGIN_LOCKOUT_MAX", "1h"))
+	impersonationTokenExpiresIn, _ := time.ParseDuration(getEnv("IMPERSONATION_TOKEN_EXPIRES_IN", "15m"))
+	ginMode := getEnv("GIN_MODE", "debug")
+	graphqlMaxComplexity, _ := strconv.Atoi(getEnv("GRAPHQL_MAX_COMPLEXITY", "2000"))
+	graphqlMaxDepth, _ := strconv.Atoi(getEnv("GRAPHQL_MAX_DEPTH", "10"))
+	graphqlListComplexity, _ := strconv.Atoi(getEnv("GRAPHQL_LIST_COMPLEXITY", "10"))
+	graphqlIntrospection, _ := strconv.ParseBool(getEnv("GRAPHQL_INTROSPECTION", strconv.FormatBool(ginMode != "release")))
+	graphqlPersistedQueriesOnly, _ := strconv.ParseBool(getEnv("GRAPHQL_PERSISTED_QUERIES_ONLY", "false"))
+	rateLimitEnabled, _ := strconv.ParseBool(getEnv("RATE_LIMIT_ENABLED", "true"))
+	corsAllowCredentials, _ := strconv.ParseBool(getEnv("CORS_ALLOW_CREDENTIALS", "false"))
+	corsMaxAge, _ := time.ParseDuration(getEnv("CORS_MAX_AGE", "12h"))
+	hstsMaxAge, _ := time.ParseDuration(getEnv("HSTS_MAX_AGE", "8760h"))
+	hstsIncludeSubdomains, _ := strconv.ParseBool(getEnv("HSTS_INCLUDE_SUBDOMAINS", "false"))
+
+	trustedProxies := getEnvList("TRUSTED_PROXIES", "")
+	for _, proxy := range trustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				return nil, fmt.Errorf("invalid trusted proxy %q: must be an IP address or CIDR range", proxy)
+			}
+		}
+	}
 
 	return &Config{
 		Server: ServerConfig{
-			Port:    getEnv("PORT", "8080"),
-			GinMode: getEnv("GIN_MODE", "debug"),
+			Port:           getEnv("PORT", "8080"),
+			GinMode:        ginMode,
+			TrustedProxies: trustedProxies,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins:   getEnvList("CORS_ALLOWED_ORIGINS", "http://localhost:3000"),
+			AllowedMethods:   getEnvList("CORS_ALLOWED_METHODS", "GET,POST,PUT,DELETE,OPTIONS"),
+			AllowedHeaders:   getEnvList("CORS_ALLOWED_HEADERS", "Content-Type,Authorization,X-API-Key,X-Request-ID"),
+			ExposedHeaders:   getEnvList("CORS_EXPOSED_HEADERS", "X-Request-ID,RateLimit-Limit,RateLimit-Remaining,RateLimit-Reset,RateLimit-Policy,Retry-After"),
+			AllowCredentials: corsAllowCredentials,
+			MaxAge:           corsMaxAge,
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            hstsMaxAge,
+			HSTSIncludeSubdomains: hstsIncludeSubdomains,
+			ContentSecurityPolicy: getEnv("CONTENT_SECURITY_POLICY", "default-src 'none'; frame-ancestors 'none'"),
+			DocsContentSecurityPolicy: getEnv("DOCS_CONTENT_SECURITY_POLICY",
+				"default-src 'self'; "+
+					"script-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net https://unpkg.com; "+
+					"style-src 'self' 'unsafe-inline' https://cdn.jsdelivr.net https://unpkg.com; "+
+					"img-src 'self' data: https:; font-src 'self' data: https:; "+
+					"connect-src 'self'; frame-ancestors 'none'"),
+			FrameOptions:   getEnv("FRAME_OPTIONS", "DENY"),
+			ReferrerPolicy: getEnv("REFERRER_POLICY", "strict-origin-when-cross-origin"),
+		},
+		GraphQL: GraphQLConfig{
+			MaxComplexity:        graphqlMaxComplexity,
+			MaxDepth:             graphqlMaxDepth,
+			ListComplexity:       graphqlListComplexity,
+			Introspection:        graphqlIntrospection,
+			PersistedQueriesOnly: graphqlPersistedQueriesOnly,
+			PersistedQueriesFile: getEnv("GRAPHQL_PERSISTED_QUERIES_FILE", "./graph/persisted_queries.json"),
 		},
 		Database: DatabaseConfig{
 			Host:     getEnv("DB_HOST", "localhost"),
@@ -85,6 +265,41 @@ func Load() (*Config, error) {
 			ExpiresIn:             jwtExpiresIn,
 			RefreshTokenExpiresIn: refreshTokenExpiresIn,
 		},
+		Auth: AuthConfig{
+			EmailVerificationURL:            getEnv("EMAIL_VERIFICATION_URL", "http://localhost:3000/verify-email"),
+			EmailChangeURL:                  getEnv("EMAIL_CHANGE_URL", "http://localhost:3000/confirm-email-change"),
+			EmailVerificationExpiresIn:      emailVerificationExpiresIn,
+			RequireVerifiedEmailForLogin:    requireVerifiedEmailForLogin,
+			RequireVerifiedEmailForCheckout: requireVerifiedEmailForCheckout,
+			TOTPIssuer:                      getEnv("TOTP_ISSUER", "Shop"),
+			TwoFactorChallengeExpiresIn:     twoFactorChallengeExpiresIn,
+			RequireAdminTwoFactor:           requireAdminTwoFactor,
+			MaxFailedLoginAttempts:          maxFailedLoginAttempts,
+			MaxFailedLoginAttemptsPerIP:     maxFailedLoginAttemptsPerIP,
+			FailedLoginWindow:               failedLoginWindow,
+			LoginLockoutBase:                loginLockoutBase,
+			LoginLockoutMax:                 loginLockoutMax,
+			ImpersonationTokenExpiresIn:     impersonationTokenExpiresIn,
+		},
+		OIDC: OIDCConfig{
+			RedirectBaseURL: getEnv("OIDC_REDIRECT_BASE_URL", "http://localhost:8080/api/v1/auth/oidc"),
+			Providers:       loadOIDCProviders(),
+		},
+		RateLimit: RateLimitConfig{
+			Enabled:  rateLimitEnabled,
+			Store:    getEnv("RATE_LIMIT_STORE", "memory"),
+			Policies: loadRateLimitPolicies(),
+		},
+		PubSub: PubSubConfig{
+			Backend: getEnv("PUBSUB_BACKEND", "memory"),
+		},
+		Search: SearchConfig{
+			Backend:  getEnv("SEARCH_BACKEND", "postgres"),
+			URL:      getEnv("SEARCH_URL", "http://localhost:9200"),
+			Index:    getEnv("SEARCH_INDEX", "products"),
+			Username: getEnv("SEARCH_USERNAME", ""),
+			Password: getEnv("SEARCH_PASSWORD", ""),
+		},
 		AWS: AWSConfig{
 			Region:          getEnv("AWS_REGION", "us-east-1"),
 			AccessKeyID:     getEnv("AWS_ACCESS_KEY_ID", "test"),
@@ -108,9 +323,71 @@ func Load() (*Config, error) {
 	}, nil
 }
 
+// loadOIDCProviders reads the providers listed in OIDC_PROVIDERS. Each provider
+// is configured with OIDC_<NAME>_* variables.
+func loadOIDCProviders() []OIDCProviderConfig {
+	var providers []OIDCProviderConfig
+	for _, name := range strings.Split(getEnv("OIDC_PROVIDERS", ""), ",") {
+		name = strings.TrimSpace(strings.ToLower(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OIDCProviderConfig{
+			Name:         name,
+			IssuerURL:    getEnv(prefix+"ISSUER_URL", ""),
+			AuthURL:      getEnv(prefix+"AUTH_URL", ""),
+			TokenURL:     getEnv(prefix+"TOKEN_URL", ""),
+			UserInfoURL:  getEnv(prefix+"USERINFO_URL", ""),
+			ClientID:     getEnv(prefix+"CLIENT_ID", ""),
+			ClientSecret: getEnv(prefix+"CLIENT_SECRET", ""),
+			Scopes:       strings.Fields(getEnv(prefix+"SCOPES", "openid email profile")),
+		})
+	}
+	return providers
+}
+
+// defaultRateLimitPolicies are the policies referenced by the router. Each can
+// be overridden with RATE_LIMIT_<NAME>_LIMIT, _PERIOD and _KEY.
+var defaultRateLimitPolicies = []RateLimitPolicy{
+	{Name: "auth", Limit: 10, Period: time.Minute, Key: "ip"},
+	{Name: "public", Limit: 120, Period: time.Minute, Key: "ip"},
+	{Name: "search_clicks", Limit: 30, Period: time.Minute, Key: "ip"},
+	{Name: "user", Limit: 300, Period: time.Minute, Key: "user"},
+}
+
+func loadRateLimitPolicies() map[string]RateLimitPolicy {
+	policies := make(map[string]RateLimitPolicy, len(defaultRateLimitPolicies))
+	for _, policy := range defaultRateLimitPolicies {
+		prefix := "RATE_LIMIT_" + strings.ToUpper(policy.Name) + "_"
+		if limit, err := strconv.Atoi(getEnv(prefix+"LIMIT", "")); err == nil {
+			policy.Limit = limit
+		}
+		if period, err := time.ParseDuration(getEnv(prefix+"PERIOD", "")); err == nil {
+			policy.Period = period
+		}
+		policy.Key = getEnv(prefix+"KEY", policy.Key)
+
+		policies[policy.Name] = policy
+	}
+	return policies
+}
+
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
 		return value
 	}
 	return defaultValue
 }
+
+// getEnvList reads a comma separated list, dropping empty entries
+func getEnvList(key, defaultValue string) []string {
+	var values []string
+	for _, value := range strings.Split(getEnv(key, defaultValue), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -9,14 +9,20 @@ import (
 	"gorm.io/gorm/logger"
 )
 
-func New(cfg *config.DatabaseConfig) (*gorm.DB, error) {
-	dsn := fmt.Sprintf(
+// DSN returns the connection string for cfg
+func DSN(cfg *config.DatabaseConfig) string {
+	return fmt.Sprintf(
 		"host=%s user=%s password=%s dbname=%s port=%s sslmode=%s TimeZone=UTC",
 		cfg.Host, cfg.User, cfg.Password, cfg.Name, cfg.Port, cfg.SSLMode,
 	)
+}
 
-	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+func New(cfg *config.DatabaseConfig) (*gorm.DB, error) {
+	db, err := gorm.Open(postgres.Open(DSN(cfg)), &gorm.Config{
 		Logger: logger.Default.LogMode(logger.Info),
+		// Report constraint violations as gorm.ErrDuplicatedKey and
+		// gorm.ErrForeignKeyViolated so they can be classified
+		TranslateError: true,
 	})
 
 	if err != nil {
@@ -20,21 +20,71 @@ type RefreshTokenRequest struct {
 }
 
 type AuthResponse struct {
-	User         UserResponse `json:"user"`
-	AccessToken  string       `json:"access_token"`
-	RefreshToken string       `json:"refresh_token"`
+	User              UserResponse `json:"user"`
+	AccessToken       string       `json:"access_token"`
+	RefreshToken      string       `json:"refresh_token"`
+	TwoFactorRequired bool         `json:"two_factor_required"`
+	ChallengeToken    string       `json:"challenge_token,omitempty"`
+}
+
+type OIDCCallbackRequest struct {
+	Provider  string `json:"-"`
+	Code      string `form:"code" binding:"required"`
+	State     string `form:"state" binding:"required"`
+	FlowState string `form:"-"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" binding:"required,email"`
 }
 
 type UserResponse struct {
-	ID        uint      `json:"id"`
-	Email     string    `json:"email"`
-	FirstName string    `json:"first_name"`
-	LastName  string    `json:"last_name"`
-	Phone     string    `json:"phone"`
-	Role      string    `json:"role"`
-	IsActive  bool      `json:"is_active"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID               uint       `json:"id"`
+	Email            string     `json:"email"`
+	FirstName        string     `json:"first_name"`
+	LastName         string     `json:"last_name"`
+	Phone            string     `json:"phone"`
+	Role             string     `json:"role"`
+	IsActive         bool       `json:"is_active"`
+	EmailVerifiedAt  *time.Time `json:"email_verified_at"`
+	TwoFactorEnabled bool       `json:"two_factor_enabled"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+}
+
+// ChangePasswordRequest changes the password of the current user. Every
+// session except the one holding RefreshToken is revoked.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" binding:"required"`
+	NewPassword     string `json:"new_password" binding:"required,min=8"`
+	RefreshToken    string `json:"refresh_token"`
+}
+
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"new_email" binding:"required,email"`
+	CurrentPassword string `json:"current_password" binding:"required"`
 }
 
 type UpdateProfileRequest struct {
@@ -42,3 +92,129 @@ type UpdateProfileRequest struct {
 	LastName  string `json:"last_name" binding:"required"`
 	Phone     string `json:"phone"`
 }
+
+type RoleResponse struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type AssignRoleRequest struct {
+	Role string `json:"role" binding:"required"`
+}
+
+type CreateAPIKeyRequest struct {
+	UserID    uint       `json:"user_id"`
+	Name      string     `json:"name" binding:"required,max=100"`
+	Scopes    []string   `json:"scopes"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type APIKeyResponse struct {
+	ID         uint       `json:"id"`
+	UserID     uint       `json:"user_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+// CreatedAPIKeyResponse includes the plaintext key, which is only returned once
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key"`
+}
+
+type AdminUserSearchRequest struct {
+	Query    string `form:"q"`
+	Role     string `form:"role"`
+	IsActive *bool  `form:"is_active"`
+	Page     int    `form:"page"`
+	Limit    int    `form:"limit"`
+}
+
+type AdminUserResponse struct {
+	UserResponse
+	Roles            []string   `json:"roles"`
+	LockedUntil      *time.Time `json:"locked_until"`
+	IsServiceAccount bool       `json:"is_service_account"`
+}
+
+type UpdateUserStatusRequest struct {
+	IsActive *bool `json:"is_active" binding:"required"`
+}
+
+// UpdateServiceAccountRequest marks an account as used by an integration.
+// Only service accounts can be given API keys by someone else.
+type UpdateServiceAccountRequest struct {
+	IsServiceAccount *bool `json:"is_service_account" binding:"required"`
+}
+
+type SetUserRolesRequest struct {
+	Roles []string `json:"roles"`
+}
+
+type ImpersonateRequest struct {
+	Reason string `json:"reason" binding:"required,max=500"`
+}
+
+type ImpersonationResponse struct {
+	User           UserResponse `json:"user"`
+	AccessToken    string       `json:"access_token"`
+	ExpiresAt      time.Time    `json:"expires_at"`
+	ImpersonatorID uint         `json:"impersonator_id"`
+}
+
+// DeleteAccountRequest confirms account deletion. The password is required
+// unless the account was created through a social login.
+// DeleteAccountRequest confirms account deletion. Accounts with a password
+// must give it. Social login accounts give a two-factor code when 2FA is
+// enabled and otherwise must have signed in within the last few minutes.
+type DeleteAccountRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type LinkedIdentityResponse struct {
+	Provider  string    `json:"provider"`
+	Email     string    `json:"email"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// UserDataExport is everything the shop stores about a user
+type UserDataExport struct {
+	ExportedAt     time.Time                `json:"exported_at"`
+	Profile        UserResponse             `json:"profile"`
+	LinkedAccounts []LinkedIdentityResponse `json:"linked_accounts"`
+	Cart           *CartResponse            `json:"cart"`
+	Orders         []OrderResponse          `json:"orders"`
+}
+
+type AuditLogSearchRequest struct {
+	ActorID    *uint      `form:"actor_id"`
+	Action     string     `form:"action"`
+	EntityType string     `form:"entity_type"`
+	EntityID   string     `form:"entity_id"`
+	From       *time.Time `form:"from"`
+	To         *time.Time `form:"to"`
+	Page       int        `form:"page"`
+	Limit      int        `form:"limit"`
+}
+
+type AuditLogResponse struct {
+	ID         uint           `json:"id"`
+	ActorID    *uint          `json:"actor_id"`
+	Action     string         `json:"action"`
+	EntityType string         `json:"entity_type"`
+	EntityID   string         `json:"entity_id"`
+	Before     map[string]any `json:"before"`
+	After      map[string]any `json:"after"`
+	IPAddress  string         `json:"ip_address"`
+	UserAgent  string         `json:"user_agent"`
+	RequestID  string         `json:"request_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
@@ -5,6 +5,9 @@ import "time"
 type AddToCartRequest struct {
 	ProductID uint `json:"product_id" binding:"required"`
 	Quantity  int  `json:"quantity" binding:"required,min=1"`
+
+	// SearchID attributes the addition to the search the product was found by
+	SearchID *uint `json:"search_id,omitempty"`
 }
 
 type UpdateCartItemRequest struct {
@@ -39,8 +42,21 @@ type OrderResponse struct {
 	UpdatedAt   time.Time           `json:"updated_at"`
 }
 
+// AdminOrderListRequest filters the orders of every customer
+type AdminOrderListRequest struct {
+	UserID *uint  `form:"user_id"`
+	Status string `form:"status" binding:"omitempty,oneof=pending confirmed shipped delivered cancelled"`
+	Page   int    `form:"page"`
+	Limit  int    `form:"limit"`
+}
+
+type UpdateOrderStatusRequest struct {
+	Status string `json:"status" binding:"required,oneof=pending confirmed shipped delivered cancelled"`
+}
+
 type OrderItemResponse struct {
 	ID        uint            `json:"id"`
+	ProductID uint            `json:"product_id"`
 	Product   ProductResponse `json:"product"`
 	Quantity  int             `json:"quantity"`
 	Price     float64         `json:"price"`
@@ -0,0 +1,10 @@
+package dto
+
+// CursorPageRequest selects a page of a keyset-paginated list. First and
+// After page forwards from a cursor, Last and Before page backwards.
+type CursorPageRequest struct {
+	First  int
+	After  string
+	Last   int
+	Before string
+}
@@ -1,20 +1,73 @@
 package dto
 
-import "time"
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Sort keys accepted by product listings
+const (
+	ProductSortPriceAsc    = "price_asc"
+	ProductSortPriceDesc   = "price_desc"
+	ProductSortNewest      = "newest"
+	ProductSortName        = "name"
+	ProductSortBestSelling = "best_selling"
+)
+
+// Attributes are free-form product properties such as colour or size that
+// listings can be filtered by
+type Attributes map[string]string
+
+// MarshalGQL lets Attributes be used as a GraphQL scalar
+func (a Attributes) MarshalGQL(w io.Writer) {
+	if a == nil {
+		a = Attributes{}
+	}
+
+	data, _ := json.Marshal(map[string]string(a))
+	w.Write(data)
+}
+
+// UnmarshalGQL accepts an object whose values are all strings
+func (a *Attributes) UnmarshalGQL(v any) error {
+	values, ok := v.(map[string]any)
+	if !ok {
+		return fmt.Errorf("attributes must be an object")
+	}
+
+	result := make(Attributes, len(values))
+	for key, value := range values {
+		s, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("attribute %q must be a string", key)
+		}
+		result[key] = s
+	}
+
+	*a = result
+	return nil
+}
 
 type CreateCategoryRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	ParentID    *uint  `json:"parent_id"`
 }
 
+// UpdateCategoryRequest replaces a category. A missing ParentID makes it a
+// top-level category.
 type UpdateCategoryRequest struct {
 	Name        string `json:"name" binding:"required"`
 	Description string `json:"description"`
+	ParentID    *uint  `json:"parent_id"`
 	IsActive    *bool  `json:"is_active"`
 }
 
 type CategoryResponse struct {
 	ID          uint      `json:"id"`
+	ParentID    *uint     `json:"parent_id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
 	IsActive    bool      `json:"is_active"`
@@ -23,21 +76,23 @@ type CategoryResponse struct {
 }
 
 type CreateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	SKU         string  `json:"sku" binding:"required"`
+	CategoryID  uint       `json:"category_id" binding:"required"`
+	Name        string     `json:"name" binding:"required"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price" binding:"required,gt=0"`
+	Stock       int        `json:"stock" binding:"min=0"`
+	SKU         string     `json:"sku" binding:"required"`
+	Attributes  Attributes `json:"attributes" binding:"max=50,dive,keys,required,max=50,endkeys,max=200"`
 }
 
 type UpdateProductRequest struct {
-	CategoryID  uint    `json:"category_id" binding:"required"`
-	Name        string  `json:"name" binding:"required"`
-	Description string  `json:"description"`
-	Price       float64 `json:"price" binding:"required,gt=0"`
-	Stock       int     `json:"stock" binding:"min=0"`
-	IsActive    *bool   `json:"is_active"`
+	CategoryID  uint       `json:"category_id" binding:"required"`
+	Name        string     `json:"name" binding:"required"`
+	Description string     `json:"description"`
+	Price       float64    `json:"price" binding:"required,gt=0"`
+	Stock       int        `json:"stock" binding:"min=0"`
+	IsActive    *bool      `json:"is_active"`
+	Attributes  Attributes `json:"attributes" binding:"max=50,dive,keys,required,max=50,endkeys,max=200"` // unchanged when omitted
 }
 
 type ProductResponse struct {
@@ -49,6 +104,8 @@ type ProductResponse struct {
 	Stock       int                    `json:"stock"`
 	SKU         string                 `json:"sku"`
 	IsActive    bool                   `json:"is_active"`
+	Attributes  Attributes             `json:"attributes"`
+	SalesCount  int                    `json:"sales_count"`
 	Category    CategoryResponse       `json:"category"`
 	Images      []ProductImageResponse `json:"images"`
 	CreatedAt   time.Time              `json:"created_at"`
@@ -63,16 +120,91 @@ type ProductImageResponse struct {
 	CreatedAt time.Time `json:"created_at"`
 }
 
+// ProductFilter narrows a product listing or search. CategoryID matches the
+// category and all of its descendants, and every attribute must match exactly.
+type ProductFilter struct {
+	CategoryID *uint      `form:"category_id"`
+	MinPrice   *float64   `form:"min_price"`
+	MaxPrice   *float64   `form:"max_price"`
+	InStock    bool       `form:"in_stock"`
+	Attributes Attributes `form:"-"`
+}
+
+type ProductListRequest struct {
+	ProductFilter
+	Sort  string `form:"sort" binding:"omitempty,oneof=price_asc price_desc newest name best_selling"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+}
+
 type SearchProductsRequest struct {
-	Query      string   `form:"q" binding:"required,min=1"`
-	Page       int      `form:"page"`
-	Limit      int      `form:"limit"`
-	CategoryID *uint    `form:"category_id"`
-	MinPrice   *float64 `form:"min_price"`
-	MaxPrice   *float64 `form:"max_price"`
+	ProductFilter
+	Query string `form:"q" binding:"required,min=1"`
+	Page  int    `form:"page"`
+	Limit int    `form:"limit"`
+
+	// SearchID is the search_id of the first page, quoted on later pages so
+	// their products can be attributed to the same search
+	SearchID uint `form:"search_id"`
 }
 
 type ProductSearchResult struct {
 	ProductResponse
 	Rank float32 `json:"rank"`
+
+	// Pinned results are listed first for the query regardless of rank
+	Pinned bool `json:"pinned"`
+}
+
+// SearchFacets summarises the products matching a search. Each facet ignores
+// the filter on its own dimension, so its buckets show how many results
+// changing that filter would give.
+type SearchFacets struct {
+	Categories  []CategoryFacet   `json:"categories"`
+	PriceRanges []PriceRangeFacet `json:"price_ranges"`
+	InStock     int64             `json:"in_stock"`
+	Attributes  []AttributeFacet  `json:"attributes"`
+}
+
+type CategoryFacet struct {
+	CategoryID uint   `json:"category_id"`
+	Name       string `json:"name"`
+	Count      int64  `json:"count"`
+}
+
+// PriceRangeFacet counts the products priced from Min (inclusive) up to Max
+// (exclusive)
+type PriceRangeFacet struct {
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Count int64   `json:"count"`
+}
+
+type AttributeFacet struct {
+	Name   string                `json:"name"`
+	Values []AttributeValueFacet `json:"values"`
+}
+
+type AttributeValueFacet struct {
+	Value string `json:"value"`
+	Count int64  `json:"count"`
+}
+
+// Kinds of search suggestion
+const (
+	SuggestionTypeCategory = "category"
+	SuggestionTypeProduct  = "product"
+)
+
+type SearchSuggestRequest struct {
+	Query string `form:"q" binding:"required,min=1,max=100"`
+	Limit int    `form:"limit"`
+}
+
+// SearchSuggestion completes a partial search query. ID refers to the
+// category or product named by Text, depending on Type.
+type SearchSuggestion struct {
+	Type string `json:"type"`
+	ID   uint   `json:"id"`
+	Text string `json:"text"`
 }
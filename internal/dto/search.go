@@ -0,0 +1,135 @@
+package dto
+
+import "time"
+
+// SearchDocument is the searchable view of a product kept in a search index.
+// CategoryIDs lists the product's category and all of its ancestors, so a
+// category filter also matches products in subcategories.
+type SearchDocument struct {
+	ID           uint              `json:"id"`
+	Name         string            `json:"name"`
+	Description  string            `json:"description"`
+	SKU          string            `json:"sku"`
+	CategoryID   uint              `json:"category_id"`
+	CategoryIDs  []uint            `json:"category_ids"`
+	CategoryName string            `json:"category_name"`
+	Price        float64           `json:"price"`
+	InStock      bool              `json:"in_stock"`
+	Attributes   map[string]string `json:"attributes"`
+	Boost        float64           `json:"boost"`
+	CreatedAt    time.Time         `json:"created_at"`
+}
+
+// SearchQuery asks a search index for a page of matching products. Products
+// matching any of the Alternatives, phrasings of Text with synonyms swapped
+// in, match as well. ExcludeIDs leaves products out of the hits but not the
+// facets. Facets are only counted when requested, and Limit may be 0 to count
+// them alone.
+type SearchQuery struct {
+	Text         string
+	Alternatives []string
+	Filter       ProductFilter
+	ExcludeIDs   []uint
+	Offset       int
+	Limit        int
+	Facets       bool
+}
+
+// SearchHits lists matching product IDs, most relevant first
+type SearchHits struct {
+	Hits   []SearchHit
+	Total  int64
+	Facets *SearchFacets
+}
+
+type SearchHit struct {
+	ID    uint
+	Score float64
+}
+
+// SearchClickRequest reports that a shopper opened a product from the results
+// of a search
+type SearchClickRequest struct {
+	SearchID  uint `json:"search_id" binding:"required"`
+	ProductID uint `json:"product_id" binding:"required"`
+}
+
+// SearchReportRequest selects the UTC days a search report covers, both
+// inclusive. It defaults to the last 30 days.
+type SearchReportRequest struct {
+	From  *time.Time `form:"from" time_format:"2006-01-02"`
+	To    *time.Time `form:"to" time_format:"2006-01-02"`
+	Limit int        `form:"limit"`
+}
+
+type SearchReport struct {
+	From              time.Time          `json:"from"`
+	To                time.Time          `json:"to"`
+	Totals            SearchStats        `json:"totals"`
+	TopQueries        []SearchQueryStats `json:"top_queries"`
+	ZeroResultQueries []SearchQueryStats `json:"zero_result_queries"`
+}
+
+// SearchStats summarises searches. The rates are the share of searches
+// after which a result was clicked or added to the cart.
+type SearchStats struct {
+	Searches           int64   `json:"searches"`
+	ZeroResultSearches int64   `json:"zero_result_searches"`
+	ClickedSearches    int64   `json:"clicked_searches"`
+	AddToCartSearches  int64   `json:"add_to_cart_searches"`
+	ClickThroughRate   float64 `json:"click_through_rate"`
+	AddToCartRate      float64 `json:"add_to_cart_rate"`
+}
+
+type SearchQueryStats struct {
+	Query string `json:"query"`
+	SearchStats
+}
+
+// SynonymSetRequest lists terms, single words or phrases, that should match
+// each other in searches
+type SynonymSetRequest struct {
+	Terms []string `json:"terms" binding:"required,min=2,max=20,dive,required,max=100"`
+}
+
+type SynonymSetResponse struct {
+	ID        uint      `json:"id"`
+	Terms     []string  `json:"terms"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ProductBoostRequest sets how a product ranks in searches. Each point
+// doubles its relevance score and negative values bury it; 0 removes the
+// adjustment.
+type ProductBoostRequest struct {
+	Boost *float64 `json:"boost" binding:"required,min=-10,max=10"`
+}
+
+type ProductBoostResponse struct {
+	ProductID uint    `json:"product_id"`
+	Name      string  `json:"name"`
+	SKU       string  `json:"sku"`
+	Boost     float64 `json:"boost"`
+}
+
+// SearchPinRequest pins a product to the top of the results of a query.
+// Pins with lower positions come first.
+type SearchPinRequest struct {
+	Query     string `json:"query" binding:"required,max=200"`
+	ProductID uint   `json:"product_id" binding:"required"`
+	Position  int    `json:"position" binding:"min=0"`
+}
+
+type SearchPinListRequest struct {
+	Query string `form:"query"`
+}
+
+type SearchPinResponse struct {
+	ID          uint      `json:"id"`
+	Query       string    `json:"query"`
+	ProductID   uint      `json:"product_id"`
+	ProductName string    `json:"product_name"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,12 @@
+package interfaces
+
+import "context"
+
+// PubSub fans out messages to every subscriber of a topic. Delivery is best
+// effort: slow subscribers may miss messages, so payloads should be treated as
+// change notifications rather than a durable log. The channel returned by
+// Subscribe is closed once ctx is done.
+type PubSub interface {
+	Publish(ctx context.Context, topic string, payload []byte) error
+	Subscribe(ctx context.Context, topic string) (<-chan []byte, error)
+}
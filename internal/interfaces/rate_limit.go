@@ -0,0 +1,9 @@
+package interfaces
+
+// RateLimitStore keeps token buckets shared by the rate limiter. Take refills
+// the bucket for key at refillRate tokens per second up to capacity, removes
+// one token if available and returns the tokens left and whether the request
+// is allowed.
+type RateLimitStore interface {
+	Take(key string, capacity int, refillRate float64) (tokens float64, allowed bool, err error)
+}
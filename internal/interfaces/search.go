@@ -0,0 +1,19 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+)
+
+// SearchIndex keeps searchable copies of active products. Index adds or
+// replaces documents by ID and Delete ignores unknown IDs, so both may be
+// repeated safely. IDs lists every indexed document so stale ones can be
+// found. Indexes backed by the products table itself treat Index and Delete
+// as no-ops and have no IDs of their own.
+type SearchIndex interface {
+	Index(ctx context.Context, documents []dto.SearchDocument) error
+	Delete(ctx context.Context, ids []uint) error
+	IDs(ctx context.Context) ([]uint, error)
+	Search(ctx context.Context, query *dto.SearchQuery) (*dto.SearchHits, error)
+}
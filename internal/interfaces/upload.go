@@ -1,8 +1,8 @@
 package interfaces
 
-import "mime/multipart"
+import "io"
 
 type UploadProvider interface {
-	UploadFile(file *multipart.FileHeader, path string) (string, error)
+	UploadFile(file io.Reader, path string) (string, error)
 	DeleteFile(path string) error
 }
@@ -0,0 +1,62 @@
+package models
+
+import "time"
+
+// AuditLog records a security relevant or administrative action. For updates
+// Before and After only contain the fields that changed.
+type AuditLog struct {
+	ID         uint           `json:"id" gorm:"primaryKey"`
+	ActorID    *uint          `json:"actor_id"`
+	Action     string         `json:"action" gorm:"not null"`
+	EntityType string         `json:"entity_type" gorm:"not null"`
+	EntityID   string         `json:"entity_id"`
+	Before     map[string]any `json:"before" gorm:"serializer:json"`
+	After      map[string]any `json:"after" gorm:"serializer:json"`
+	IPAddress  string         `json:"ip_address"`
+	UserAgent  string         `json:"user_agent"`
+	RequestID  string         `json:"request_id"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+const (
+	AuditEntityCategory   = "category"
+	AuditEntityProduct    = "product"
+	AuditEntityUser       = "user"
+	AuditEntityAPIKey     = "api_key"
+	AuditEntitySynonymSet = "search_synonym_set"
+	AuditEntitySearchPin  = "search_pin"
+)
+
+const (
+	AuditActionCategoryCreate = "category.create"
+	AuditActionCategoryUpdate = "category.update"
+	AuditActionCategoryDelete = "category.delete"
+
+	AuditActionProductCreate      = "product.create"
+	AuditActionProductUpdate      = "product.update"
+	AuditActionProductDelete      = "product.delete"
+	AuditActionProductImageCreate = "product.image_create"
+	AuditActionProductSearchBoost = "product.search_boost"
+
+	AuditActionSynonymSetCreate = "search_synonym_set.create"
+	AuditActionSynonymSetUpdate = "search_synonym_set.update"
+	AuditActionSynonymSetDelete = "search_synonym_set.delete"
+	AuditActionSearchPinCreate  = "search_pin.create"
+	AuditActionSearchPinDelete  = "search_pin.delete"
+
+	AuditActionLogin           = "auth.login"
+	AuditActionLoginFailed     = "auth.login_failed"
+	AuditActionAccountLocked   = "auth.account_locked"
+	AuditActionAccountUnlocked = "auth.account_unlocked"
+
+	AuditActionUserStatusChange = "user.status_change"
+	AuditActionUserRolesChange  = "user.roles_change"
+	AuditActionUserImpersonate  = "user.impersonate"
+	AuditActionServiceAccount   = "user.service_account"
+	AuditActionPasswordChange   = "user.password_change"
+	AuditActionEmailChange      = "user.email_change"
+	AuditActionTwoFactorEnable  = "user.two_factor_enable"
+	AuditActionTwoFactorDisable = "user.two_factor_disable"
+	AuditActionAPIKeyCreate     = "api_key.create"
+	AuditActionAPIKeyRevoke     = "api_key.revoke"
+)
@@ -8,6 +8,7 @@ import (
 
 type Category struct {
 	ID          uint           `json:"id" gorm:"primaryKey"`
+	ParentID    *uint          `json:"parent_id"`
 	Name        string         `json:"name" gorm:"not null"`
 	Description string         `json:"description"`
 	IsActive    bool           `json:"is_active" gorm:"default:true"`
@@ -20,17 +21,26 @@ type Category struct {
 }
 
 type Product struct {
-	ID          uint           `json:"id" gorm:"primaryKey"`
-	CategoryID  uint           `json:"category_id" gorm:"not null"`
-	Name        string         `json:"name" gorm:"not null"`
-	Description string         `json:"description"`
-	Price       float64        `json:"price" gorm:"not null"`
-	Stock       int            `json:"stock" gorm:"default:0"`
-	SKU         string         `json:"sku" gorm:"uniqueIndex;not null"`
-	IsActive    bool           `json:"is_active" gorm:"default:true"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `json:"-" gorm:"index"`
+	ID          uint              `json:"id" gorm:"primaryKey"`
+	CategoryID  uint              `json:"category_id" gorm:"not null"`
+	Name        string            `json:"name" gorm:"not null"`
+	Description string            `json:"description"`
+	Price       float64           `json:"price" gorm:"not null"`
+	Stock       int               `json:"stock" gorm:"default:0"`
+	SKU         string            `json:"sku" gorm:"uniqueIndex;not null"`
+	IsActive    bool              `json:"is_active" gorm:"default:true"`
+	Attributes  map[string]string `json:"attributes" gorm:"serializer:json;not null"`
+	CreatedAt   time.Time         `json:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at"`
+	DeletedAt   gorm.DeletedAt    `json:"-" gorm:"index"`
+
+	// SalesCount is the number of units ordered. It is denormalised so the
+	// catalogue can be sorted by it.
+	SalesCount int `json:"sales_count" gorm:"default:0"`
+
+	// SearchBoost adjusts search relevance: each point doubles the score, and
+	// negative values bury the product
+	SearchBoost float64 `json:"search_boost" gorm:"default:0"`
 
 	// Relationships
 	Category   Category       `json:"category"`
@@ -0,0 +1,67 @@
+package models
+
+import "time"
+
+// SearchQuery logs a product search. Query is normalised so searches that
+// differ only in case or spacing are counted together.
+type SearchQuery struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Query       string    `json:"query" gorm:"not null"`
+	ResultCount int64     `json:"result_count" gorm:"not null"`
+	UserID      *uint     `json:"user_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// SearchResult records a product returned by a search
+type SearchResult struct {
+	SearchQueryID uint `json:"search_query_id" gorm:"primaryKey"`
+	ProductID     uint `json:"product_id" gorm:"primaryKey"`
+}
+
+// SearchEvent records a shopper acting on a product found by a search
+type SearchEvent struct {
+	ID            uint      `json:"id" gorm:"primaryKey"`
+	SearchQueryID uint      `json:"search_query_id" gorm:"not null"`
+	ProductID     uint      `json:"product_id" gorm:"not null"`
+	Type          string    `json:"type" gorm:"not null"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+const (
+	SearchEventClick     = "click"
+	SearchEventAddToCart = "add_to_cart"
+)
+
+// SearchQueryDailyStats rolls up the searches for a query on one UTC day.
+// Clicked and add-to-cart searches count searches with at least one such
+// event, however many products were acted on.
+type SearchQueryDailyStats struct {
+	Day                time.Time `json:"day" gorm:"primaryKey;type:date"`
+	Query              string    `json:"query" gorm:"primaryKey"`
+	Searches           int64     `json:"searches"`
+	ZeroResultSearches int64     `json:"zero_result_searches"`
+	ClickedSearches    int64     `json:"clicked_searches"`
+	AddToCartSearches  int64     `json:"add_to_cart_searches"`
+}
+
+// SearchSynonymSet lists normalised terms that match each other in searches.
+// Terms may be phrases of several words.
+type SearchSynonymSet struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Terms     []string  `json:"terms" gorm:"serializer:json;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SearchPin lists a product first in the results of a normalised query.
+// Pins with lower positions come first.
+type SearchPin struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	Query     string    `json:"query" gorm:"not null"`
+	ProductID uint      `json:"product_id" gorm:"not null"`
+	Position  int       `json:"position" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// Relationships
+	Product Product `json:"product"`
+}
@@ -7,22 +7,44 @@ import (
 )
 
 type User struct {
-	ID        uint           `json:"id" gorm:"primaryKey"`
-	Email     string         `json:"email" gorm:"uniqueIndex;not null"`
-	Password  string         `json:"-" gorm:"not null"`
-	FirstName string         `json:"first_name" gorm:"not null"`
-	LastName  string         `json:"last_name" gorm:"not null"`
-	Phone     string         `json:"phone"`
-	IsActive  bool           `json:"is_active" gorm:"default:true"`
-	Role      UserRole       `json:"role" gorm:"default:customer"`
-	CreatedAt time.Time      `json:"created_at"`
-	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	ID                  uint           `json:"id" gorm:"primaryKey"`
+	Email               string         `json:"email" gorm:"uniqueIndex;not null"`
+	Password            string         `json:"-" gorm:"not null"`
+	PasswordSet         bool           `json:"-" gorm:"default:false"`
+	FirstName           string         `json:"first_name" gorm:"not null"`
+	LastName            string         `json:"last_name" gorm:"not null"`
+	Phone               string         `json:"phone"`
+	IsActive            bool           `json:"is_active" gorm:"default:true"`
+	Role                UserRole       `json:"role" gorm:"default:customer"`
+	IsServiceAccount    bool           `json:"is_service_account" gorm:"default:false"`
+	EmailVerifiedAt     *time.Time     `json:"email_verified_at"`
+	TwoFactorEnabled    bool           `json:"two_factor_enabled" gorm:"default:false"`
+	TwoFactorSecret     string         `json:"-"`
+	FailedLoginAttempts int            `json:"-" gorm:"default:0"`
+	LastFailedLoginAt   *time.Time     `json:"-"`
+	LockedUntil         *time.Time     `json:"locked_until"`
+	DeletionRequestedAt *time.Time     `json:"-"`
+	AnonymizedAt        *time.Time     `json:"-"`
+	CreatedAt           time.Time      `json:"created_at"`
+	UpdatedAt           time.Time      `json:"updated_at"`
+	DeletedAt           gorm.DeletedAt `json:"-" gorm:"index"`
 
 	// Relationships
-	RefreshTokens []RefreshToken `json:"-"`
-	Orders        []Order        `json:"-"`
-	Cart          Cart           `json:"-"`
+	RefreshTokens      []RefreshToken      `json:"-"`
+	VerificationTokens []VerificationToken `json:"-"`
+	RecoveryCodes      []RecoveryCode      `json:"-"`
+	Identities         []UserIdentity      `json:"-"`
+	Roles              []Role              `json:"-" gorm:"many2many:user_roles"`
+	Orders             []Order             `json:"-"`
+	Cart               Cart                `json:"-"`
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(now)
 }
 
 type UserRole string
@@ -43,3 +65,137 @@ type RefreshToken struct {
 	// Relationships
 	User User `json:"-"`
 }
+
+// VerificationToken is a single-use token emailed to the user. Only the
+// SHA-256 hash of the token is stored.
+type VerificationToken struct {
+	ID        uint                `json:"id" gorm:"primaryKey"`
+	UserID    uint                `json:"user_id" gorm:"not null"`
+	TokenHash string              `json:"-" gorm:"uniqueIndex;not null"`
+	Purpose   VerificationPurpose `json:"purpose" gorm:"not null"`
+	Email     string              `json:"email" gorm:"not null"`
+	ExpiresAt time.Time           `json:"expires_at" gorm:"not null"`
+	UsedAt    *time.Time          `json:"used_at"`
+	CreatedAt time.Time           `json:"created_at"`
+	DeletedAt gorm.DeletedAt      `json:"-" gorm:"index"`
+
+	// Relationships
+	User User `json:"-"`
+}
+
+type VerificationPurpose string
+
+const (
+	VerificationPurposeEmail              VerificationPurpose = "email_verification"
+	VerificationPurposeTwoFactorChallenge VerificationPurpose = "two_factor_challenge"
+	VerificationPurposeEmailChange        VerificationPurpose = "email_change"
+)
+
+// RecoveryCode is a single-use fallback for TOTP codes. Only the SHA-256 hash
+// of the code is stored.
+type RecoveryCode struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    uint           `json:"user_id" gorm:"not null"`
+	CodeHash  string         `json:"-" gorm:"not null"`
+	UsedAt    *time.Time     `json:"used_at"`
+	CreatedAt time.Time      `json:"created_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	User User `json:"-"`
+}
+
+// LoginThrottle tracks failed login attempts from a single IP address
+type LoginThrottle struct {
+	ID             uint       `json:"id" gorm:"primaryKey"`
+	IPAddress      string     `json:"ip_address" gorm:"uniqueIndex;not null"`
+	FailedAttempts int        `json:"failed_attempts" gorm:"default:0"`
+	LastFailedAt   time.Time  `json:"last_failed_at"`
+	LockedUntil    *time.Time `json:"locked_until"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+// UserIdentity links a user to an account at an external identity provider
+type UserIdentity struct {
+	ID        uint           `json:"id" gorm:"primaryKey"`
+	UserID    uint           `json:"user_id" gorm:"not null"`
+	Provider  string         `json:"provider" gorm:"not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Subject   string         `json:"subject" gorm:"not null;uniqueIndex:idx_user_identities_provider_subject"`
+	Email     string         `json:"email"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	User User `json:"-"`
+}
+
+// UserImpersonation records every impersonation token issued by staff
+type UserImpersonation struct {
+	ID        uint      `json:"id" gorm:"primaryKey"`
+	AdminID   uint      `json:"admin_id" gorm:"not null"`
+	UserID    uint      `json:"user_id" gorm:"not null"`
+	Reason    string    `json:"reason" gorm:"not null"`
+	IPAddress string    `json:"ip_address"`
+	ExpiresAt time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Role groups permissions that can be granted to staff accounts
+type Role struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"uniqueIndex;not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+
+	// Relationships
+	Permissions []Permission `json:"permissions" gorm:"many2many:role_permissions"`
+}
+
+type Permission struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	Name        string    `json:"name" gorm:"uniqueIndex;not null"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+const (
+	PermissionProductsWrite       = "products:write"
+	PermissionCategoriesWrite     = "categories:write"
+	PermissionOrdersRead          = "orders:read"
+	PermissionOrdersWrite         = "orders:write"
+	PermissionUsersRead           = "users:read"
+	PermissionUsersWrite          = "users:write"
+	PermissionRolesManage         = "roles:manage"
+	PermissionAPIKeysManage       = "api_keys:manage"
+	PermissionUsersImpersonate    = "users:impersonate"
+	PermissionAuditLogsRead       = "audit_logs:read"
+	PermissionSearchAnalyticsRead = "search_analytics:read"
+	PermissionSearchManage        = "search:manage"
+)
+
+// APIKey authenticates server-to-server integrations as its owner. Only the
+// SHA-256 hash of the key is stored; the prefix identifies it in listings.
+type APIKey struct {
+	ID          uint       `json:"id" gorm:"primaryKey"`
+	UserID      uint       `json:"user_id" gorm:"not null"`
+	CreatedByID *uint      `json:"created_by_id"`
+	Name        string     `json:"name" gorm:"not null"`
+	Prefix      string     `json:"prefix" gorm:"not null"`
+	KeyHash     string     `json:"-" gorm:"uniqueIndex;not null"`
+	Scopes      []string   `json:"scopes" gorm:"serializer:json;not null"`
+	ExpiresAt   *time.Time `json:"expires_at"`
+	LastUsedAt  *time.Time `json:"last_used_at"`
+	RevokedAt   *time.Time `json:"revoked_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+
+	// Relationships
+	User User `json:"-"`
+}
+
+func (k *APIKey) IsUsable(now time.Time) bool {
+	return k.RevokedAt == nil && (k.ExpiresAt == nil || k.ExpiresAt.After(now))
+}
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"net"
 	"net/smtp"
+	"strconv"
+	"time"
 )
 
 type SMTPConfig struct {
@@ -31,7 +33,7 @@ func NewEmailNotifier(config *SMTPConfig) *EmailNotifier {
 }
 
 func (e *EmailNotifier) SendSimpleEmail(email *SimpleEmail) error {
-	addr := fmt.Sprintf("%s:%d", e.config.Host, e.config.Port)
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
 
 	// Connect directly without TLS for development
 	conn, err := net.Dial("tcp", addr)
@@ -98,3 +100,97 @@ The Shop Team`, userName),
 
 	return e.SendSimpleEmail(email)
 }
+
+func (e *EmailNotifier) SendEmailVerification(userEmail, userName, verifyURL string) error {
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(`Hello %s,
+
+Please confirm your email address by opening the link below:
+
+%s
+
+If you did not create an account, you can ignore this email.
+
+Best regards,
+The Shop Team`, userName, verifyURL),
+	}
+
+	return e.SendSimpleEmail(email)
+}
+
+func (e *EmailNotifier) SendAccountLockedNotification(userEmail, userName string, lockedUntil time.Time) error {
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: "Your account has been temporarily locked",
+		Body: fmt.Sprintf(`Hello %s,
+
+We detected several failed login attempts on your account, so it has been
+locked until %s.
+
+If this wasn't you, we recommend changing your password once you can log in
+again. Contact support if you need your account unlocked sooner.
+
+Best regards,
+The Shop Team`, userName, lockedUntil.UTC().Format(time.RFC1123)),
+	}
+
+	return e.SendSimpleEmail(email)
+}
+
+func (e *EmailNotifier) SendAccountDeletedNotification(userEmail, userName string, deletedAt time.Time) error {
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: "Your account has been deleted",
+		Body: fmt.Sprintf(`Hello %s,
+
+As requested, your account was deleted on %s. Your personal details have
+been removed from our systems. Order records are kept without your personal
+details where we are required to retain them for accounting.
+
+This is the last email you will receive from us.
+
+Best regards,
+The Shop Team`, userName, deletedAt.UTC().Format(time.RFC1123)),
+	}
+
+	return e.SendSimpleEmail(email)
+}
+
+func (e *EmailNotifier) SendEmailChangeConfirmation(userEmail, userName, confirmURL string) error {
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(`Hello %s,
+
+Please confirm that you want to use this address for your account by opening
+the link below:
+
+%s
+
+If you did not request this change, you can ignore this email.
+
+Best regards,
+The Shop Team`, userName, confirmURL),
+	}
+
+	return e.SendSimpleEmail(email)
+}
+
+func (e *EmailNotifier) SendEmailChangedNotification(userEmail, userName, newEmail string) error {
+	email := &SimpleEmail{
+		To:      userEmail,
+		Subject: "Your email address was changed",
+		Body: fmt.Sprintf(`Hello %s,
+
+The email address of your account was changed to %s.
+
+If you did not make this change, please contact support immediately.
+
+Best regards,
+The Shop Team`, userName, newEmail),
+	}
+
+	return e.SendSimpleEmail(email)
+}
@@ -1,5 +1,55 @@
 package notifications
 
+import "time"
+
 const (
-	UserLoggedIn = "USER_LOGGED_IN"
+	UserLoggedIn               = "USER_LOGGED_IN"
+	EmailVerificationRequested = "EMAIL_VERIFICATION_REQUESTED"
+	AccountLocked              = "ACCOUNT_LOCKED"
+	AccountDeleted             = "ACCOUNT_DELETED"
+	EmailChangeRequested       = "EMAIL_CHANGE_REQUESTED"
+	EmailChanged               = "EMAIL_CHANGED"
 )
+
+// EmailVerificationMessage is the payload of an EmailVerificationRequested event
+type EmailVerificationMessage struct {
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	VerifyURL string `json:"verify_url"`
+}
+
+// AccountLockedMessage is the payload of an AccountLocked event
+type AccountLockedMessage struct {
+	Email       string    `json:"email"`
+	FirstName   string    `json:"first_name"`
+	LastName    string    `json:"last_name"`
+	LockedUntil time.Time `json:"locked_until"`
+}
+
+// AccountDeletedMessage is the payload of an AccountDeleted event. It carries
+// the contact details captured before the account was anonymised.
+type AccountDeletedMessage struct {
+	Email     string    `json:"email"`
+	FirstName string    `json:"first_name"`
+	LastName  string    `json:"last_name"`
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// EmailChangeMessage is the payload of an EmailChangeRequested event, sent to
+// the new address
+type EmailChangeMessage struct {
+	Email      string `json:"email"`
+	FirstName  string `json:"first_name"`
+	LastName   string `json:"last_name"`
+	ConfirmURL string `json:"confirm_url"`
+}
+
+// EmailChangedMessage is the payload of an EmailChanged event, sent to the
+// previous address
+type EmailChangedMessage struct {
+	Email     string `json:"email"`
+	NewEmail  string `json:"new_email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
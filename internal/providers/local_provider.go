@@ -2,7 +2,7 @@ package providers
 
 import (
 	"fmt"
-	"mime/multipart"
+	"io"
 	"os"
 	"path/filepath"
 )
@@ -15,7 +15,7 @@ func NewLocalUploadProvider(basePath string) *LocalUploadProvider {
 	return &LocalUploadProvider{basePath: basePath}
 }
 
-func (p *LocalUploadProvider) UploadFile(file *multipart.FileHeader, path string) (string, error) {
+func (p *LocalUploadProvider) UploadFile(file io.Reader, path string) (string, error) {
 
 	fullPath := filepath.Join(p.basePath, path)
 
@@ -23,13 +23,6 @@ func (p *LocalUploadProvider) UploadFile(file *multipart.FileHeader, path string
 		return "", err
 	}
 
-	// Open source file
-	src, err := file.Open()
-	if err != nil {
-		return "", err
-	}
-	defer src.Close()
-
 	// create destination
 	dst, err := os.Create(fullPath)
 	if err != nil {
@@ -38,7 +31,7 @@ func (p *LocalUploadProvider) UploadFile(file *multipart.FileHeader, path string
 	defer dst.Close()
 
 	// read from source to destination
-	if _, err := dst.ReadFrom(src); err != nil {
+	if _, err := dst.ReadFrom(file); err != nil {
 		return "", err
 	}
 
@@ -0,0 +1,66 @@
+package providers
+
+import (
+	"context"
+	"sync"
+)
+
+// subscriberBuffer is how many messages a subscriber may fall behind before
+// further messages to it are dropped
+const subscriberBuffer = 16
+
+// MemoryPubSub delivers messages within the process. Subscribers on other
+// replicas do not receive them.
+type MemoryPubSub struct {
+	mu          sync.RWMutex
+	subscribers map[string]map[chan []byte]struct{}
+}
+
+func NewMemoryPubSub() *MemoryPubSub {
+	return &MemoryPubSub{
+		subscribers: make(map[string]map[chan []byte]struct{}),
+	}
+}
+
+func (p *MemoryPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	p.deliver(topic, payload)
+	return nil
+}
+
+func (p *MemoryPubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	ch := make(chan []byte, subscriberBuffer)
+
+	p.mu.Lock()
+	if p.subscribers[topic] == nil {
+		p.subscribers[topic] = make(map[chan []byte]struct{})
+	}
+	p.subscribers[topic][ch] = struct{}{}
+	p.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		p.mu.Lock()
+		delete(p.subscribers[topic], ch)
+		if len(p.subscribers[topic]) == 0 {
+			delete(p.subscribers, topic)
+		}
+		p.mu.Unlock()
+
+		close(ch)
+	}()
+
+	return ch, nil
+}
+
+func (p *MemoryPubSub) deliver(topic string, payload []byte) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	for ch := range p.subscribers[topic] {
+		select {
+		case ch <- payload:
+		default:
+		}
+	}
+}
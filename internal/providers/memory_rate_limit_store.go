@@ -0,0 +1,70 @@
+package providers
+
+import (
+	"math"
+	"sync"
+	"time"
+)
+
+const rateLimitSweepInterval = time.Minute
+
+type tokenBucket struct {
+	tokens    float64
+	updatedAt time.Time
+	expiresAt time.Time
+}
+
+// MemoryRateLimitStore keeps buckets in process memory. Limits are not shared
+// between replicas.
+type MemoryRateLimitStore struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastSweep time.Time
+}
+
+func NewMemoryRateLimitStore() *MemoryRateLimitStore {
+	return &MemoryRateLimitStore{
+		buckets:   make(map[string]*tokenBucket),
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *MemoryRateLimitStore) Take(key string, capacity int, refillRate float64) (float64, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	if now.Sub(s.lastSweep) > rateLimitSweepInterval {
+		s.sweep(now)
+	}
+
+	bucket, ok := s.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: float64(capacity), updatedAt: now}
+		s.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.updatedAt).Seconds()
+	bucket.tokens = math.Min(float64(capacity), bucket.tokens+elapsed*refillRate)
+	bucket.updatedAt = now
+
+	allowed := bucket.tokens >= 1
+	if allowed {
+		bucket.tokens--
+	}
+
+	// Once the bucket has refilled it is indistinguishable from a new one
+	refillSeconds := (float64(capacity) - bucket.tokens) / refillRate
+	bucket.expiresAt = now.Add(time.Duration(refillSeconds * float64(time.Second)))
+
+	return bucket.tokens, allowed, nil
+}
+
+func (s *MemoryRateLimitStore) sweep(now time.Time) {
+	for key, bucket := range s.buckets {
+		if now.After(bucket.expiresAt) {
+			delete(s.buckets, key)
+		}
+	}
+	s.lastSweep = now
+}
@@ -0,0 +1,290 @@
+package providers
+
+import (
+	"cmp"
+	"context"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+)
+
+// Relevance of a query word found in each document field
+const (
+	memorySearchNameWeight     = 3
+	memorySearchSKUWeight      = 2
+	memorySearchCategoryWeight = 1
+	memorySearchTextWeight     = 1
+)
+
+// MemorySearchIndex keeps documents in process memory and scans them on every
+// search. Each word of the query, or of one of its alternatives, must start a
+// word of the name, SKU, category or description. It suits tests and small
+// development catalogues; replicas do not share it.
+type MemorySearchIndex struct {
+	mu        sync.RWMutex
+	documents map[uint]dto.SearchDocument
+}
+
+func NewMemorySearchIndex() *MemorySearchIndex {
+	return &MemorySearchIndex{
+		documents: make(map[uint]dto.SearchDocument),
+	}
+}
+
+func (i *MemorySearchIndex) Index(ctx context.Context, documents []dto.SearchDocument) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, document := range documents {
+		i.documents[document.ID] = document
+	}
+
+	return nil
+}
+
+func (i *MemorySearchIndex) Delete(ctx context.Context, ids []uint) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for _, id := range ids {
+		delete(i.documents, id)
+	}
+
+	return nil
+}
+
+func (i *MemorySearchIndex) IDs(ctx context.Context) ([]uint, error) {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	ids := make([]uint, 0, len(i.documents))
+	for id := range i.documents {
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
+
+func (i *MemorySearchIndex) Search(ctx context.Context, query *dto.SearchQuery) (*dto.SearchHits, error) {
+	phrases := [][]string{searchWords(query.Text)}
+	for _, alternative := range query.Alternatives {
+		phrases = append(phrases, searchWords(alternative))
+	}
+
+	type candidate struct {
+		document *dto.SearchDocument
+		score    float64
+	}
+	var candidates []candidate
+
+	i.mu.RLock()
+	for id := range i.documents {
+		document := i.documents[id]
+		var score float64
+		for _, words := range phrases {
+			score = math.Max(score, memorySearchScore(&document, words))
+		}
+
+		if score > 0 {
+			candidates = append(candidates, candidate{document: &document, score: score * boostFactor(document.Boost)})
+		}
+	}
+	i.mu.RUnlock()
+
+	var hits []candidate
+	for _, c := range candidates {
+		if matchesFilter(c.document, &query.Filter) && !slices.Contains(query.ExcludeIDs, c.document.ID) {
+			hits = append(hits, c)
+		}
+	}
+
+	slices.SortFunc(hits, func(a, b candidate) int {
+		return cmp.Or(
+			cmp.Compare(b.score, a.score),
+			b.document.CreatedAt.Compare(a.document.CreatedAt),
+			cmp.Compare(a.document.ID, b.document.ID),
+		)
+	})
+
+	result := &dto.SearchHits{Hits: []dto.SearchHit{}, Total: int64(len(hits))}
+	if query.Offset < len(hits) {
+		for _, hit := range hits[query.Offset:min(query.Offset+query.Limit, len(hits))] {
+			result.Hits = append(result.Hits, dto.SearchHit{ID: hit.document.ID, Score: hit.score})
+		}
+	}
+
+	if query.Facets {
+		documents := make([]*dto.SearchDocument, len(candidates))
+		for n, c := range candidates {
+			documents[n] = c.document
+		}
+		result.Facets = memorySearchFacets(documents, &query.Filter)
+	}
+
+	return result, nil
+}
+
+// memorySearchScore sums the weight of the best field each query word
+// matches, or returns 0 unless every word matches
+func memorySearchScore(document *dto.SearchDocument, words []string) float64 {
+	if len(words) == 0 {
+		return 0
+	}
+
+	fields := []struct {
+		words  []string
+		weight float64
+	}{
+		{searchWords(document.Name), memorySearchNameWeight},
+		{searchWords(document.SKU), memorySearchSKUWeight},
+		{searchWords(document.CategoryName), memorySearchCategoryWeight},
+		{searchWords(document.Description), memorySearchTextWeight},
+	}
+
+	var score float64
+	for _, word := range words {
+		var best float64
+		for _, field := range fields {
+			if field.weight > best && slices.ContainsFunc(field.words, func(w string) bool {
+				return strings.HasPrefix(w, word)
+			}) {
+				best = field.weight
+			}
+		}
+
+		if best == 0 {
+			return 0
+		}
+		score += best
+	}
+
+	return score
+}
+
+// searchWords splits text into lowercase words
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+func matchesFilter(document *dto.SearchDocument, filter *dto.ProductFilter) bool {
+	if filter.CategoryID != nil && !slices.Contains(document.CategoryIDs, *filter.CategoryID) {
+		return false
+	}
+
+	if filter.MinPrice != nil && document.Price < *filter.MinPrice {
+		return false
+	}
+
+	if filter.MaxPrice != nil && document.Price > *filter.MaxPrice {
+		return false
+	}
+
+	if filter.InStock && !document.InStock {
+		return false
+	}
+
+	for name, value := range filter.Attributes {
+		if document.Attributes[name] != value {
+			return false
+		}
+	}
+
+	return true
+}
+
+func memorySearchFacets(documents []*dto.SearchDocument, filter *dto.ProductFilter) *dto.SearchFacets {
+	matching := func(filter *dto.ProductFilter) []*dto.SearchDocument {
+		var result []*dto.SearchDocument
+		for _, document := range documents {
+			if matchesFilter(document, filter) {
+				result = append(result, document)
+			}
+		}
+		return result
+	}
+
+	facets := &dto.SearchFacets{
+		Categories:  []dto.CategoryFacet{},
+		PriceRanges: []dto.PriceRangeFacet{},
+	}
+
+	categoryFilter := *filter
+	categoryFilter.CategoryID = nil
+	categoryCounts := make(map[uint]*dto.CategoryFacet)
+	for _, document := range matching(&categoryFilter) {
+		count, ok := categoryCounts[document.CategoryID]
+		if !ok {
+			count = &dto.CategoryFacet{CategoryID: document.CategoryID, Name: document.CategoryName}
+			categoryCounts[document.CategoryID] = count
+		}
+		count.Count++
+	}
+	for _, count := range categoryCounts {
+		facets.Categories = append(facets.Categories, *count)
+	}
+	slices.SortFunc(facets.Categories, func(a, b dto.CategoryFacet) int {
+		return cmp.Or(cmp.Compare(b.Count, a.Count), cmp.Compare(a.Name, b.Name))
+	})
+
+	priceFilter := *filter
+	priceFilter.MinPrice, priceFilter.MaxPrice = nil, nil
+	if priced := matching(&priceFilter); len(priced) > 0 {
+		low, high := math.Inf(1), math.Inf(-1)
+		for _, document := range priced {
+			low, high = math.Min(low, document.Price), math.Max(high, document.Price)
+		}
+
+		start, step := priceRangeBounds(low, high)
+		buckets := make(map[float64]int64)
+		for _, document := range priced {
+			buckets[math.Floor((document.Price-start)/step)]++
+		}
+		for bucket, count := range buckets {
+			lower := start + bucket*step
+			facets.PriceRanges = append(facets.PriceRanges, dto.PriceRangeFacet{Min: lower, Max: lower + step, Count: count})
+		}
+		slices.SortFunc(facets.PriceRanges, func(a, b dto.PriceRangeFacet) int {
+			return cmp.Compare(a.Min, b.Min)
+		})
+	}
+
+	stockFilter := *filter
+	stockFilter.InStock = false
+	for _, document := range matching(&stockFilter) {
+		if document.InStock {
+			facets.InStock++
+		}
+	}
+
+	valueCounts := make(map[attributeCount]int64)
+	for _, document := range matching(filter) {
+		for name, value := range document.Attributes {
+			if _, selected := filter.Attributes[name]; !selected {
+				valueCounts[attributeCount{Name: name, Value: value}]++
+			}
+		}
+	}
+	for name := range filter.Attributes {
+		others := withoutAttribute(filter, name)
+		for _, document := range matching(&others) {
+			if value, ok := document.Attributes[name]; ok {
+				valueCounts[attributeCount{Name: name, Value: value}]++
+			}
+		}
+	}
+
+	counts := make([]attributeCount, 0, len(valueCounts))
+	for key, count := range valueCounts {
+		key.Count = count
+		counts = append(counts, key)
+	}
+	facets.Attributes = groupAttributeCounts(counts)
+
+	return facets
+}
@@ -0,0 +1,299 @@
+package providers
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+)
+
+func testSearchDocuments() []dto.SearchDocument {
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	return []dto.SearchDocument{
+		{
+			ID: 1, Name: "Red Running Shoe", SKU: "SHOE-RED", CategoryID: 2, CategoryIDs: []uint{2, 1},
+			CategoryName: "Shoes", Price: 50, InStock: true,
+			Attributes: map[string]string{"color": "red", "size": "42"}, CreatedAt: created,
+		},
+		{
+			ID: 2, Name: "Blue Running Shoe", SKU: "SHOE-BLUE", CategoryID: 2, CategoryIDs: []uint{2, 1},
+			CategoryName: "Shoes", Price: 70, InStock: false,
+			Attributes: map[string]string{"color": "blue", "size": "42"}, CreatedAt: created.Add(time.Hour),
+		},
+		{
+			ID: 3, Name: "Crew Socks", Description: "Pairs for running", SKU: "SOCK-1",
+			CategoryID: 3, CategoryIDs: []uint{3, 1}, CategoryName: "Socks", Price: 10, InStock: true,
+			Attributes: map[string]string{"color": "red"}, CreatedAt: created.Add(2 * time.Hour),
+		},
+		{
+			ID: 4, Name: "Trail Jacket", SKU: "JACKET-1", CategoryID: 4, CategoryIDs: []uint{4},
+			CategoryName: "Jackets", Price: 120, InStock: true, CreatedAt: created.Add(3 * time.Hour),
+		},
+	}
+}
+
+func newTestMemorySearchIndex(t *testing.T) *MemorySearchIndex {
+	t.Helper()
+
+	index := NewMemorySearchIndex()
+	if err := index.Index(context.Background(), testSearchDocuments()); err != nil {
+		t.Fatalf("Index: %v", err)
+	}
+
+	return index
+}
+
+func hitIDs(hits *dto.SearchHits) []uint {
+	ids := make([]uint, len(hits.Hits))
+	for i, hit := range hits.Hits {
+		ids[i] = hit.ID
+	}
+	return ids
+}
+
+func TestMemorySearchIndexSearch(t *testing.T) {
+	category := uint(2)
+	minPrice, maxPrice := 20.0, 100.0
+
+	tests := []struct {
+		name      string
+		query     dto.SearchQuery
+		wantIDs   []uint
+		wantTotal int64
+	}{
+		{
+			name:      "name matches rank above description matches",
+			query:     dto.SearchQuery{Text: "running", Limit: 10},
+			wantIDs:   []uint{2, 1, 3},
+			wantTotal: 3,
+		},
+		{
+			name:      "every word must match",
+			query:     dto.SearchQuery{Text: "red shoe", Limit: 10},
+			wantIDs:   []uint{1},
+			wantTotal: 1,
+		},
+		{
+			name:      "words match prefixes",
+			query:     dto.SearchQuery{Text: "jack", Limit: 10},
+			wantIDs:   []uint{4},
+			wantTotal: 1,
+		},
+		{
+			name:      "alternatives widen the match",
+			query:     dto.SearchQuery{Text: "socks", Alternatives: []string{"jacket"}, Limit: 10},
+			wantIDs:   []uint{4, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "no match",
+			query:     dto.SearchQuery{Text: "hat", Limit: 10},
+			wantIDs:   []uint{},
+			wantTotal: 0,
+		},
+		{
+			name:      "category filter includes descendants",
+			query:     dto.SearchQuery{Text: "running", Filter: dto.ProductFilter{CategoryID: &category}, Limit: 10},
+			wantIDs:   []uint{2, 1},
+			wantTotal: 2,
+		},
+		{
+			name:      "price filter",
+			query:     dto.SearchQuery{Text: "running", Filter: dto.ProductFilter{MinPrice: &minPrice, MaxPrice: &maxPrice}, Limit: 10},
+			wantIDs:   []uint{2, 1},
+			wantTotal: 2,
+		},
+		{
+			name:      "in stock filter",
+			query:     dto.SearchQuery{Text: "running", Filter: dto.ProductFilter{InStock: true}, Limit: 10},
+			wantIDs:   []uint{1, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "attribute filter",
+			query:     dto.SearchQuery{Text: "running", Filter: dto.ProductFilter{Attributes: dto.Attributes{"color": "red"}}, Limit: 10},
+			wantIDs:   []uint{1, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "excluded IDs are not counted",
+			query:     dto.SearchQuery{Text: "running", ExcludeIDs: []uint{2}, Limit: 10},
+			wantIDs:   []uint{1, 3},
+			wantTotal: 2,
+		},
+		{
+			name:      "first page",
+			query:     dto.SearchQuery{Text: "running", Limit: 2},
+			wantIDs:   []uint{2, 1},
+			wantTotal: 3,
+		},
+		{
+			name:      "last page",
+			query:     dto.SearchQuery{Text: "running", Offset: 2, Limit: 2},
+			wantIDs:   []uint{3},
+			wantTotal: 3,
+		},
+		{
+			name:      "offset past the end",
+			query:     dto.SearchQuery{Text: "running", Offset: 5, Limit: 2},
+			wantIDs:   []uint{},
+			wantTotal: 3,
+		},
+	}
+
+	index := newTestMemorySearchIndex(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := index.Search(context.Background(), &tt.query)
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+
+			if ids := hitIDs(hits); !slices.Equal(ids, tt.wantIDs) {
+				t.Errorf("hits = %v, want %v", ids, tt.wantIDs)
+			}
+
+			if hits.Total != tt.wantTotal {
+				t.Errorf("total = %d, want %d", hits.Total, tt.wantTotal)
+			}
+
+			if hits.Facets != nil {
+				t.Errorf("facets = %+v, want none", hits.Facets)
+			}
+		})
+	}
+}
+
+func TestMemorySearchIndexBoost(t *testing.T) {
+	index := newTestMemorySearchIndex(t)
+
+	document := testSearchDocuments()[2]
+	document.Boost = 2
+	if err := index.Index(context.Background(), []dto.SearchDocument{document}); err != nil {
+		t.Fatalf("Index: %v", err)
+	}
+
+	hits, err := index.Search(context.Background(), &dto.SearchQuery{Text: "running", Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	if ids, want := hitIDs(hits), []uint{3, 2, 1}; !slices.Equal(ids, want) {
+		t.Errorf("hits = %v, want %v", ids, want)
+	}
+}
+
+func TestMemorySearchIndexFacets(t *testing.T) {
+	category := uint(2)
+
+	tests := []struct {
+		name           string
+		filter         dto.ProductFilter
+		wantCategories []dto.CategoryFacet
+		wantPrices     []dto.PriceRangeFacet
+		wantInStock    int64
+		wantAttributes []dto.AttributeFacet
+	}{
+		{
+			name: "unfiltered",
+			wantCategories: []dto.CategoryFacet{
+				{CategoryID: 2, Name: "Shoes", Count: 2},
+				{CategoryID: 3, Name: "Socks", Count: 1},
+			},
+			wantPrices: []dto.PriceRangeFacet{
+				{Min: 0, Max: 20, Count: 1},
+				{Min: 40, Max: 60, Count: 1},
+				{Min: 60, Max: 80, Count: 1},
+			},
+			wantInStock: 2,
+			wantAttributes: []dto.AttributeFacet{
+				{Name: "color", Values: []dto.AttributeValueFacet{{Value: "red", Count: 2}, {Value: "blue", Count: 1}}},
+				{Name: "size", Values: []dto.AttributeValueFacet{{Value: "42", Count: 2}}},
+			},
+		},
+		{
+			name:   "a filter does not narrow its own facet",
+			filter: dto.ProductFilter{CategoryID: &category, Attributes: dto.Attributes{"color": "red"}},
+			wantCategories: []dto.CategoryFacet{
+				{CategoryID: 2, Name: "Shoes", Count: 1},
+				{CategoryID: 3, Name: "Socks", Count: 1},
+			},
+			wantPrices: []dto.PriceRangeFacet{
+				{Min: 50, Max: 51, Count: 1},
+			},
+			wantInStock: 1,
+			wantAttributes: []dto.AttributeFacet{
+				{Name: "color", Values: []dto.AttributeValueFacet{{Value: "blue", Count: 1}, {Value: "red", Count: 1}}},
+				{Name: "size", Values: []dto.AttributeValueFacet{{Value: "42", Count: 1}}},
+			},
+		},
+	}
+
+	index := newTestMemorySearchIndex(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits, err := index.Search(context.Background(), &dto.SearchQuery{
+				Text:   "running",
+				Filter: tt.filter,
+				Limit:  10,
+				Facets: true,
+			})
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+
+			if hits.Facets == nil {
+				t.Fatal("facets missing")
+			}
+
+			if !slices.Equal(hits.Facets.Categories, tt.wantCategories) {
+				t.Errorf("categories = %+v, want %+v", hits.Facets.Categories, tt.wantCategories)
+			}
+
+			if !slices.Equal(hits.Facets.PriceRanges, tt.wantPrices) {
+				t.Errorf("price ranges = %+v, want %+v", hits.Facets.PriceRanges, tt.wantPrices)
+			}
+
+			if hits.Facets.InStock != tt.wantInStock {
+				t.Errorf("in stock = %d, want %d", hits.Facets.InStock, tt.wantInStock)
+			}
+
+			if !slices.EqualFunc(hits.Facets.Attributes, tt.wantAttributes, func(a, b dto.AttributeFacet) bool {
+				return a.Name == b.Name && slices.Equal(a.Values, b.Values)
+			}) {
+				t.Errorf("attributes = %+v, want %+v", hits.Facets.Attributes, tt.wantAttributes)
+			}
+		})
+	}
+}
+
+func TestMemorySearchIndexDelete(t *testing.T) {
+	index := newTestMemorySearchIndex(t)
+	ctx := context.Background()
+
+	if err := index.Delete(ctx, []uint{1, 4, 99}); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+
+	ids, err := index.IDs(ctx)
+	if err != nil {
+		t.Fatalf("IDs: %v", err)
+	}
+
+	slices.Sort(ids)
+	if want := []uint{2, 3}; !slices.Equal(ids, want) {
+		t.Errorf("IDs = %v, want %v", ids, want)
+	}
+
+	hits, err := index.Search(ctx, &dto.SearchQuery{Text: "running", Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	if got, want := hitIDs(hits), []uint{2, 3}; !slices.Equal(got, want) {
+		t.Errorf("hits = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,630 @@
+package providers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	appconfig "github.com/abhilashdk2016/golang-ecommerce/internal/config"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+)
+
+const (
+	openSearchTimeout = 10 * time.Second
+
+	// openSearchMaxBuckets caps the categories and attribute names counted
+	// for facets
+	openSearchMaxBuckets = 500
+
+	// openSearchScrollSize and openSearchScrollKeepAlive page through every
+	// document when listing IDs
+	openSearchScrollSize      = 1000
+	openSearchScrollKeepAlive = "1m"
+)
+
+// openSearchMapping lays out the index created by CreateIndex. Attributes are
+// nested so a filter on an attribute matches its name and value together.
+const openSearchMapping = `{
+	"mappings": {
+		"properties": {
+			"name": {"type": "text"},
+			"description": {"type": "text"},
+			"sku": {"type": "text"},
+			"category_id": {"type": "integer"},
+			"category_ids": {"type": "integer"},
+			"category_name": {"type": "text", "fields": {"raw": {"type": "keyword"}}},
+			"price": {"type": "double"},
+			"in_stock": {"type": "boolean"},
+			"attributes": {
+				"type": "nested",
+				"properties": {
+					"name": {"type": "keyword"},
+					"value": {"type": "keyword"}
+				}
+			},
+			"boost_factor": {"type": "double"},
+			"created_at": {"type": "date"}
+		}
+	}
+}`
+
+type object = map[string]any
+
+// OpenSearchIndex stores products in an OpenSearch or Elasticsearch compatible
+// server through its REST API. Queries match whole words with typo tolerance
+// across the name, SKU, category and description, and scores are multiplied
+// by each product's boost factor.
+type OpenSearchIndex struct {
+	client   *http.Client
+	url      string
+	index    string
+	username string
+	password string
+}
+
+func NewOpenSearchIndex(cfg *appconfig.SearchConfig) *OpenSearchIndex {
+	return &OpenSearchIndex{
+		client:   &http.Client{Timeout: openSearchTimeout},
+		url:      strings.TrimRight(cfg.URL, "/"),
+		index:    cfg.Index,
+		username: cfg.Username,
+		password: cfg.Password,
+	}
+}
+
+// CreateIndex creates the index with its mapping unless it already exists
+func (i *OpenSearchIndex) CreateIndex(ctx context.Context) error {
+	resp, err := i.send(ctx, http.MethodHead, "/"+url.PathEscape(i.index), "", nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusNotFound:
+		return i.do(ctx, http.MethodPut, "/"+url.PathEscape(i.index), strings.NewReader(openSearchMapping), nil)
+	default:
+		return fmt.Errorf("opensearch: checking index %s: %s", i.index, resp.Status)
+	}
+}
+
+type openSearchDocument struct {
+	dto.SearchDocument
+	Attributes  []openSearchAttribute `json:"attributes"`
+	BoostFactor float64               `json:"boost_factor"`
+}
+
+type openSearchAttribute struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+func (i *OpenSearchIndex) Index(ctx context.Context, documents []dto.SearchDocument) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, document := range documents {
+		attributes := make([]openSearchAttribute, 0, len(document.Attributes))
+		for name, value := range document.Attributes {
+			attributes = append(attributes, openSearchAttribute{Name: name, Value: value})
+		}
+
+		if err := encoder.Encode(object{"index": i.bulkTarget(document.ID)}); err != nil {
+			return err
+		}
+		if err := encoder.Encode(openSearchDocument{
+			SearchDocument: document,
+			Attributes:     attributes,
+			BoostFactor:    boostFactor(document.Boost),
+		}); err != nil {
+			return err
+		}
+	}
+
+	return i.bulk(ctx, &body)
+}
+
+func (i *OpenSearchIndex) Delete(ctx context.Context, ids []uint) error {
+	var body bytes.Buffer
+	encoder := json.NewEncoder(&body)
+	for _, id := range ids {
+		if err := encoder.Encode(object{"delete": i.bulkTarget(id)}); err != nil {
+			return err
+		}
+	}
+
+	return i.bulk(ctx, &body)
+}
+
+// IDs scrolls through the index in storage order, which is the cheapest way
+// to visit every document
+func (i *OpenSearchIndex) IDs(ctx context.Context) ([]uint, error) {
+	body, err := json.Marshal(object{
+		"size":    openSearchScrollSize,
+		"_source": false,
+		"sort":    []string{"_doc"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var page openSearchScrollResponse
+	path := "/" + url.PathEscape(i.index) + "/_search?scroll=" + openSearchScrollKeepAlive
+	if err := i.do(ctx, http.MethodPost, path, bytes.NewReader(body), &page); err != nil {
+		return nil, err
+	}
+
+	var scrollID string
+	defer func() {
+		if scrollID != "" {
+			i.clearScroll(scrollID)
+		}
+	}()
+
+	ids := []uint{}
+	for {
+		scrollID = page.ScrollID
+		if len(page.Hits.Hits) == 0 {
+			return ids, nil
+		}
+
+		for _, hit := range page.Hits.Hits {
+			id, err := strconv.ParseUint(hit.ID, 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("opensearch: unexpected document id %q", hit.ID)
+			}
+			ids = append(ids, uint(id))
+		}
+
+		body, err := json.Marshal(object{"scroll": openSearchScrollKeepAlive, "scroll_id": page.ScrollID})
+		if err != nil {
+			return nil, err
+		}
+
+		page = openSearchScrollResponse{}
+		if err := i.do(ctx, http.MethodPost, "/_search/scroll", bytes.NewReader(body), &page); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// clearScroll frees a scroll context early instead of waiting for it to
+// expire. Failures only delay the cleanup, so they are ignored.
+func (i *OpenSearchIndex) clearScroll(scrollID string) {
+	body, err := json.Marshal(object{"scroll_id": scrollID})
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), openSearchTimeout)
+	defer cancel()
+	_ = i.do(ctx, http.MethodDelete, "/_search/scroll", bytes.NewReader(body), nil)
+}
+
+func (i *OpenSearchIndex) bulkTarget(id uint) object {
+	return object{"_index": i.index, "_id": strconv.FormatUint(uint64(id), 10)}
+}
+
+// bulk sends a bulk request, failing if any of its actions failed. Deleting
+// a document that is not indexed is not an error.
+func (i *OpenSearchIndex) bulk(ctx context.Context, body *bytes.Buffer) error {
+	if body.Len() == 0 {
+		return nil
+	}
+
+	var result struct {
+		Errors bool `json:"errors"`
+		Items  []map[string]struct {
+			ID     string          `json:"_id"`
+			Status int             `json:"status"`
+			Error  json.RawMessage `json:"error"`
+		} `json:"items"`
+	}
+	if err := i.do(ctx, http.MethodPost, "/_bulk", body, &result); err != nil {
+		return err
+	}
+
+	if !result.Errors {
+		return nil
+	}
+
+	for _, item := range result.Items {
+		for action, status := range item {
+			if status.Status >= 300 && !(action == "delete" && status.Status == http.StatusNotFound) {
+				return fmt.Errorf("opensearch: %s %s: %s", action, status.ID, status.Error)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (i *OpenSearchIndex) Search(ctx context.Context, query *dto.SearchQuery) (*dto.SearchHits, error) {
+	phrases := []object{}
+	for _, text := range append([]string{query.Text}, query.Alternatives...) {
+		phrases = append(phrases, object{"multi_match": object{
+			"query":     text,
+			"fields":    []string{"name^3", "sku^2", "category_name", "description"},
+			"fuzziness": "AUTO",
+			"operator":  "and",
+		}})
+	}
+
+	match := object{"function_score": object{
+		"query":              object{"bool": object{"should": phrases, "minimum_should_match": 1}},
+		"field_value_factor": object{"field": "boost_factor", "missing": 1},
+		"boost_mode":         "multiply",
+	}}
+
+	// Filters go in post_filter so the facet aggregations can each leave
+	// their own dimension out
+	postFilter := openSearchFilter(&query.Filter)
+	if len(query.ExcludeIDs) > 0 {
+		excluded := make([]string, len(query.ExcludeIDs))
+		for n, id := range query.ExcludeIDs {
+			excluded[n] = strconv.FormatUint(uint64(id), 10)
+		}
+
+		postFilter = object{"bool": object{
+			"filter":   []object{postFilter},
+			"must_not": []object{{"ids": object{"values": excluded}}},
+		}}
+	}
+
+	request := object{
+		"from":             query.Offset,
+		"size":             query.Limit,
+		"track_total_hits": true,
+		"query":            match,
+		"post_filter":      postFilter,
+		"sort":             []object{{"_score": "desc"}, {"created_at": "desc"}},
+	}
+	if query.Facets {
+		request["aggs"] = openSearchFacetAggregations(&query.Filter)
+	}
+
+	var response openSearchResponse
+	if err := i.search(ctx, request, &response); err != nil {
+		return nil, err
+	}
+
+	result := &dto.SearchHits{Hits: []dto.SearchHit{}, Total: response.Hits.Total.Value}
+	for _, hit := range response.Hits.Hits {
+		id, err := strconv.ParseUint(hit.ID, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("opensearch: unexpected document id %q", hit.ID)
+		}
+		result.Hits = append(result.Hits, dto.SearchHit{ID: uint(id), Score: hit.Score})
+	}
+
+	if query.Facets {
+		facets, err := i.facets(ctx, match, &query.Filter, &response.Aggregations)
+		if err != nil {
+			return nil, err
+		}
+		result.Facets = facets
+	}
+
+	return result, nil
+}
+
+// facets builds the facets from the aggregations of a search. Price ranges
+// depend on the lowest and highest prices found, so they take a second
+// request.
+func (i *OpenSearchIndex) facets(ctx context.Context, match object, filter *dto.ProductFilter, aggregations *openSearchAggregations) (*dto.SearchFacets, error) {
+	facets := &dto.SearchFacets{
+		Categories:  []dto.CategoryFacet{},
+		PriceRanges: []dto.PriceRangeFacet{},
+		InStock:     aggregations.InStock.DocCount,
+	}
+
+	for _, bucket := range aggregations.Categories.IDs.Buckets {
+		facet := dto.CategoryFacet{CategoryID: bucket.Key, Count: bucket.DocCount}
+		if len(bucket.Names.Buckets) > 0 {
+			facet.Name = bucket.Names.Buckets[0].Key
+		}
+		facets.Categories = append(facets.Categories, facet)
+	}
+
+	counts := aggregations.Attributes.counts("")
+	for name, bucket := range aggregations.SelectedAttributes.Buckets {
+		counts = append(counts, bucket.counts(name)...)
+	}
+	facets.Attributes = groupAttributeCounts(counts)
+
+	stats := aggregations.Prices.Stats
+	if stats.Count == 0 {
+		return facets, nil
+	}
+
+	_, step := priceRangeBounds(stats.Min, stats.Max)
+
+	priceFilter := *filter
+	priceFilter.MinPrice, priceFilter.MaxPrice = nil, nil
+
+	// Histogram buckets start at multiples of step, as in the other indexes
+	var response openSearchResponse
+	if err := i.search(ctx, object{
+		"size":  0,
+		"query": match,
+		"aggs": object{"prices": object{
+			"filter": openSearchFilter(&priceFilter),
+			"aggs": object{"ranges": object{"histogram": object{
+				"field":         "price",
+				"interval":      step,
+				"min_doc_count": 1,
+			}}},
+		}},
+	}, &response); err != nil {
+		return nil, err
+	}
+
+	for _, bucket := range response.Aggregations.Prices.Ranges.Buckets {
+		facets.PriceRanges = append(facets.PriceRanges, dto.PriceRangeFacet{
+			Min:   bucket.Key,
+			Max:   bucket.Key + step,
+			Count: bucket.DocCount,
+		})
+	}
+
+	return facets, nil
+}
+
+// openSearchFilter returns a bool query matching the products filter selects
+func openSearchFilter(filter *dto.ProductFilter) object {
+	clauses := []object{}
+
+	if filter.CategoryID != nil {
+		clauses = append(clauses, object{"term": object{"category_ids": *filter.CategoryID}})
+	}
+
+	if filter.MinPrice != nil || filter.MaxPrice != nil {
+		bounds := object{}
+		if filter.MinPrice != nil {
+			bounds["gte"] = *filter.MinPrice
+		}
+		if filter.MaxPrice != nil {
+			bounds["lte"] = *filter.MaxPrice
+		}
+		clauses = append(clauses, object{"range": object{"price": bounds}})
+	}
+
+	if filter.InStock {
+		clauses = append(clauses, object{"term": object{"in_stock": true}})
+	}
+
+	for name, value := range filter.Attributes {
+		clauses = append(clauses, object{"nested": object{
+			"path": "attributes",
+			"query": object{"bool": object{"filter": []object{
+				{"term": object{"attributes.name": name}},
+				{"term": object{"attributes.value": value}},
+			}}},
+		}})
+	}
+
+	return object{"bool": object{"filter": clauses}}
+}
+
+// openSearchFacetAggregations counts matches by category, price, stock and
+// attribute value. Each facet is counted without its own filter.
+func openSearchFacetAggregations(filter *dto.ProductFilter) object {
+	categoryFilter := *filter
+	categoryFilter.CategoryID = nil
+
+	priceFilter := *filter
+	priceFilter.MinPrice, priceFilter.MaxPrice = nil, nil
+
+	stockFilter := *filter
+	stockFilter.InStock = true
+
+	selected := make([]string, 0, len(filter.Attributes))
+	for name := range filter.Attributes {
+		selected = append(selected, name)
+	}
+
+	aggregations := object{
+		"categories": object{
+			"filter": openSearchFilter(&categoryFilter),
+			"aggs": object{"ids": object{
+				"terms": object{"field": "category_id", "size": openSearchMaxBuckets},
+				"aggs":  object{"names": object{"terms": object{"field": "category_name.raw", "size": 1}}},
+			}},
+		},
+		"prices": object{
+			"filter": openSearchFilter(&priceFilter),
+			"aggs":   object{"stats": object{"stats": object{"field": "price"}}},
+		},
+		"in_stock": object{
+			"filter": openSearchFilter(&stockFilter),
+		},
+		"attributes": object{
+			"filter": openSearchFilter(filter),
+			"aggs": openSearchAttributeAggregation(object{"bool": object{
+				"must_not": []object{{"terms": object{"attributes.name": selected}}},
+			}}),
+		},
+	}
+
+	// Each selected attribute is counted under the other filters only; the
+	// counts of the other names in its bucket are ignored
+	if len(selected) > 0 {
+		filters := object{}
+		for _, name := range selected {
+			others := withoutAttribute(filter, name)
+			filters[name] = openSearchFilter(&others)
+		}
+
+		aggregations["selected_attributes"] = object{
+			"filters": object{"filters": filters},
+			"aggs":    openSearchAttributeAggregation(object{"terms": object{"attributes.name": selected}}),
+		}
+	}
+
+	return aggregations
+}
+
+// openSearchAttributeAggregation counts the values of the attributes whose
+// names match the names query
+func openSearchAttributeAggregation(names object) object {
+	return object{"nested": object{
+		"nested": object{"path": "attributes"},
+		"aggs": object{"names": object{
+			"filter": names,
+			"aggs": object{"names": object{
+				"terms": object{"field": "attributes.name", "size": openSearchMaxBuckets},
+				"aggs": object{"values": object{
+					"terms": object{"field": "attributes.value", "size": maxAttributeFacetValues},
+				}},
+			}},
+		}},
+	}}
+}
+
+type openSearchScrollResponse struct {
+	ScrollID string `json:"_scroll_id"`
+	Hits     struct {
+		Hits []struct {
+			ID string `json:"_id"`
+		} `json:"hits"`
+	} `json:"hits"`
+}
+
+type openSearchResponse struct {
+	Hits struct {
+		Total struct {
+			Value int64 `json:"value"`
+		} `json:"total"`
+		Hits []struct {
+			ID    string  `json:"_id"`
+			Score float64 `json:"_score"`
+		} `json:"hits"`
+	} `json:"hits"`
+	Aggregations openSearchAggregations `json:"aggregations"`
+}
+
+type openSearchAggregations struct {
+	Categories struct {
+		IDs struct {
+			Buckets []struct {
+				Key      uint            `json:"key"`
+				DocCount int64           `json:"doc_count"`
+				Names    openSearchTerms `json:"names"`
+			} `json:"buckets"`
+		} `json:"ids"`
+	} `json:"categories"`
+	Prices struct {
+		Stats struct {
+			Count int64   `json:"count"`
+			Min   float64 `json:"min"`
+			Max   float64 `json:"max"`
+		} `json:"stats"`
+		Ranges struct {
+			Buckets []struct {
+				Key      float64 `json:"key"`
+				DocCount int64   `json:"doc_count"`
+			} `json:"buckets"`
+		} `json:"ranges"`
+	} `json:"prices"`
+	InStock struct {
+		DocCount int64 `json:"doc_count"`
+	} `json:"in_stock"`
+	Attributes         openSearchAttributeCounts `json:"attributes"`
+	SelectedAttributes struct {
+		Buckets map[string]openSearchAttributeCounts `json:"buckets"`
+	} `json:"selected_attributes"`
+}
+
+type openSearchTerms struct {
+	Buckets []struct {
+		Key      string           `json:"key"`
+		DocCount int64            `json:"doc_count"`
+		Values   *openSearchTerms `json:"values"`
+	} `json:"buckets"`
+}
+
+// openSearchAttributeCounts is the result of openSearchAttributeAggregation
+type openSearchAttributeCounts struct {
+	Nested struct {
+		Names struct {
+			Names openSearchTerms `json:"names"`
+		} `json:"names"`
+	} `json:"nested"`
+}
+
+// counts lists the attribute value counts, only for attribute name unless it
+// is empty
+func (a *openSearchAttributeCounts) counts(name string) []attributeCount {
+	var counts []attributeCount
+	for _, names := range a.Nested.Names.Names.Buckets {
+		if (name != "" && names.Key != name) || names.Values == nil {
+			continue
+		}
+
+		for _, value := range names.Values.Buckets {
+			counts = append(counts, attributeCount{Name: names.Key, Value: value.Key, Count: value.DocCount})
+		}
+	}
+
+	return counts
+}
+
+func (i *OpenSearchIndex) search(ctx context.Context, request object, response *openSearchResponse) error {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+
+	return i.do(ctx, http.MethodPost, "/"+url.PathEscape(i.index)+"/_search", bytes.NewReader(body), response)
+}
+
+// do sends a request and decodes its JSON response into result, unless
+// result is nil
+func (i *OpenSearchIndex) do(ctx context.Context, method, path string, body io.Reader, result any) error {
+	contentType := "application/json"
+	if path == "/_bulk" {
+		contentType = "application/x-ndjson"
+	}
+
+	resp, err := i.send(ctx, method, path, contentType, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("opensearch: %s %s: %s: %s", method, path, resp.Status, message)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(result)
+}
+
+func (i *OpenSearchIndex) send(ctx context.Context, method, path, contentType string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, i.url+path, body)
+	if err != nil {
+		return nil, err
+	}
+
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	if i.username != "" {
+		req.SetBasicAuth(i.username, i.password)
+	}
+
+	return i.client.Do(req)
+}
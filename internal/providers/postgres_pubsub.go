@@ -0,0 +1,104 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	pubSubChannel        = "pubsub"
+	pubSubReconnectDelay = 5 * time.Second
+)
+
+type pubSubMessage struct {
+	Topic   string `json:"topic"`
+	Payload []byte `json:"payload"`
+}
+
+// PostgresPubSub publishes with NOTIFY so subscribers on every replica receive
+// the message. Each replica holds one dedicated LISTEN connection and fans
+// notifications out to its local subscribers. Messages published while that
+// connection is being re-established are missed. NOTIFY payloads are limited
+// to 8000 bytes, so messages should stay small.
+type PostgresPubSub struct {
+	db    *gorm.DB
+	dsn   string
+	local *MemoryPubSub
+}
+
+// NewPostgresPubSub starts listening in the background until ctx is done
+func NewPostgresPubSub(ctx context.Context, db *gorm.DB, dsn string) *PostgresPubSub {
+	p := &PostgresPubSub{
+		db:    db,
+		dsn:   dsn,
+		local: NewMemoryPubSub(),
+	}
+
+	go p.listen(ctx)
+
+	return p
+}
+
+func (p *PostgresPubSub) Publish(ctx context.Context, topic string, payload []byte) error {
+	message, err := json.Marshal(pubSubMessage{Topic: topic, Payload: payload})
+	if err != nil {
+		return err
+	}
+
+	// Local subscribers are reached through our own LISTEN connection, which
+	// keeps delivery order the same on every replica
+	return p.db.WithContext(ctx).Exec("SELECT pg_notify(?, ?)", pubSubChannel, string(message)).Error
+}
+
+func (p *PostgresPubSub) Subscribe(ctx context.Context, topic string) (<-chan []byte, error) {
+	return p.local.Subscribe(ctx, topic)
+}
+
+func (p *PostgresPubSub) listen(ctx context.Context) {
+	for {
+		err := p.receive(ctx)
+		if ctx.Err() != nil {
+			return
+		}
+
+		log.Printf("Pub/sub listener disconnected, reconnecting in %s: %v", pubSubReconnectDelay, err)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(pubSubReconnectDelay):
+		}
+	}
+}
+
+func (p *PostgresPubSub) receive(ctx context.Context) error {
+	conn, err := pgx.Connect(ctx, p.dsn)
+	if err != nil {
+		return err
+	}
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+pubSubChannel); err != nil {
+		return err
+	}
+
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		var message pubSubMessage
+		if err := json.Unmarshal([]byte(notification.Payload), &message); err != nil {
+			log.Printf("Failed to decode pub/sub message: %v", err)
+			continue
+		}
+
+		p.local.deliver(message.Topic, message.Payload)
+	}
+}
@@ -0,0 +1,78 @@
+package providers
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// takeTokenSQL refills and takes from a bucket in a single statement so
+// concurrent requests from several replicas cannot overdraw it. The database
+// clock is used so replicas with clock skew agree on the refill. A bucket is
+// full again, and can be deleted, once capacity / rate seconds have passed.
+const takeTokenSQL = `
+INSERT INTO rate_limit_buckets AS b (key, tokens, allowed, updated_at, expires_at)
+VALUES (@key, @capacity - 1, true, clock_timestamp(), clock_timestamp() + make_interval(secs => @capacity / @rate))
+ON CONFLICT (key) DO UPDATE SET
+	tokens = LEAST(@capacity, b.tokens + EXTRACT(EPOCH FROM clock_timestamp() - b.updated_at) * @rate)
+		- CASE WHEN LEAST(@capacity, b.tokens + EXTRACT(EPOCH FROM clock_timestamp() - b.updated_at) * @rate) >= 1 THEN 1 ELSE 0 END,
+	allowed = LEAST(@capacity, b.tokens + EXTRACT(EPOCH FROM clock_timestamp() - b.updated_at) * @rate) >= 1,
+	updated_at = clock_timestamp(),
+	expires_at = clock_timestamp() + make_interval(secs => @capacity / @rate)
+RETURNING tokens, allowed`
+
+// PostgresRateLimitStore keeps buckets in the rate_limit_buckets table so all
+// API replicas share the same limits
+type PostgresRateLimitStore struct {
+	db *gorm.DB
+
+	mu        sync.Mutex
+	lastSweep time.Time
+}
+
+func NewPostgresRateLimitStore(db *gorm.DB) *PostgresRateLimitStore {
+	return &PostgresRateLimitStore{
+		db:        db,
+		lastSweep: time.Now(),
+	}
+}
+
+func (s *PostgresRateLimitStore) Take(key string, capacity int, refillRate float64) (float64, bool, error) {
+	params := map[string]any{
+		"key":      key,
+		"capacity": float64(capacity),
+		"rate":     refillRate,
+	}
+
+	var result struct {
+		Tokens  float64
+		Allowed bool
+	}
+
+	if err := s.db.Raw(takeTokenSQL, params).Scan(&result).Error; err != nil {
+		return 0, false, err
+	}
+
+	s.sweepExpired()
+
+	return result.Tokens, result.Allowed, nil
+}
+
+// sweepExpired deletes refilled buckets at most once per sweep interval
+func (s *PostgresRateLimitStore) sweepExpired() {
+	s.mu.Lock()
+	if time.Since(s.lastSweep) < rateLimitSweepInterval {
+		s.mu.Unlock()
+		return
+	}
+	s.lastSweep = time.Now()
+	s.mu.Unlock()
+
+	go func() {
+		if err := s.db.Exec("DELETE FROM rate_limit_buckets WHERE expires_at < clock_timestamp()").Error; err != nil {
+			log.Printf("Failed to delete expired rate limit buckets: %v", err)
+		}
+	}()
+}
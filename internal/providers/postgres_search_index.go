@@ -0,0 +1,270 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// fuzzySearchThreshold is the word similarity a product name needs to match a
+// query in the typo-tolerant fallback. pg_trgm's default of 0.6 misses most
+// transposed letters.
+const fuzzySearchThreshold = "0.3"
+
+// PostgresSearchIndex searches the products table directly, using the
+// search_vector column kept up to date by a trigger, so Index and Delete have
+// nothing to do. When full-text search finds nothing, product names similar
+// to the query are matched instead so misspelt queries still find results.
+type PostgresSearchIndex struct {
+	db *gorm.DB
+}
+
+func NewPostgresSearchIndex(db *gorm.DB) *PostgresSearchIndex {
+	return &PostgresSearchIndex{db: db}
+}
+
+func (i *PostgresSearchIndex) Index(ctx context.Context, documents []dto.SearchDocument) error {
+	return nil
+}
+
+func (i *PostgresSearchIndex) Delete(ctx context.Context, ids []uint) error {
+	return nil
+}
+
+func (i *PostgresSearchIndex) IDs(ctx context.Context) ([]uint, error) {
+	return nil, nil
+}
+
+func (i *PostgresSearchIndex) Search(ctx context.Context, query *dto.SearchQuery) (*dto.SearchHits, error) {
+	result := &dto.SearchHits{Hits: []dto.SearchHit{}}
+
+	err := i.runSearch(ctx, query, func(search productSearch) error {
+		matches := search.query(&query.Filter)
+		if len(query.ExcludeIDs) > 0 {
+			matches = matches.Where("products.id NOT IN ?", query.ExcludeIDs)
+		}
+
+		if err := matches.Count(&result.Total).Error; err != nil {
+			return err
+		}
+
+		if query.Limit > 0 {
+			if err := matches.
+				Select("products.id, ? AS score", search.rank()).
+				Order("score DESC, products.created_at DESC").
+				Offset(query.Offset).
+				Limit(query.Limit).
+				Scan(&result.Hits).Error; err != nil {
+				return err
+			}
+		}
+
+		if query.Facets {
+			facets, err := searchFacets(search, &query.Filter)
+			if err != nil {
+				return err
+			}
+			result.Facets = facets
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// productSearch matches products against a query, either by full text of
+// the query or any of its alternatives or, in fuzzy mode, by trigram
+// similarity of their names to the query
+type productSearch struct {
+	db           *gorm.DB
+	text         string
+	alternatives []string
+	fuzzy        bool
+}
+
+// query selects the active products matching the search and filter
+func (p productSearch) query(filter *dto.ProductFilter) *gorm.DB {
+	query := p.db.Model(&models.Product{})
+	if p.fuzzy {
+		query = query.Where("? <% products.name", p.text)
+	} else {
+		query = query.Where("products.search_vector @@ ?", p.tsquery())
+	}
+
+	return repository.FilterProducts(query, filter)
+}
+
+// tsquery returns a full-text query matching the text or any alternative
+func (p productSearch) tsquery() clause.Expr {
+	sql := "plainto_tsquery('english', ?)"
+	vars := []any{p.text}
+	for _, alternative := range p.alternatives {
+		sql += " || plainto_tsquery('english', ?)"
+		vars = append(vars, alternative)
+	}
+
+	return gorm.Expr("("+sql+")", vars...)
+}
+
+// rank returns the relevance of a product, scaled by its search boost
+func (p productSearch) rank() clause.Expr {
+	if p.fuzzy {
+		return gorm.Expr("word_similarity(?, products.name) * power(2, products.search_boost)", p.text)
+	}
+
+	return gorm.Expr("ts_rank(products.search_vector, ?) * power(2, products.search_boost)", p.tsquery())
+}
+
+// runSearch calls fn with a full-text search for query, or with a fuzzy one
+// when full text finds no products matching its filter. Fuzzy searches run in
+// a transaction that lowers the similarity threshold for its duration.
+func (i *PostgresSearchIndex) runSearch(ctx context.Context, query *dto.SearchQuery, fn func(search productSearch) error) error {
+	db := i.db.WithContext(ctx)
+	search := productSearch{db: db, text: query.Text, alternatives: query.Alternatives}
+
+	var found bool
+	if err := db.Raw("SELECT EXISTS (?)", search.query(&query.Filter).Select("1")).Scan(&found).Error; err != nil {
+		return err
+	}
+
+	if found {
+		return fn(search)
+	}
+
+	return db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("SELECT set_config('pg_trgm.word_similarity_threshold', ?, true)", fuzzySearchThreshold).Error; err != nil {
+			return err
+		}
+
+		return fn(productSearch{db: tx, text: query.Text, fuzzy: true})
+	})
+}
+
+// searchFacets counts the products matching search by category, price range,
+// stock and attribute value
+func searchFacets(search productSearch, filter *dto.ProductFilter) (*dto.SearchFacets, error) {
+	facets := &dto.SearchFacets{Categories: []dto.CategoryFacet{}}
+
+	categoryFilter := *filter
+	categoryFilter.CategoryID = nil
+	if err := search.query(&categoryFilter).
+		Joins("JOIN categories ON categories.id = products.category_id").
+		Select("products.category_id, categories.name, COUNT(*) AS count").
+		Group("products.category_id, categories.name").
+		Order("count DESC, categories.name").
+		Scan(&facets.Categories).Error; err != nil {
+		return nil, err
+	}
+
+	priceFilter := *filter
+	priceFilter.MinPrice, priceFilter.MaxPrice = nil, nil
+	priceRanges, err := priceRangeFacets(search, &priceFilter)
+	if err != nil {
+		return nil, err
+	}
+	facets.PriceRanges = priceRanges
+
+	stockFilter := *filter
+	stockFilter.InStock = false
+	if err := search.query(&stockFilter).
+		Where("products.stock > 0").
+		Count(&facets.InStock).Error; err != nil {
+		return nil, err
+	}
+
+	attributes, err := attributeFacets(search, filter)
+	if err != nil {
+		return nil, err
+	}
+	facets.Attributes = attributes
+
+	return facets, nil
+}
+
+func priceRangeFacets(search productSearch, filter *dto.ProductFilter) ([]dto.PriceRangeFacet, error) {
+	var stats struct {
+		Min   float64
+		Max   float64
+		Count int64
+	}
+	if err := search.query(filter).
+		Select("COALESCE(MIN(products.price), 0) AS min, COALESCE(MAX(products.price), 0) AS max, COUNT(*) AS count").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	ranges := []dto.PriceRangeFacet{}
+	if stats.Count == 0 {
+		return ranges, nil
+	}
+
+	start, step := priceRangeBounds(stats.Min, stats.Max)
+
+	var buckets []struct {
+		Bucket float64
+		Count  int64
+	}
+	if err := search.query(filter).
+		Select("FLOOR((products.price - ?) / ?) AS bucket, COUNT(*) AS count", start, step).
+		Group("bucket").
+		Order("bucket").
+		Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+
+	for _, bucket := range buckets {
+		lower := start + bucket.Bucket*step
+		ranges = append(ranges, dto.PriceRangeFacet{
+			Min:   lower,
+			Max:   lower + step,
+			Count: bucket.Count,
+		})
+	}
+
+	return ranges, nil
+}
+
+// attributeFacets counts attribute values. Attributes the search filters on
+// are counted without their own filter, so every value stays selectable.
+func attributeFacets(search productSearch, filter *dto.ProductFilter) ([]dto.AttributeFacet, error) {
+	count := func(filter *dto.ProductFilter, where string, names []string) ([]attributeCount, error) {
+		var rows []attributeCount
+		err := search.query(filter).
+			Joins("CROSS JOIN LATERAL jsonb_each_text(products.attributes) AS attr(key, value)").
+			Select("attr.key AS name, attr.value AS value, COUNT(*) AS count").
+			Where(where, names).
+			Group("attr.key, attr.value").
+			Scan(&rows).Error
+		return rows, err
+	}
+
+	// The empty name never matches, which keeps NOT IN valid when nothing is selected
+	selected := []string{""}
+	for name := range filter.Attributes {
+		selected = append(selected, name)
+	}
+
+	counts, err := count(filter, "attr.key NOT IN ?", selected)
+	if err != nil {
+		return nil, err
+	}
+
+	for name := range filter.Attributes {
+		others := withoutAttribute(filter, name)
+		selectedCounts, err := count(&others, "attr.key IN ?", []string{name})
+		if err != nil {
+			return nil, err
+		}
+		counts = append(counts, selectedCounts...)
+	}
+
+	return groupAttributeCounts(counts), nil
+}
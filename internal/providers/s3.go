@@ -2,7 +2,7 @@ package providers
 
 import (
 	"context"
-	"mime/multipart"
+	"io"
 	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
@@ -51,18 +51,12 @@ func NewS3Provider(cfg *appconfig.Config) *S3Provider {
 	}
 }
 
-func (p *S3Provider) UploadFile(file *multipart.FileHeader, path string) (string, error) {
-
-	src, err := file.Open()
-	if err != nil {
-		return "", err
-	}
-	defer src.Close()
+func (p *S3Provider) UploadFile(file io.Reader, path string) (string, error) {
 
 	result, err := p.uploader.Upload(context.TODO(), &s3.PutObjectInput{
 		Bucket: aws.String(p.bucket),
 		Key:    aws.String(path),
-		Body:   src,
+		Body:   file,
 	})
 
 	if err != nil {
@@ -0,0 +1,100 @@
+package providers
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+)
+
+// Facet counts work the same way in every search index: each facet ignores
+// the filter on its own dimension, so its buckets show how many results
+// changing that filter would give.
+
+const (
+	// priceRangeCount is roughly how many price ranges a search returns. Range
+	// widths are rounded to 1, 2 or 5 times a power of ten, so the actual
+	// number may differ slightly.
+	priceRangeCount = 5
+
+	// maxAttributeFacetValues caps the values listed per attribute, keeping
+	// the most common ones
+	maxAttributeFacetValues = 20
+)
+
+// boostFactor scales a relevance score by a product's search boost, doubling
+// it for each point
+func boostFactor(boost float64) float64 {
+	return math.Pow(2, boost)
+}
+
+// priceRangeBounds returns where the first price range starts and how wide
+// each range is for prices between low and high
+func priceRangeBounds(low, high float64) (start, step float64) {
+	step = priceRangeStep((high - low) / priceRangeCount)
+	return math.Floor(low/step) * step, step
+}
+
+// priceRangeStep rounds width up to 1, 2 or 5 times a power of ten so price
+// ranges have readable bounds
+func priceRangeStep(width float64) float64 {
+	if width <= 0 {
+		return 1
+	}
+
+	magnitude := math.Pow(10, math.Floor(math.Log10(width)))
+	for _, factor := range []float64{1, 2, 5} {
+		if width <= factor*magnitude {
+			return factor * magnitude
+		}
+	}
+
+	return 10 * magnitude
+}
+
+// withoutAttribute returns filter without its condition on attribute name
+func withoutAttribute(filter *dto.ProductFilter, name string) dto.ProductFilter {
+	others := *filter
+	others.Attributes = make(dto.Attributes, len(filter.Attributes))
+	for key, value := range filter.Attributes {
+		if key != name {
+			others.Attributes[key] = value
+		}
+	}
+
+	return others
+}
+
+type attributeCount struct {
+	Name  string
+	Value string
+	Count int64
+}
+
+// groupAttributeCounts builds attribute facets sorted by name, listing the
+// most common values of each first
+func groupAttributeCounts(counts []attributeCount) []dto.AttributeFacet {
+	slices.SortFunc(counts, func(a, b attributeCount) int {
+		return cmp.Or(
+			cmp.Compare(a.Name, b.Name),
+			cmp.Compare(b.Count, a.Count),
+			cmp.Compare(a.Value, b.Value),
+		)
+	})
+
+	facets := []dto.AttributeFacet{}
+	for _, count := range counts {
+		last := len(facets) - 1
+		if last < 0 || facets[last].Name != count.Name {
+			facets = append(facets, dto.AttributeFacet{Name: count.Name})
+			last++
+		}
+
+		if len(facets[last].Values) < maxAttributeFacetValues {
+			facets[last].Values = append(facets[last].Values, dto.AttributeValueFacet{Value: count.Value, Count: count.Count})
+		}
+	}
+
+	return facets
+}
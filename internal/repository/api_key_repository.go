@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+func (r *APIKeyRepository) Create(key *models.APIKey) error {
+	return r.db.Create(key).Error
+}
+func (r *APIKeyRepository) GetByID(id uint) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.First(&key, id).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+func (r *APIKeyRepository) GetByHash(keyHash string) (*models.APIKey, error) {
+	var key models.APIKey
+	if err := r.db.Preload("User").Where("key_hash = ?", keyHash).First(&key).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List returns the keys owned by userID, or every key when userID is zero
+func (r *APIKeyRepository) List(userID uint) ([]models.APIKey, error) {
+	var keys []models.APIKey
+	query := r.db.Order("created_at DESC")
+	if userID != 0 {
+		query = query.Where("user_id = ?", userID)
+	}
+	if err := query.Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+func (r *APIKeyRepository) Revoke(id uint) error {
+	return r.db.Model(&models.APIKey{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", time.Now()).Error
+}
+func (r *APIKeyRepository) UpdateLastUsed(id uint, usedAt time.Time) error {
+	return r.db.Model(&models.APIKey{}).Where("id = ?", id).UpdateColumn("last_used_at", usedAt).Error
+}
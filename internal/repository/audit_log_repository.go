@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db: db,
+	}
+}
+
+func (r *AuditLogRepository) Create(log *models.AuditLog) error {
+	return r.db.Create(log).Error
+}
+func (r *AuditLogRepository) List(filter AuditLogFilter) ([]models.AuditLog, int64, error) {
+	query := r.db.Model(&models.AuditLog{})
+
+	if filter.ActorID != nil {
+		query = query.Where("actor_id = ?", *filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.EntityType != "" {
+		query = query.Where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityID != "" {
+		query = query.Where("entity_id = ?", filter.EntityID)
+	}
+	if filter.From != nil {
+		query = query.Where("created_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("created_at < ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var logs []models.AuditLog
+	if err := query.Order("created_at DESC, id DESC").
+		Offset(filter.Offset).Limit(filter.Limit).
+		Find(&logs).Error; err != nil {
+		return nil, 0, err
+	}
+
+	return logs, total, nil
+}
@@ -1,6 +1,10 @@
 package repository
 
-import "github.com/abhilashdk2016/golang-ecommerce/internal/models"
+import (
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+)
 
 type UserRepositoryInterface interface {
 	GetByEmail(email string) (*models.User, error)
@@ -9,11 +13,29 @@ type UserRepositoryInterface interface {
 	Create(user *models.User) error
 	Update(user *models.User) error
 	Delete(id uint) error
+	RecordLoginFailure(userID uint, now time.Time, window time.Duration, lockouts []LoginLockout) (*models.User, error)
+	ResetLoginFailures(userID uint) error
 
 	CreateRefreshToken(token *models.RefreshToken) error
 	GetValidRefreshToken(token string) (*models.RefreshToken, error)
 	DeleteRefreshToken(token string) error
 	DeleteRefreshTokenByID(id uint) error
+	DeleteRefreshTokensByUserID(userID uint) error
+	DeleteOtherRefreshTokens(userID uint, keepToken string) error
+
+	CreateVerificationToken(token *models.VerificationToken) error
+	GetValidVerificationToken(tokenHash string, purpose models.VerificationPurpose) (*models.VerificationToken, error)
+	MarkVerificationTokenUsed(id uint) error
+	DeleteVerificationTokens(userID uint, purpose models.VerificationPurpose) error
+
+	ReplaceRecoveryCodes(userID uint, codes []models.RecoveryCode) error
+	UseRecoveryCode(userID uint, codeHash string) error
+	DeleteRecoveryCodes(userID uint) error
+
+	GetIdentity(provider, subject string) (*models.UserIdentity, error)
+	CreateIdentity(identity *models.UserIdentity) error
+
+	CreateImpersonation(impersonation *models.UserImpersonation) error
 }
 
 type CartRepositoryInterface interface {
@@ -22,3 +44,58 @@ type CartRepositoryInterface interface {
 	Update(cart *models.Cart) error
 	Delete(id uint) error
 }
+
+type LoginThrottleRepositoryInterface interface {
+	GetByIP(ipAddress string) (*models.LoginThrottle, error)
+	RecordFailure(ipAddress string, now time.Time, window time.Duration) (*models.LoginThrottle, error)
+	Lock(id uint, until time.Time) error
+}
+
+type RoleRepositoryInterface interface {
+	GetAll() ([]models.Role, error)
+	GetByName(name string) (*models.Role, error)
+	GetByUserID(userID uint) ([]models.Role, error)
+	GetPermissionNames(userID uint) ([]string, error)
+	AssignToUser(userID, roleID uint) error
+	RemoveFromUser(userID, roleID uint) error
+	ReplaceUserRoles(userID uint, roleIDs []uint) error
+}
+
+type APIKeyRepositoryInterface interface {
+	Create(key *models.APIKey) error
+	GetByID(id uint) (*models.APIKey, error)
+	GetByHash(keyHash string) (*models.APIKey, error)
+	List(userID uint) ([]models.APIKey, error)
+	Revoke(id uint) error
+	UpdateLastUsed(id uint, usedAt time.Time) error
+}
+
+type AuditLogFilter struct {
+	ActorID    *uint
+	Action     string
+	EntityType string
+	EntityID   string
+	From       *time.Time
+	To         *time.Time
+	Offset     int
+	Limit      int
+}
+
+type AuditLogRepositoryInterface interface {
+	Create(log *models.AuditLog) error
+	List(filter AuditLogFilter) ([]models.AuditLog, int64, error)
+}
+
+type SearchAnalyticsRepositoryInterface interface {
+	CreateQuery(query *models.SearchQuery, productIDs []uint) error
+	GetQuery(id uint) (*models.SearchQuery, error)
+	AddResults(searchID uint, productIDs []uint) error
+	CreateEvent(searchID, productID uint, eventType string) (bool, error)
+	Aggregate(day time.Time) error
+	LastAggregatedDay() (*time.Time, error)
+	FirstQueryTime() (*time.Time, error)
+	DeleteQueriesBefore(before time.Time) (int64, error)
+	SumStats(from, to time.Time) (*models.SearchQueryDailyStats, error)
+	TopQueries(from, to time.Time, limit int) ([]models.SearchQueryDailyStats, error)
+	ZeroResultQueries(from, to time.Time, limit int) ([]models.SearchQueryDailyStats, error)
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"gorm.io/gorm"
+)
+
+type LoginThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) *LoginThrottleRepository {
+	return &LoginThrottleRepository{
+		db: db,
+	}
+}
+
+func (r *LoginThrottleRepository) GetByIP(ipAddress string) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	if err := r.db.Where("ip_address = ?", ipAddress).First(&throttle).Error; err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// RecordFailure counts a failed login from ipAddress in a single statement,
+// so concurrent attempts cannot overwrite each other's count. The count
+// restarts when the previous failure is older than window.
+func (r *LoginThrottleRepository) RecordFailure(ipAddress string, now time.Time, window time.Duration) (*models.LoginThrottle, error) {
+	var throttle models.LoginThrottle
+	err := r.db.Raw(`
+		INSERT INTO login_throttles (ip_address, failed_attempts, last_failed_at, created_at, updated_at)
+		VALUES (?, 1, ?, ?, ?)
+		ON CONFLICT (ip_address) DO UPDATE SET
+			failed_attempts = CASE
+				WHEN login_throttles.last_failed_at IS NULL OR login_throttles.last_failed_at < ? THEN 1
+				ELSE login_throttles.failed_attempts + 1
+			END,
+			last_failed_at = EXCLUDED.last_failed_at,
+			updated_at = EXCLUDED.updated_at
+		RETURNING *`,
+		ipAddress, now, now, now, now.Add(-window),
+	).Scan(&throttle).Error
+	if err != nil {
+		return nil, err
+	}
+	return &throttle, nil
+}
+
+// Lock blocks logins from the throttle's address until the given time,
+// never shortening a longer lockout set by a concurrent attempt
+func (r *LoginThrottleRepository) Lock(id uint, until time.Time) error {
+	return r.db.Model(&models.LoginThrottle{}).
+		Where("id = ? AND (locked_until IS NULL OR locked_until < ?)", id, until).
+		Update("locked_until", until).Error
+}
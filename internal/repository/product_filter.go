@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"encoding/json"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"gorm.io/gorm"
+)
+
+// CategorySubtreeSQL selects the IDs of a category and all of its descendants
+const CategorySubtreeSQL = `WITH RECURSIVE tree AS (
+	SELECT id FROM categories WHERE id = ? AND deleted_at IS NULL
+	UNION
+	SELECT c.id FROM categories c JOIN tree t ON c.parent_id = t.id WHERE c.deleted_at IS NULL
+) SELECT id FROM tree`
+
+// FilterProducts restricts query to the active products matching filter
+func FilterProducts(query *gorm.DB, filter *dto.ProductFilter) *gorm.DB {
+	query = query.Where("products.is_active = ?", true)
+
+	if filter.CategoryID != nil {
+		query = query.Where("products.category_id IN ("+CategorySubtreeSQL+")", *filter.CategoryID)
+	}
+
+	if filter.MinPrice != nil {
+		query = query.Where("products.price >= ?", *filter.MinPrice)
+	}
+
+	if filter.MaxPrice != nil {
+		query = query.Where("products.price <= ?", *filter.MaxPrice)
+	}
+
+	if filter.InStock {
+		query = query.Where("products.stock > 0")
+	}
+
+	if len(filter.Attributes) > 0 {
+		attributes, _ := json.Marshal(filter.Attributes)
+		query = query.Where("products.attributes @> ?::jsonb", string(attributes))
+	}
+
+	return query
+}
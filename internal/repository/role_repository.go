@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{
+		db: db,
+	}
+}
+
+func (r *RoleRepository) GetAll() ([]models.Role, error) {
+	var roles []models.Role
+	if err := r.db.Preload("Permissions").Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+func (r *RoleRepository) GetByName(name string) (*models.Role, error) {
+	var role models.Role
+	if err := r.db.Preload("Permissions").Where("name = ?", name).First(&role).Error; err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+func (r *RoleRepository) GetByUserID(userID uint) ([]models.Role, error) {
+	var roles []models.Role
+	err := r.db.Preload("Permissions").
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Order("roles.name").
+		Find(&roles).Error
+	if err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+func (r *RoleRepository) GetPermissionNames(userID uint) ([]string, error) {
+	var names []string
+	err := r.db.Table("permissions").
+		Distinct("permissions.name").
+		Joins("JOIN role_permissions ON role_permissions.permission_id = permissions.id").
+		Joins("JOIN user_roles ON user_roles.role_id = role_permissions.role_id").
+		Where("user_roles.user_id = ?", userID).
+		Pluck("permissions.name", &names).Error
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+func (r *RoleRepository) AssignToUser(userID, roleID uint) error {
+	return r.db.Table("user_roles").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]any{"user_id": userID, "role_id": roleID}).Error
+}
+func (r *RoleRepository) RemoveFromUser(userID, roleID uint) error {
+	return r.db.Exec("DELETE FROM user_roles WHERE user_id = ? AND role_id = ?", userID, roleID).Error
+}
+
+// ReplaceUserRoles sets the user's roles to exactly roleIDs
+func (r *RoleRepository) ReplaceUserRoles(userID uint, roleIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec("DELETE FROM user_roles WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		for _, roleID := range roleIDs {
+			if err := tx.Table("user_roles").Create(map[string]any{"user_id": userID, "role_id": roleID}).Error; err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
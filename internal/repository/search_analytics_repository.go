@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// aggregateSearchesSQL rolls the searches made between two times up into the
+// stats of a day, replacing the stats from any earlier run
+const aggregateSearchesSQL = `INSERT INTO search_query_daily_stats(day, query, searches, zero_result_searches, clicked_searches, add_to_cart_searches)
+SELECT ?::date, q.query, COUNT(*),
+	COUNT(*) FILTER (WHERE q.result_count = 0),
+	COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM search_events e WHERE e.search_query_id = q.id AND e.type = ?)),
+	COUNT(*) FILTER (WHERE EXISTS (SELECT 1 FROM search_events e WHERE e.search_query_id = q.id AND e.type = ?))
+FROM search_queries q
+WHERE q.created_at >= ? AND q.created_at < ?
+GROUP BY q.query
+ON CONFLICT (day, query) DO UPDATE SET
+	searches = EXCLUDED.searches,
+	zero_result_searches = EXCLUDED.zero_result_searches,
+	clicked_searches = EXCLUDED.clicked_searches,
+	add_to_cart_searches = EXCLUDED.add_to_cart_searches`
+
+// createSearchEventSQL records an event only if the search returned the
+// product
+const createSearchEventSQL = `INSERT INTO search_events(search_query_id, product_id, type, created_at)
+SELECT r.search_query_id, r.product_id, ?, CURRENT_TIMESTAMP FROM search_results r
+WHERE r.search_query_id = ? AND r.product_id = ?`
+
+type SearchAnalyticsRepository struct {
+	db *gorm.DB
+}
+
+func NewSearchAnalyticsRepository(db *gorm.DB) *SearchAnalyticsRepository {
+	return &SearchAnalyticsRepository{
+		db: db,
+	}
+}
+
+// CreateQuery logs a search along with the products it returned
+func (r *SearchAnalyticsRepository) CreateQuery(query *models.SearchQuery, productIDs []uint) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(query).Error; err != nil {
+			return err
+		}
+
+		return createSearchResults(tx, query.ID, productIDs)
+	})
+}
+
+func (r *SearchAnalyticsRepository) GetQuery(id uint) (*models.SearchQuery, error) {
+	var query models.SearchQuery
+	if err := r.db.First(&query, id).Error; err != nil {
+		return nil, err
+	}
+	return &query, nil
+}
+
+// AddResults records more products returned by a logged search, such as
+// those on later pages
+func (r *SearchAnalyticsRepository) AddResults(searchID uint, productIDs []uint) error {
+	return createSearchResults(r.db, searchID, productIDs)
+}
+
+func createSearchResults(db *gorm.DB, searchID uint, productIDs []uint) error {
+	if len(productIDs) == 0 {
+		return nil
+	}
+
+	results := make([]models.SearchResult, len(productIDs))
+	for i, productID := range productIDs {
+		results[i] = models.SearchResult{SearchQueryID: searchID, ProductID: productID}
+	}
+
+	return db.Clauses(clause.OnConflict{DoNothing: true}).Create(&results).Error
+}
+
+// CreateEvent records an event on a search result, returning false when the
+// search did not return the product
+func (r *SearchAnalyticsRepository) CreateEvent(searchID, productID uint, eventType string) (bool, error) {
+	result := r.db.Exec(createSearchEventSQL, eventType, searchID, productID)
+	if result.Error != nil {
+		return false, result.Error
+	}
+
+	return result.RowsAffected > 0, nil
+}
+
+// Aggregate computes the daily stats of the UTC day starting at day
+func (r *SearchAnalyticsRepository) Aggregate(day time.Time) error {
+	return r.db.Exec(aggregateSearchesSQL,
+		day.Format(time.DateOnly),
+		models.SearchEventClick,
+		models.SearchEventAddToCart,
+		day,
+		day.AddDate(0, 0, 1),
+	).Error
+}
+
+// LastAggregatedDay returns the latest day with stored stats, or nil when
+// nothing has been aggregated
+func (r *SearchAnalyticsRepository) LastAggregatedDay() (*time.Time, error) {
+	var day *time.Time
+	err := r.db.Model(&models.SearchQueryDailyStats{}).Select("MAX(day)").Row().Scan(&day)
+	return day, err
+}
+
+// FirstQueryTime returns when the oldest logged search was made, or nil when
+// none are logged
+func (r *SearchAnalyticsRepository) FirstQueryTime() (*time.Time, error) {
+	var createdAt *time.Time
+	err := r.db.Model(&models.SearchQuery{}).Select("MIN(created_at)").Row().Scan(&createdAt)
+	return createdAt, err
+}
+
+// DeleteQueriesBefore removes searches logged before the given time along
+// with their events
+func (r *SearchAnalyticsRepository) DeleteQueriesBefore(before time.Time) (int64, error) {
+	result := r.db.Where("created_at < ?", before).Delete(&models.SearchQuery{})
+	return result.RowsAffected, result.Error
+}
+
+// SumStats adds up the daily stats between two days, both inclusive
+func (r *SearchAnalyticsRepository) SumStats(from, to time.Time) (*models.SearchQueryDailyStats, error) {
+	var stats models.SearchQueryDailyStats
+	if err := r.dailyStats(from, to).
+		Select("COALESCE(SUM(searches), 0) AS searches, " +
+			"COALESCE(SUM(zero_result_searches), 0) AS zero_result_searches, " +
+			"COALESCE(SUM(clicked_searches), 0) AS clicked_searches, " +
+			"COALESCE(SUM(add_to_cart_searches), 0) AS add_to_cart_searches").
+		Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+
+	return &stats, nil
+}
+
+// TopQueries returns the most searched queries between two days, both
+// inclusive, with their stats added up
+func (r *SearchAnalyticsRepository) TopQueries(from, to time.Time, limit int) ([]models.SearchQueryDailyStats, error) {
+	return r.queryStats(r.dailyStats(from, to), "searches DESC, query", limit)
+}
+
+// ZeroResultQueries returns the queries that most often found nothing
+// between two days, both inclusive
+func (r *SearchAnalyticsRepository) ZeroResultQueries(from, to time.Time, limit int) ([]models.SearchQueryDailyStats, error) {
+	query := r.dailyStats(from, to).Having("SUM(zero_result_searches) > 0")
+	return r.queryStats(query, "zero_result_searches DESC, searches DESC, query", limit)
+}
+
+func (r *SearchAnalyticsRepository) dailyStats(from, to time.Time) *gorm.DB {
+	return r.db.Model(&models.SearchQueryDailyStats{}).
+		Where("day BETWEEN ? AND ?", from.Format(time.DateOnly), to.Format(time.DateOnly))
+}
+
+func (r *SearchAnalyticsRepository) queryStats(query *gorm.DB, order string, limit int) ([]models.SearchQueryDailyStats, error) {
+	var stats []models.SearchQueryDailyStats
+	err := query.
+		Select("query, SUM(searches) AS searches, " +
+			"SUM(zero_result_searches) AS zero_result_searches, " +
+			"SUM(clicked_searches) AS clicked_searches, " +
+			"SUM(add_to_cart_searches) AS add_to_cart_searches").
+		Group("query").
+		Order(order).
+		Limit(limit).
+		Find(&stats).Error
+	return stats, err
+}
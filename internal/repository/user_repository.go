@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
@@ -50,6 +52,64 @@ func (r *UserRepository) Delete(id uint) error {
 	return r.db.Delete(&models.User{}, id).Error
 }
 
+// LoginLockout locks an account until Until once it reaches Attempts failed
+// logins
+type LoginLockout struct {
+	Attempts int
+	Until    time.Time
+}
+
+// RecordLoginFailure counts a failed login against the account and applies
+// the longest lockout it has reached, in a single statement so concurrent
+// attempts cannot overwrite each other's count. Only the lockout columns are
+// written. The count restarts when the previous failure is older than window.
+func (r *UserRepository) RecordLoginFailure(userID uint, now time.Time, window time.Duration, lockouts []LoginLockout) (*models.User, error) {
+	count := "CASE WHEN last_failed_login_at IS NULL OR last_failed_login_at < ? THEN 1 ELSE failed_login_attempts + 1 END"
+	cutoff := now.Add(-window)
+
+	lockedUntil := "locked_until"
+	args := []any{cutoff, now}
+	if len(lockouts) > 0 {
+		var cases strings.Builder
+		cases.WriteString("CASE")
+		for _, lockout := range slices.Backward(lockouts) {
+			cases.WriteString(" WHEN " + count + " >= ? THEN ?")
+			args = append(args, cutoff, lockout.Attempts, lockout.Until)
+		}
+		cases.WriteString(" ELSE locked_until END")
+		lockedUntil = cases.String()
+	}
+	args = append(args, userID)
+
+	var user models.User
+	result := r.db.Raw(`
+		UPDATE users SET
+			failed_login_attempts = `+count+`,
+			last_failed_login_at = ?,
+			locked_until = `+lockedUntil+`
+		WHERE id = ? AND deleted_at IS NULL
+		RETURNING id, failed_login_attempts, last_failed_login_at, locked_until`,
+		args...,
+	).Scan(&user)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &user, nil
+}
+
+// ResetLoginFailures clears the failed login count and any lockout without
+// touching the rest of the account
+func (r *UserRepository) ResetLoginFailures(userID uint) error {
+	return r.db.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
+		"failed_login_attempts": 0,
+		"last_failed_login_at":  nil,
+		"locked_until":          nil,
+	}).Error
+}
+
 func (r *UserRepository) CreateRefreshToken(token *models.RefreshToken) error {
 	return r.db.Create(token).Error
 }
@@ -66,3 +126,66 @@ func (r *UserRepository) DeleteRefreshToken(token string) error {
 func (r *UserRepository) DeleteRefreshTokenByID(id uint) error {
 	return r.db.Delete(&models.RefreshToken{}, id).Error
 }
+func (r *UserRepository) DeleteRefreshTokensByUserID(userID uint) error {
+	return r.db.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error
+}
+func (r *UserRepository) DeleteOtherRefreshTokens(userID uint, keepToken string) error {
+	return r.db.Where("user_id = ? AND token <> ?", userID, keepToken).Delete(&models.RefreshToken{}).Error
+}
+
+func (r *UserRepository) CreateVerificationToken(token *models.VerificationToken) error {
+	return r.db.Create(token).Error
+}
+func (r *UserRepository) GetValidVerificationToken(tokenHash string, purpose models.VerificationPurpose) (*models.VerificationToken, error) {
+	var token models.VerificationToken
+	if err := r.db.Where("token_hash = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", tokenHash, purpose, time.Now()).
+		First(&token).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+func (r *UserRepository) MarkVerificationTokenUsed(id uint) error {
+	return r.db.Model(&models.VerificationToken{}).Where("id = ?", id).Update("used_at", time.Now()).Error
+}
+func (r *UserRepository) DeleteVerificationTokens(userID uint, purpose models.VerificationPurpose) error {
+	return r.db.Where("user_id = ? AND purpose = ?", userID, purpose).Delete(&models.VerificationToken{}).Error
+}
+
+func (r *UserRepository) ReplaceRecoveryCodes(userID uint, codes []models.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Unscoped().Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+}
+func (r *UserRepository) UseRecoveryCode(userID uint, codeHash string) error {
+	result := r.db.Model(&models.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+func (r *UserRepository) DeleteRecoveryCodes(userID uint) error {
+	return r.db.Unscoped().Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
+
+func (r *UserRepository) GetIdentity(provider, subject string) (*models.UserIdentity, error) {
+	var identity models.UserIdentity
+	if err := r.db.Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+func (r *UserRepository) CreateIdentity(identity *models.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+func (r *UserRepository) CreateImpersonation(impersonation *models.UserImpersonation) error {
+	return r.db.Create(impersonation).Error
+}
@@ -0,0 +1,176 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Search users
+// @Description Paginated user search by name, email or phone with role and status filters (requires users:read)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param q query string false "Search term"
+// @Param role query string false "Role name"
+// @Param is_active query bool false "Account status"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.AdminUserResponse} "Users retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/users [get]
+func (s *Server) searchUsers(c *gin.Context) {
+	var req dto.AdminUserSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	users, meta, err := s.userService.SearchUsers(&req)
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch users", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Users retrieved successfully", users, *meta)
+}
+
+// @Summary Get a user
+// @Description Retrieve a user account with its roles (requires users:read)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} utils.Response{data=dto.AdminUserResponse} "User retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid user ID"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id} [get]
+func (s *Server) getUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	user, err := s.userService.GetUser(uint(id))
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch user", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "User retrieved successfully", user)
+}
+
+// @Summary Activate or deactivate a user
+// @Description Toggle a user account. Deactivation revokes the user's sessions. (requires users:write)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.UpdateUserStatusRequest true "New status"
+// @Success 200 {object} utils.Response{data=dto.AdminUserResponse} "User status updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/users/{id}/status [put]
+func (s *Server) updateUserStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req dto.UpdateUserStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	user, err := s.userService.SetUserActive(c.Request.Context(), c.GetUint("user_id"), uint(id), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to update user status", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "User status updated successfully", user)
+}
+
+// @Summary Mark a service account
+// @Description Mark or unmark a user as a service account. Staff with api_keys:manage can issue API keys for service accounts as well as for themselves. (requires users:write)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.UpdateServiceAccountRequest true "Account type"
+// @Success 200 {object} utils.Response{data=dto.AdminUserResponse} "User updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id}/service-account [put]
+func (s *Server) updateServiceAccount(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req dto.UpdateServiceAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	user, err := s.userService.SetServiceAccount(c.Request.Context(), c.GetUint("user_id"), uint(id), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to update user", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "User updated successfully", user)
+}
+
+// @Summary Impersonate a user
+// @Description Issue a short-lived access token for a customer account. The reason is recorded for auditing. (requires users:impersonate)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.ImpersonateRequest true "Reason for impersonation"
+// @Success 200 {object} utils.Response{data=dto.ImpersonationResponse} "Impersonation token issued"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/users/{id}/impersonate [post]
+func (s *Server) impersonateUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req dto.ImpersonateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	adminID := c.GetUint("user_id")
+	response, err := s.authService.ImpersonateUser(c.Request.Context(), adminID, uint(id), &req, c.ClientIP())
+	if err != nil {
+		s.errorResponse(c, "Failed to impersonate user", err)
+		return
+	}
+
+	s.logger.Info().
+		Uint("admin_id", adminID).
+		Uint("user_id", uint(id)).
+		Str("reason", req.Reason).
+		Msg("impersonation token issued")
+
+	utils.SuccessResponse(c, "Impersonation token issued", response)
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Create API key
+// @Description Issue an API key for the caller or for a service account. Scopes must be permissions both the caller and the owner hold. Keys are not accepted for credential changes, account deletion or checkout. The key is only returned once. (requires api_keys:manage)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateAPIKeyRequest true "API key details"
+// @Success 201 {object} utils.Response{data=dto.CreatedAPIKeyResponse} "API key created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission, scope the caller lacks or owner not a service account"
+// @Router /admin/api-keys [post]
+func (s *Server) createAPIKey(c *gin.Context) {
+	var req dto.CreateAPIKeyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	key, err := s.apiKeyService.CreateAPIKey(c.Request.Context(), c.GetUint("user_id"), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to create API key", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "API key created successfully", key)
+}
+
+// @Summary List API keys
+// @Description List API keys, optionally filtered by owner (requires api_keys:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "Owner user ID"
+// @Success 200 {object} utils.Response{data=[]dto.APIKeyResponse} "API keys retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid user ID"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/api-keys [get]
+func (s *Server) listAPIKeys(c *gin.Context) {
+	var userID uint64
+	if value := c.Query("user_id"); value != "" {
+		var err error
+		userID, err = strconv.ParseUint(value, 10, 32)
+		if err != nil {
+			utils.BadRequestResponse(c, "Invalid user ID", err)
+			return
+		}
+	}
+
+	keys, err := s.apiKeyService.ListAPIKeys(uint(userID))
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch API keys", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "API keys retrieved successfully", keys)
+}
+
+// @Summary Revoke API key
+// @Description Revoke an API key so it can no longer be used (requires api_keys:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "API key ID"
+// @Success 200 {object} utils.Response "API key revoked successfully"
+// @Failure 400 {object} utils.Response "Invalid API key ID"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "API key not found"
+// @Router /admin/api-keys/{id} [delete]
+func (s *Server) revokeAPIKey(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid API key ID", err)
+		return
+	}
+
+	if err := s.apiKeyService.RevokeAPIKey(c.Request.Context(), uint(id)); err != nil {
+		s.errorResponse(c, "Failed to revoke API key", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "API key revoked successfully", nil)
+}
@@ -0,0 +1,40 @@
+package server
+
+import (
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List audit logs
+// @Description Paginated audit trail of admin and security actions, newest first (requires audit_logs:read)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param actor_id query int false "User who performed the action"
+// @Param action query string false "Action, e.g. product.update"
+// @Param entity_type query string false "Entity type, e.g. product"
+// @Param entity_id query string false "Entity ID"
+// @Param from query string false "Start of time range (RFC 3339)"
+// @Param to query string false "End of time range, exclusive (RFC 3339)"
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(20)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.AuditLogResponse} "Audit logs retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/audit-logs [get]
+func (s *Server) listAuditLogs(c *gin.Context) {
+	var req dto.AuditLogSearchRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	logs, meta, err := s.auditService.ListAuditLogs(&req)
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch audit logs", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Audit logs retrieved successfully", logs, *meta)
+}
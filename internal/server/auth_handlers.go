@@ -1,7 +1,12 @@
 package server
 
 import (
+	"errors"
+	"math"
+	"strconv"
+
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/services"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -23,7 +28,7 @@ func (s *Server) register(c *gin.Context) {
 	}
 	response, err := s.authService.Register(&req)
 	if err != nil {
-		utils.BadRequestResponse(c, "registration failed", err)
+		s.errorResponse(c, "registration failed", err)
 		return
 	}
 
@@ -31,13 +36,14 @@ func (s *Server) register(c *gin.Context) {
 }
 
 // @Summary User login
-// @Description Authenticate user with email and password
+// @Description Authenticate user with email and password. Accounts with 2FA enabled receive a challenge token instead of a token pair.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param request body dto.LoginRequest true "User login credentials"
 // @Success 200 {object} utils.Response{data=dto.AuthResponse} "Login successful"
 // @Failure 401 {object} utils.Response "Invalid credentials"
+// @Failure 429 {object} utils.Response "Too many failed login attempts"
 // @Router /auth/login [post]
 func (s *Server) login(c *gin.Context) {
 	var req dto.LoginRequest
@@ -45,9 +51,14 @@ func (s *Server) login(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid request data", err)
 		return
 	}
-	response, err := s.authService.Login(&req)
+	response, err := s.authService.Login(c.Request.Context(), &req, c.ClientIP())
 	if err != nil {
-		utils.BadRequestResponse(c, "login failed", err)
+		s.loginErrorResponse(c, err)
+		return
+	}
+
+	if response.TwoFactorRequired {
+		utils.SuccessResponse(c, "two-factor authentication required", response)
 		return
 	}
 
@@ -71,7 +82,7 @@ func (s *Server) refreshToken(c *gin.Context) {
 	}
 	response, err := s.authService.RefreshToken(&req)
 	if err != nil {
-		utils.UnauthorizedResponse(c, "token refresh failed")
+		s.errorResponse(c, "token refresh failed", err)
 		return
 	}
 
@@ -95,9 +106,141 @@ func (s *Server) logout(c *gin.Context) {
 	}
 	err := s.authService.Logout(req.RefreshToken)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "logout failed", err)
+		s.errorResponse(c, "logout failed", err)
 		return
 	}
 
 	utils.SuccessResponse(c, "logged out successfully", nil)
 }
+
+// @Summary Verify email address
+// @Description Confirm the user's email address using the token sent by email
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "Verification token"
+// @Success 200 {object} utils.Response{data=dto.UserResponse} "Email verified successfully"
+// @Failure 400 {object} utils.Response "Invalid or expired token"
+// @Router /auth/verify-email [post]
+func (s *Server) verifyEmail(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+	user, err := s.authService.VerifyEmail(&req)
+	if err != nil {
+		s.errorResponse(c, "email verification failed", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "email verified successfully", user)
+}
+
+// @Summary Confirm email change
+// @Description Switch the account to the new email address using the token sent to that address
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "Confirmation token"
+// @Success 200 {object} utils.Response{data=dto.UserResponse} "Email changed successfully"
+// @Failure 400 {object} utils.Response "Invalid or expired token"
+// @Router /auth/confirm-email-change [post]
+func (s *Server) confirmEmailChange(c *gin.Context) {
+	var req dto.VerifyEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+	user, err := s.authService.ConfirmEmailChange(c.Request.Context(), &req)
+	if err != nil {
+		s.errorResponse(c, "email change failed", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "email changed successfully", user)
+}
+
+// @Summary Resend verification email
+// @Description Send a new verification email if the account exists and is not yet verified
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.ResendVerificationRequest true "Account email"
+// @Success 200 {object} utils.Response "Verification email sent"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Router /auth/resend-verification [post]
+func (s *Server) resendVerification(c *gin.Context) {
+	var req dto.ResendVerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+	if err := s.authService.ResendVerificationEmail(&req); err != nil {
+		s.errorResponse(c, "failed to send verification email", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "if the account exists and is unverified, a verification email has been sent", nil)
+}
+
+// @Summary Complete two-factor login
+// @Description Exchange the challenge token returned by login and a TOTP or recovery code for a token pair
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.TwoFactorLoginRequest true "Challenge token and code"
+// @Success 200 {object} utils.Response{data=dto.AuthResponse} "Login successful"
+// @Failure 401 {object} utils.Response "Invalid challenge or code"
+// @Failure 429 {object} utils.Response "Too many failed login attempts"
+// @Router /auth/login/2fa [post]
+func (s *Server) verifyTwoFactorLogin(c *gin.Context) {
+	var req dto.TwoFactorLoginRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+	response, err := s.authService.VerifyTwoFactorLogin(c.Request.Context(), &req)
+	if err != nil {
+		s.loginErrorResponse(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, "logged in successfully", response)
+}
+
+// @Summary Unlock a user account
+// @Description Clear a temporary lockout caused by failed login attempts (requires users:write)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} utils.Response "Account unlocked successfully"
+// @Failure 400 {object} utils.Response "Invalid user ID"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id}/unlock [post]
+func (s *Server) unlockUser(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	if err := s.authService.UnlockAccount(c.Request.Context(), uint(id)); err != nil {
+		s.errorResponse(c, "Failed to unlock user", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Account unlocked successfully", nil)
+}
+
+func (s *Server) loginErrorResponse(c *gin.Context, err error) {
+	var lockedErr *services.LoginLockedError
+	if errors.As(err, &lockedErr) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(lockedErr.RetryAfter.Seconds()))))
+	}
+
+	s.errorResponse(c, "login failed", err)
+}
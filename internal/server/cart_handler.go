@@ -4,6 +4,7 @@ import (
 	"strconv"
 
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -22,7 +23,7 @@ func (s *Server) getCart(c *gin.Context) {
 
 	cart, err := s.cartService.GetCart(userID)
 	if err != nil {
-		utils.NotFoundResponse(c, "Cart not found")
+		s.errorResponse(c, "Failed to fetch cart", err)
 		return
 	}
 
@@ -30,15 +31,17 @@ func (s *Server) getCart(c *gin.Context) {
 }
 
 // @Summary Add item to cart
-// @Description Add a product to the user's shopping cart
+// @Description Add a product to the user's shopping cart. Include the search_id of the search the product was found by to count the addition in search analytics.
 // @Tags Cart
 // @Accept json
 // @Produce json
 // @Security BearerAuth
 // @Param request body dto.AddToCartRequest true "Item to add to cart"
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Item added to cart successfully"
-// @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
+// @Failure 400 {object} utils.Response "Invalid request data"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 409 {object} utils.Response "Insufficient stock (code OUT_OF_STOCK)"
 // @Router /cart/items [post]
 func (s *Server) addToCart(c *gin.Context) {
 
@@ -52,10 +55,16 @@ func (s *Server) addToCart(c *gin.Context) {
 
 	cart, err := s.cartService.AddToCart(userID, &req)
 	if err != nil {
-		utils.BadRequestResponse(c, "Failed to add item to cart", err)
+		s.errorResponse(c, "Failed to add item to cart", err)
 		return
 	}
 
+	if req.SearchID != nil {
+		if err := s.searchAnalyticsService.RecordEvent(*req.SearchID, req.ProductID, models.SearchEventAddToCart); err != nil {
+			s.logger.Error().Err(err).Uint("search_id", *req.SearchID).Msg("failed to record search add to cart")
+		}
+	}
+
 	utils.SuccessResponse(c, "Item added to cart successfully", cart)
 }
 
@@ -68,8 +77,10 @@ func (s *Server) addToCart(c *gin.Context) {
 // @Param id path int true "Cart Item ID"
 // @Param request body dto.UpdateCartItemRequest true "New quantity"
 // @Success 200 {object} utils.Response{data=dto.CartResponse} "Cart item updated successfully"
-// @Failure 400 {object} utils.Response "Invalid request data or insufficient stock"
+// @Failure 400 {object} utils.Response "Invalid request data"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 404 {object} utils.Response "Cart item not found"
+// @Failure 409 {object} utils.Response "Insufficient stock (code OUT_OF_STOCK)"
 // @Router /cart/items/{id} [put]
 func (s *Server) updateCartItem(c *gin.Context) {
 	userID := c.GetUint("user_id")
@@ -88,7 +99,7 @@ func (s *Server) updateCartItem(c *gin.Context) {
 
 	cart, err := s.cartService.UpdateCartItem(userID, uint(id), &req)
 	if err != nil {
-		utils.BadRequestResponse(c, "Failed to update cart item", err)
+		s.errorResponse(c, "Failed to update cart item", err)
 		return
 	}
 
@@ -114,7 +125,7 @@ func (s *Server) removeFromCart(c *gin.Context) {
 	}
 
 	if err := s.cartService.RemoveFromCart(userID, uint(id)); err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to remove item from cart", err)
+		s.errorResponse(c, "Failed to remove item from cart", err)
 		return
 	}
 
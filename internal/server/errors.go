@@ -0,0 +1,19 @@
+package server
+
+import (
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// errorResponse responds with the status and code of the domain error behind
+// err. Errors that are not domain errors are logged and reported as internal
+// errors so that database and driver messages never reach the client.
+func (s *Server) errorResponse(c *gin.Context, message string, err error) {
+	appErr := apperror.From(err)
+	if appErr.Code == apperror.CodeInternal {
+		s.logger.Error().Err(err).Str("path", c.FullPath()).Msg(message)
+	}
+
+	utils.AppErrorResponse(c, message, appErr)
+}
@@ -2,75 +2,204 @@ package server
 
 import (
 	"context"
-
+	"errors"
+	"net/http"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 	"github.com/99designs/gqlgen/graphql/handler"
 	"github.com/99designs/gqlgen/graphql/handler/extension"
 	"github.com/99designs/gqlgen/graphql/handler/lru"
 	"github.com/99designs/gqlgen/graphql/handler/transport"
 	"github.com/99designs/gqlgen/graphql/playground"
 	"github.com/abhilashdk2016/golang-ecommerce/graph"
+	gqlextension "github.com/abhilashdk2016/golang-ecommerce/graph/extension"
+	"github.com/abhilashdk2016/golang-ecommerce/graph/loader"
 	"github.com/abhilashdk2016/golang-ecommerce/graph/resolver"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
 	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/vektah/gqlparser/v2/gqlerror"
 )
 
+const websocketKeepAlive = 10 * time.Second
+
 func (s *Server) createGraphQLHandler() *handler.Server {
 
 	rvr := resolver.NewResolver(
 		s.authService,
+		s.oidcService,
+		s.rbacService,
+		s.apiKeyService,
+		s.auditService,
+		s.privacyService,
 		s.userService,
-		s.productService, s.cartService,
+		s.productService,
+		s.uploadService,
+		s.cartService,
 		s.orderService,
+		s.searchAnalyticsService,
+		s.searchTuningService,
 	)
 
-	schema := graph.NewExecutableSchema(graph.Config{Resolvers: rvr})
+	schema := graph.NewExecutableSchema(graph.Config{
+		Resolvers: rvr,
+		Directives: graph.DirectiveRoot{
+			Auth:          rvr.Auth,
+			Session:       rvr.Session,
+			HasRole:       rvr.HasRole,
+			HasPermission: rvr.HasPermission,
+		},
+		Complexity: resolver.NewComplexityRoot(s.config.GraphQL.ListComplexity),
+	})
 
 	srv := handler.New(schema)
 
+	srv.AddTransport(transport.Websocket{
+		KeepAlivePingInterval: websocketKeepAlive,
+		InitFunc:              s.websocketInit,
+		Upgrader: websocket.Upgrader{
+			CheckOrigin: s.checkWebsocketOrigin,
+		},
+	})
 	srv.AddTransport(transport.Options{})
 	srv.AddTransport(transport.GET{})
 	srv.AddTransport(transport.POST{})
-	srv.AddTransport(transport.MultipartForm{})
+	srv.AddTransport(transport.MultipartForm{
+		MaxUploadSize: s.config.Upload.MaxFileSize,
+	})
 
 	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+	srv.SetErrorPresenter(s.presentGraphQLError)
+	srv.SetRecoverFunc(s.recoverGraphQL)
 
-	srv.Use(extension.Introspection{})
-	srv.Use(extension.AutomaticPersistedQuery{
-		Cache: lru.New[string](100),
+	srv.AroundOperations(func(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+		return next(resolver.WithRoleCache(ctx))
 	})
 
+	// Loaders are created per response rather than per operation so that each
+	// subscription event sees fresh data
+	srv.AroundResponses(func(ctx context.Context, next graphql.ResponseHandler) *graphql.Response {
+		return next(loader.WithLoaders(ctx, loader.NewLoaders(s.productService, s.userService)))
+	})
+
+	gqlConfig := s.config.GraphQL
+	if gqlConfig.Introspection {
+		srv.Use(extension.Introspection{})
+	}
+
+	if gqlConfig.PersistedQueriesOnly {
+		queries, err := gqlextension.LoadPersistedQueries(gqlConfig.PersistedQueriesFile)
+		if err != nil {
+			s.logger.Fatal().Err(err).Msg("failed to load persisted queries")
+		}
+
+		srv.Use(extension.AutomaticPersistedQuery{Cache: queries})
+		srv.Use(gqlextension.PersistedQueryAllowList{Queries: queries})
+	} else {
+		srv.Use(extension.AutomaticPersistedQuery{
+			Cache: lru.New[string](100),
+		})
+	}
+
+	srv.Use(extension.FixedComplexityLimit(gqlConfig.MaxComplexity))
+	srv.Use(gqlextension.DepthLimit{MaxDepth: gqlConfig.MaxDepth})
+
 	return srv
 }
 
-func (s *Server) graphqlHandler() gin.HandlerFunc {
-	h := s.createGraphQLHandler()
+// presentGraphQLError exposes the domain error code of every field error as
+// extensions.code. Errors raised by gqlgen itself while coercing arguments
+// are invalid input; any other unclassified error is logged and replaced by a
+// generic message so that database and driver details never reach the client.
+func (s *Server) presentGraphQLError(ctx context.Context, err error) *gqlerror.Error {
+	gqlErr := graphql.DefaultErrorPresenter(ctx, err)
+
+	var appErr *apperror.Error
+	var coercionErr *gqlerror.Error
+	switch {
+	case errors.As(err, &appErr):
+	case errors.As(err, &coercionErr):
+		appErr = apperror.New(apperror.CodeInvalidInput, gqlErr.Message)
+	default:
+		appErr = apperror.From(err)
+	}
 
-	return func(c *gin.Context) {
-		h.ServeHTTP(c.Writer, c.Request)
+	if appErr.Code == apperror.CodeInternal && appErr.Err != nil {
+		s.logger.Error().Err(err).Str("path", gqlErr.Path.String()).Msg("GraphQL resolver failed")
+		gqlErr.Message = appErr.Message
 	}
+
+	if gqlErr.Extensions == nil {
+		gqlErr.Extensions = map[string]any{}
+	}
+	gqlErr.Extensions["code"] = appErr.Code
+
+	return gqlErr
 }
 
-func (s *Server) playgroundHandler() gin.HandlerFunc {
-	h := playground.Handler("GraphQL Playground", "/graphql/")
+// recoverGraphQL reports a panicking resolver as an internal error
+func (s *Server) recoverGraphQL(ctx context.Context, err any) error {
+	s.logger.Error().Interface("panic", err).Bytes("stack", debug.Stack()).Msg("GraphQL resolver panicked")
+	return apperror.New(apperror.CodeInternal, "internal server error")
+}
 
-	return func(c *gin.Context) {
-		h.ServeHTTP(c.Writer, c.Request)
+// websocketInit authenticates a websocket connection from the bearer token in
+// the connection_init payload, since browsers cannot set headers on the
+// upgrade request. Connections without a token stay anonymous.
+func (s *Server) websocketInit(ctx context.Context, initPayload transport.InitPayload) (context.Context, *transport.InitPayload, error) {
+	authHeader := initPayload.Authorization()
+	if authHeader == "" {
+		return ctx, nil, nil
 	}
+
+	token, ok := strings.CutPrefix(authHeader, "Bearer ")
+	if !ok {
+		return nil, nil, errors.New("invalid authorization header format")
+	}
+
+	claims, err := s.authService.AuthenticateToken(token)
+	if err != nil {
+		return nil, nil, errors.New("invalid token")
+	}
+
+	ctx = context.WithValue(ctx, utils.UserIDKey, claims.UserID)
+	ctx = context.WithValue(ctx, utils.UserEmailKey, claims.Email)
+	ctx = context.WithValue(ctx, utils.UserRoleKey, claims.Role)
+	ctx = context.WithValue(ctx, utils.TwoFactorPendingKey, s.config.Auth.RequireAdminTwoFactor && !claims.TwoFactorVerified)
+	ctx = context.WithValue(ctx, utils.APIKeyScopesKey, nil)
+	ctx = context.WithValue(ctx, utils.ImpersonatorIDKey, claims.ImpersonatorID)
+
+	return ctx, nil, nil
 }
 
-// GraphQL playground handler for public endpoint
-func (s *Server) playgroundPublicHandler() gin.HandlerFunc {
-	h := playground.Handler("GraphQL Playground (Public)", "/graphql/public/")
+// checkWebsocketOrigin applies the CORS allow-list to websocket upgrades,
+// which browsers send cross-origin without a preflight
+func (s *Server) checkWebsocketOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	allowed := s.config.CORS.AllowedOrigins
+	return slices.Contains(allowed, "*") || slices.Contains(allowed, origin)
+}
+
+func (s *Server) graphqlHandler() gin.HandlerFunc {
+	h := s.createGraphQLHandler()
 
 	return func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
 	}
 }
 
-// GraphQL playground handler for protected endpoint
-func (s *Server) playgroundProtectedHandler() gin.HandlerFunc {
-	h := playground.Handler("GraphQL Playground (Protected)", "/graphql/")
+func (s *Server) playgroundHandler() gin.HandlerFunc {
+	h := playground.Handler("GraphQL Playground", "/graphql/")
 
 	return func(c *gin.Context) {
 		h.ServeHTTP(c.Writer, c.Request)
@@ -83,10 +212,16 @@ func (s *Server) graphqlMiddleware() gin.HandlerFunc {
 		userID, _ := c.Get("user_id")
 		userEmail, _ := c.Get("user_email")
 		userRole, _ := c.Get("user_role")
+		twoFactorPending := c.GetBool("two_factor_pending")
+		apiKeyScopes, _ := c.Get("api_key_scopes")
+		impersonatorID := c.GetUint("impersonator_id")
 
 		ctx := context.WithValue(c.Request.Context(), utils.UserIDKey, userID)
 		ctx = context.WithValue(ctx, utils.UserEmailKey, userEmail)
 		ctx = context.WithValue(ctx, utils.UserRoleKey, userRole)
+		ctx = context.WithValue(ctx, utils.TwoFactorPendingKey, twoFactorPending)
+		ctx = context.WithValue(ctx, utils.APIKeyScopesKey, apiKeyScopes)
+		ctx = context.WithValue(ctx, utils.ImpersonatorIDKey, impersonatorID)
 		ctx = context.WithValue(ctx, utils.GinContextKey, c)
 
 		c.Request = c.Request.WithContext(ctx)
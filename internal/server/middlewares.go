@@ -1,59 +1,273 @@
 package server
 
 import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
-	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
+// requestContextMiddleware tags each request with an ID, taken from the
+// X-Request-ID header when present, and stores the ID, client IP and user
+// agent in the request context for the audit log
+func (s *Server) requestContextMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader("X-Request-ID")
+		if requestID == "" || len(requestID) > 64 {
+			requestID, _ = utils.GenerateRandomToken(16)
+		}
+		c.Header("X-Request-ID", requestID)
+
+		ctx := context.WithValue(c.Request.Context(), utils.RequestIDKey, requestID)
+		ctx = context.WithValue(ctx, utils.ClientIPKey, c.ClientIP())
+		ctx = context.WithValue(ctx, utils.UserAgentKey, c.Request.UserAgent())
+		c.Request = c.Request.WithContext(ctx)
+
+		c.Next()
+	}
+}
+
+// rateLimit applies the named policy from the rate limit config using a token
+// bucket per client. Policies keyed by user must be installed after
+// authMiddleware. If the store is unavailable requests are let through.
+func (s *Server) rateLimit(name string) gin.HandlerFunc {
+	policy, ok := s.config.RateLimit.Policies[name]
+	if !s.config.RateLimit.Enabled || !ok || policy.Limit <= 0 || policy.Period <= 0 {
+		return func(c *gin.Context) {
+			c.Next()
+		}
+	}
+
+	refillRate := float64(policy.Limit) / policy.Period.Seconds()
+	policyHeader := fmt.Sprintf("%d;w=%d", policy.Limit, int(policy.Period.Seconds()))
+
+	return func(c *gin.Context) {
+		key := "ip:" + c.ClientIP()
+		if userID := c.GetUint("user_id"); policy.Key == "user" && userID != 0 {
+			key = fmt.Sprintf("user:%d", userID)
+		}
+
+		tokens, allowed, err := s.rateLimitStore.Take(policy.Name+":"+key, policy.Limit, refillRate)
+		if err != nil {
+			s.logger.Error().Err(err).Str("policy", policy.Name).Msg("rate limit check failed")
+			c.Next()
+			return
+		}
+
+		reset := (float64(policy.Limit) - tokens) / refillRate
+		c.Header("RateLimit-Limit", strconv.Itoa(policy.Limit))
+		c.Header("RateLimit-Remaining", strconv.Itoa(int(tokens)))
+		c.Header("RateLimit-Reset", strconv.Itoa(int(math.Ceil(reset))))
+		c.Header("RateLimit-Policy", policyHeader)
+
+		if !allowed {
+			retryAfter := (1 - tokens) / refillRate
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter))))
+			utils.TooManyRequestsResponse(c, "Rate limit exceeded", nil)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitByAuth applies one policy to authenticated requests and another to
+// anonymous ones. It must be installed after the authentication middleware.
+func (s *Server) rateLimitByAuth(authenticated, anonymous string) gin.HandlerFunc {
+	authenticatedLimit := s.rateLimit(authenticated)
+	anonymousLimit := s.rateLimit(anonymous)
+
+	return func(c *gin.Context) {
+		if c.GetUint("user_id") != 0 {
+			authenticatedLimit(c)
+		} else {
+			anonymousLimit(c)
+		}
+	}
+}
+
 func (s *Server) authMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		if c.GetHeader("X-API-Key") == "" && c.GetHeader("Authorization") == "" {
 			utils.UnauthorizedResponse(c, "Authorization header required")
 			c.Abort()
 			return
 		}
 
-		tokenParts := strings.Split(authHeader, " ")
-		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			utils.UnauthorizedResponse(c, "Invalid authorization header format")
-			c.Abort()
+		if !s.authenticate(c) {
 			return
 		}
 
-		claims, err := utils.ValidateToken(tokenParts[1], s.config.JWT.Secret)
-		if err != nil {
-			utils.UnauthorizedResponse(c, "Invalid token")
+		c.Next()
+	}
+}
+
+// optionalAuthMiddleware authenticates the request when credentials are
+// sent and lets anonymous requests through. Invalid credentials are still
+// rejected rather than silently treated as anonymous.
+func (s *Server) optionalAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !s.authenticate(c) {
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// requireUserSession rejects requests authenticated with an API key or an
+// impersonation token. It guards credential changes, two-factor settings,
+// data export, account deletion and checkout, which need the account holder
+// rather than an integration or staff member acting on their behalf.
+func (s *Server) requireUserSession() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := c.Get("api_key_id"); ok {
+			utils.ForbiddenResponse(c, "API keys cannot be used for this operation")
 			c.Abort()
 			return
 		}
 
-		c.Set("user_id", claims.UserID)
-		c.Set("user_email", claims.Email)
-		c.Set("user_role", claims.Role)
+		if c.GetUint("impersonator_id") != 0 {
+			utils.ForbiddenResponse(c, "Not allowed while impersonating")
+			c.Abort()
+			return
+		}
 
 		c.Next()
 	}
 }
 
-func (s *Server) adminMiddleware() gin.HandlerFunc {
+// authenticate sets the user context keys from an API key or bearer token,
+// if present. It aborts the request and returns false when the credentials
+// are invalid.
+func (s *Server) authenticate(c *gin.Context) bool {
+	if apiKey := c.GetHeader("X-API-Key"); apiKey != "" {
+		return s.authenticateAPIKey(c, apiKey)
+	}
+
+	authHeader := c.GetHeader("Authorization")
+	if authHeader == "" {
+		return true
+	}
+
+	tokenParts := strings.Split(authHeader, " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		utils.UnauthorizedResponse(c, "Invalid authorization header format")
+		c.Abort()
+		return false
+	}
+
+	claims, err := s.authService.AuthenticateToken(tokenParts[1])
+	if err != nil {
+		s.errorResponse(c, "Invalid token", err)
+		c.Abort()
+		return false
+	}
+
+	c.Set("user_id", claims.UserID)
+	c.Set("user_email", claims.Email)
+	c.Set("user_role", claims.Role)
+	c.Set("two_factor_pending", s.config.Auth.RequireAdminTwoFactor && !claims.TwoFactorVerified)
+	if claims.ImpersonatorID != 0 {
+		c.Set("impersonator_id", claims.ImpersonatorID)
+	}
+	var authTime time.Time
+	if claims.AuthTime != nil {
+		authTime = claims.AuthTime.Time
+	}
+	setRequestUser(c, claims.UserID, claims.ImpersonatorID, authTime)
+
+	return true
+}
+
+// authenticateAPIKey maps an API key onto the same context keys as a user
+// token. The key's scopes further restrict the owner's permissions.
+func (s *Server) authenticateAPIKey(c *gin.Context, rawKey string) bool {
+	key, err := s.apiKeyService.Authenticate(rawKey)
+	if err != nil {
+		utils.UnauthorizedResponse(c, "Invalid API key")
+		c.Abort()
+		return false
+	}
+
+	c.Set("user_id", key.UserID)
+	c.Set("user_email", key.User.Email)
+	c.Set("user_role", string(key.User.Role))
+	c.Set("two_factor_pending", false)
+	c.Set("api_key_id", key.ID)
+	c.Set("api_key_scopes", key.Scopes)
+	setRequestUser(c, key.UserID, 0, time.Time{})
+
+	return true
+}
+
+// setRequestUser makes the authenticated user available to services through
+// the request context
+func setRequestUser(c *gin.Context, userID, impersonatorID uint, authTime time.Time) {
+	ctx := context.WithValue(c.Request.Context(), utils.UserIDKey, userID)
+	ctx = context.WithValue(ctx, utils.ImpersonatorIDKey, impersonatorID)
+	ctx = context.WithValue(ctx, utils.AuthTimeKey, authTime)
+	c.Request = c.Request.WithContext(ctx)
+}
+
+// RequirePermission allows the request only when one of the user's roles
+// grants the permission. Permissions are loaded once per request so role
+// changes take effect immediately.
+func (s *Server) RequirePermission(permission string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role, exists := c.Get("user_role")
-		if !exists {
+		userID := c.GetUint("user_id")
+		if userID == 0 || c.GetUint("impersonator_id") != 0 {
 			utils.ForbiddenResponse(c, "Forbidden")
 			c.Abort()
 			return
 		}
 
-		if role != string(models.UserRoleAdmin) {
+		permissions, err := s.userPermissions(c, userID)
+		if err != nil {
+			s.errorResponse(c, "Failed to load permissions", err)
+			c.Abort()
+			return
+		}
+
+		if !slices.Contains(permissions, permission) {
 			utils.ForbiddenResponse(c, "Forbidden")
 			c.Abort()
 			return
 		}
 
+		if scopes, ok := c.Get("api_key_scopes"); ok && !slices.Contains(scopes.([]string), permission) {
+			utils.ForbiddenResponse(c, "API key is not scoped for this operation")
+			c.Abort()
+			return
+		}
+
+		if c.GetBool("two_factor_pending") {
+			utils.ForbiddenResponse(c, "Two-factor authentication required for staff access")
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
+
+func (s *Server) userPermissions(c *gin.Context, userID uint) ([]string, error) {
+	if permissions, ok := c.Get("user_permissions"); ok {
+		return permissions.([]string), nil
+	}
+
+	permissions, err := s.rbacService.GetPermissions(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Set("user_permissions", permissions)
+	return permissions, nil
+}
@@ -0,0 +1,92 @@
+package server
+
+import (
+	"net/http"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	oidcFlowCookie       = "oidc_flow"
+	oidcFlowCookieMaxAge = 600
+)
+
+// @Summary List social login providers
+// @Description List the configured OpenID Connect providers
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} utils.Response{data=[]string} "Providers retrieved successfully"
+// @Router /auth/oidc/providers [get]
+func (s *Server) getOIDCProviders(c *gin.Context) {
+	utils.SuccessResponse(c, "providers retrieved successfully", s.oidcService.Providers())
+}
+
+// @Summary Start social login
+// @Description Redirect to the identity provider's authorization page using the authorization code flow with PKCE
+// @Tags Authentication
+// @Param provider path string true "Provider name"
+// @Success 302 "Redirect to identity provider"
+// @Failure 404 {object} utils.Response "Unknown provider"
+// @Router /auth/oidc/{provider}/login [get]
+func (s *Server) oidcLogin(c *gin.Context) {
+	authURL, flowState, err := s.oidcService.BeginLogin(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		s.errorResponse(c, "failed to start login", err)
+		return
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcFlowCookie, flowState, oidcFlowCookieMaxAge, "/api/v1/auth/oidc", "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, authURL)
+}
+
+// @Summary Complete social login
+// @Description Handle the identity provider callback, link or create the account and return a token pair
+// @Tags Authentication
+// @Produce json
+// @Param provider path string true "Provider name"
+// @Param code query string true "Authorization code"
+// @Param state query string true "State"
+// @Success 200 {object} utils.Response{data=dto.AuthResponse} "Login successful"
+// @Failure 400 {object} utils.Response "Invalid callback"
+// @Failure 401 {object} utils.Response "Login failed"
+// @Failure 403 {object} utils.Response "Account disabled or email address not verified"
+// @Failure 429 {object} utils.Response "Account temporarily locked"
+// @Router /auth/oidc/{provider}/callback [get]
+func (s *Server) oidcCallback(c *gin.Context) {
+	if errMsg := c.Query("error"); errMsg != "" {
+		utils.UnauthorizedResponse(c, "identity provider returned an error: "+errMsg)
+		return
+	}
+
+	var req dto.OIDCCallbackRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	flowState, err := c.Cookie(oidcFlowCookie)
+	if err != nil {
+		utils.BadRequestResponse(c, "missing login state", err)
+		return
+	}
+	c.SetCookie(oidcFlowCookie, "", -1, "/api/v1/auth/oidc", "", c.Request.TLS != nil, true)
+
+	req.Provider = c.Param("provider")
+	req.FlowState = flowState
+
+	response, err := s.oidcService.CompleteLogin(c.Request.Context(), &req)
+	if err != nil {
+		s.loginErrorResponse(c, err)
+		return
+	}
+
+	if response.TwoFactorRequired {
+		utils.SuccessResponse(c, "two-factor authentication required", response)
+		return
+	}
+
+	utils.SuccessResponse(c, "logged in successfully", response)
+}
@@ -3,7 +3,7 @@ package server
 import (
 	"strconv"
 
-	_ "github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"github.com/gin-gonic/gin"
 )
@@ -14,15 +14,17 @@ import (
 // @Produce json
 // @Security BearerAuth
 // @Success 201 {object} utils.Response{data=dto.OrderResponse} "Order created successfully"
-// @Failure 400 {object} utils.Response "Cart is empty or insufficient stock"
+// @Failure 400 {object} utils.Response "Cart is empty"
 // @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Email address not verified, or impersonating or using an API key"
+// @Failure 409 {object} utils.Response "Insufficient stock (code OUT_OF_STOCK)"
 // @Router /orders [post]
 func (s *Server) createOrder(c *gin.Context) {
 	userID := c.GetUint("user_id")
 
 	order, err := s.orderService.CreateOrder(userID)
 	if err != nil {
-		utils.BadRequestResponse(c, "Failed to create order", err)
+		s.errorResponse(c, "Failed to create order", err)
 		return
 	}
 
@@ -30,13 +32,15 @@ func (s *Server) createOrder(c *gin.Context) {
 }
 
 // @Summary Get user's orders
-// @Description Retrieve paginated list of user's orders
+// @Description Retrieve paginated list of user's orders, newest first. Passing cursor (empty for the first page) switches to keyset pagination and returns utils.CursorMeta, whose end_cursor is the cursor for the next page.
 // @Tags Orders
 // @Produce json
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Cursor from a previous page's end_cursor"
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.OrderResponse} "Orders retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid cursor"
 // @Failure 401 {object} utils.Response "Unauthorized"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /orders [get]
@@ -46,9 +50,20 @@ func (s *Server) getOrders(c *gin.Context) {
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
 
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		orders, meta, err := s.orderService.GetOrdersByCursor(userID, &dto.CursorPageRequest{First: limit, After: cursor})
+		if err != nil {
+			s.errorResponse(c, "Failed to fetch orders", err)
+			return
+		}
+
+		utils.CursorPaginatedSuccessResponse(c, "Orders retrieved successfully", orders, *meta)
+		return
+	}
+
 	orders, meta, err := s.orderService.GetOrders(userID, page, limit)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to fetch orders", err)
+		s.errorResponse(c, "Failed to fetch orders", err)
 		return
 	}
 
@@ -77,9 +92,100 @@ func (s *Server) getOrder(c *gin.Context) {
 
 	order, err := s.orderService.GetOrder(userID, uint(id))
 	if err != nil {
-		utils.NotFoundResponse(c, "Order not found")
+		s.errorResponse(c, "Failed to fetch order", err)
 		return
 	}
 
 	utils.SuccessResponse(c, "Order retrieved successfully", order)
 }
+
+// @Summary List all orders
+// @Description Paginated list of every customer's orders, newest first, optionally for one customer or status (requires orders:read)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param user_id query int false "Customer ID"
+// @Param status query string false "Order status" Enums(pending, confirmed, shipped, delivered, cancelled)
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Success 200 {object} utils.PaginatedResponse{data=[]dto.OrderResponse} "Orders retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/orders [get]
+func (s *Server) listAllOrders(c *gin.Context) {
+	var req dto.AdminOrderListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	orders, meta, err := s.orderService.GetAllOrders(&req)
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch orders", err)
+		return
+	}
+
+	utils.PaginatedSuccessResponse(c, "Orders retrieved successfully", orders, *meta)
+}
+
+// @Summary Get any order
+// @Description Retrieve an order of any customer (requires orders:read)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Success 200 {object} utils.Response{data=dto.OrderResponse} "Order retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid order ID"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /admin/orders/{id} [get]
+func (s *Server) getAnyOrder(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	order, err := s.orderService.GetAnyOrder(uint(id))
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch order", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order retrieved successfully", order)
+}
+
+// @Summary Update order status
+// @Description Move an order to a new status. Subscribers to the order are notified. (requires orders:write)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Order ID"
+// @Param request body dto.UpdateOrderStatusRequest true "New status"
+// @Success 200 {object} utils.Response{data=dto.OrderResponse} "Order status updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "Order not found"
+// @Router /admin/orders/{id}/status [put]
+func (s *Server) updateOrderStatus(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid order ID", err)
+		return
+	}
+
+	var req dto.UpdateOrderStatusRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	order, err := s.orderService.UpdateOrderStatus(uint(id), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to update order status", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Order status updated successfully", order)
+}
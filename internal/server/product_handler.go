@@ -1,16 +1,16 @@
 package server
 
 import (
-	"errors"
 	"strconv"
 
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/services"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"github.com/gin-gonic/gin"
 )
 
 // @Summary Create a new category
-// @Description Create a new product category (Admin only)
+// @Description Create a new product category (requires categories:write)
 // @Tags Categories
 // @Accept json
 // @Produce json
@@ -19,7 +19,7 @@ import (
 // @Success 201 {object} utils.Response{data=dto.CategoryResponse} "Category created successfully"
 // @Failure 400 {object} utils.Response "Invalid request data"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 403 {object} utils.Response "Missing permission"
 // @Router /categories [post]
 func (s *Server) createCategory(c *gin.Context) {
 	var req dto.CreateCategoryRequest
@@ -28,9 +28,9 @@ func (s *Server) createCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := s.productService.CreateCategory(&req)
+	category, err := s.productService.CreateCategory(c.Request.Context(), &req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to create category", err)
+		s.errorResponse(c, "Failed to create category", err)
 		return
 	}
 
@@ -48,7 +48,7 @@ func (s *Server) createCategory(c *gin.Context) {
 func (s *Server) getCategories(c *gin.Context) {
 	categories, err := s.productService.GetCategories()
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to fetch categories", err)
+		s.errorResponse(c, "Failed to fetch categories", err)
 		return
 	}
 
@@ -56,7 +56,7 @@ func (s *Server) getCategories(c *gin.Context) {
 }
 
 // @Summary Update a category
-// @Description Update an existing category (Admin only)
+// @Description Update an existing category (requires categories:write)
 // @Tags Categories
 // @Accept json
 // @Produce json
@@ -66,7 +66,7 @@ func (s *Server) getCategories(c *gin.Context) {
 // @Success 200 {object} utils.Response{data=dto.CategoryResponse} "Category updated successfully"
 // @Failure 400 {object} utils.Response "Invalid request data"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 403 {object} utils.Response "Missing permission"
 // @Router /categories/{id} [put]
 func (s *Server) updateCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -81,9 +81,9 @@ func (s *Server) updateCategory(c *gin.Context) {
 		return
 	}
 
-	category, err := s.productService.UpdateCategory(uint(id), &req)
+	category, err := s.productService.UpdateCategory(c.Request.Context(), uint(id), &req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to update category", err)
+		s.errorResponse(c, "Failed to update category", err)
 		return
 	}
 
@@ -92,14 +92,14 @@ func (s *Server) updateCategory(c *gin.Context) {
 }
 
 // @Summary Delete a category
-// @Description Delete a category (Admin only)
+// @Description Delete a category (requires categories:write)
 // @Tags Categories
 // @Security BearerAuth
 // @Param id path int true "Category ID"
 // @Success 200 {object} utils.Response "Category deleted successfully"
 // @Failure 400 {object} utils.Response "Invalid category ID"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 403 {object} utils.Response "Missing permission"
 // @Router /categories/{id} [delete]
 func (s *Server) deleteCategory(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -108,8 +108,8 @@ func (s *Server) deleteCategory(c *gin.Context) {
 		return
 	}
 
-	if err := s.productService.DeleteCategory(uint(id)); err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to delete category", err)
+	if err := s.productService.DeleteCategory(c.Request.Context(), uint(id)); err != nil {
+		s.errorResponse(c, "Failed to delete category", err)
 		return
 	}
 
@@ -117,7 +117,7 @@ func (s *Server) deleteCategory(c *gin.Context) {
 }
 
 // @Summary Create a new product
-// @Description Create a new product (Admin only)
+// @Description Create a new product (requires products:write)
 // @Tags Products
 // @Accept json
 // @Produce json
@@ -126,7 +126,7 @@ func (s *Server) deleteCategory(c *gin.Context) {
 // @Success 201 {object} utils.Response{data=dto.ProductResponse} "Product created successfully"
 // @Failure 400 {object} utils.Response "Invalid request data"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 403 {object} utils.Response "Missing permission"
 // @Router /products [post]
 func (s *Server) createProduct(c *gin.Context) {
 	var req dto.CreateProductRequest
@@ -134,9 +134,9 @@ func (s *Server) createProduct(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid request data", err)
 		return
 	}
-	product, err := s.productService.CreateProduct(&req)
+	product, err := s.productService.CreateProduct(c.Request.Context(), &req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to create product", err)
+		s.errorResponse(c, "Failed to create product", err)
 		return
 	}
 
@@ -144,21 +144,44 @@ func (s *Server) createProduct(c *gin.Context) {
 }
 
 // @Summary Get all products
-// @Description Retrieve paginated list of active products
+// @Description Retrieve a filtered, sorted and paginated list of active products. Passing cursor (empty for the first page) switches to keyset pagination and returns utils.CursorMeta, whose end_cursor is the cursor for the next page. Cursors are only valid with the sort they were issued for.
 // @Tags Products
 // @Produce json
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Cursor from a previous page's end_cursor"
+// @Param sort query string false "Sort order, by ID when omitted" Enums(price_asc, price_desc, newest, name, best_selling)
+// @Param category_id query int false "Category, including its subcategories"
+// @Param min_price query number false "Minimum price"
+// @Param max_price query number false "Maximum price"
+// @Param in_stock query bool false "Only products in stock"
+// @Param attr[name] query string false "Attribute value, e.g. attr[color]=red; repeat for several attributes"
 // @Success 200 {object} utils.PaginatedResponse{data=[]dto.ProductResponse} "Products retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters or cursor"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /products [get]
 func (s *Server) getProducts(c *gin.Context) {
-	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
-	limit, _ := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	var req dto.ProductListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+	req.Attributes = c.QueryMap("attr")
 
-	products, meta, err := s.productService.GetProducts(page, limit)
+	if cursor, ok := c.GetQuery("cursor"); ok {
+		products, meta, err := s.productService.GetProductsByCursor(&req, &dto.CursorPageRequest{First: req.Limit, After: cursor})
+		if err != nil {
+			s.errorResponse(c, "Failed to fetch products", err)
+			return
+		}
+
+		utils.CursorPaginatedSuccessResponse(c, "Products retrieved successfully", products, *meta)
+		return
+	}
+
+	products, meta, err := s.productService.GetProducts(&req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to fetch products", err)
+		s.errorResponse(c, "Failed to fetch products", err)
 		return
 	}
 
@@ -183,7 +206,7 @@ func (s *Server) getProduct(c *gin.Context) {
 
 	product, err := s.productService.GetProduct(uint(id))
 	if err != nil {
-		utils.NotFoundResponse(c, "Product not found")
+		s.errorResponse(c, "Failed to fetch product", err)
 		return
 	}
 
@@ -191,7 +214,7 @@ func (s *Server) getProduct(c *gin.Context) {
 }
 
 // @Summary Update a product
-// @Description Update an existing product (Admin only)
+// @Description Update an existing product (requires products:write)
 // @Tags Products
 // @Accept json
 // @Produce json
@@ -201,7 +224,7 @@ func (s *Server) getProduct(c *gin.Context) {
 // @Success 200 {object} utils.Response{data=dto.ProductResponse} "Product updated successfully"
 // @Failure 400 {object} utils.Response "Invalid request data"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 403 {object} utils.Response "Missing permission"
 // @Router /products/{id} [put]
 func (s *Server) updateProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -216,9 +239,9 @@ func (s *Server) updateProduct(c *gin.Context) {
 		return
 	}
 
-	product, err := s.productService.UpdateProduct(uint(id), &req)
+	product, err := s.productService.UpdateProduct(c.Request.Context(), uint(id), &req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to update product", err)
+		s.errorResponse(c, "Failed to update product", err)
 		return
 	}
 
@@ -226,14 +249,14 @@ func (s *Server) updateProduct(c *gin.Context) {
 }
 
 // @Summary Delete a product
-// @Description Delete a product (Admin only)
+// @Description Delete a product (requires products:write)
 // @Tags Products
 // @Security BearerAuth
 // @Param id path int true "Product ID"
 // @Success 200 {object} utils.Response "Product deleted successfully"
 // @Failure 400 {object} utils.Response "Invalid product ID"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 403 {object} utils.Response "Missing permission"
 // @Router /products/{id} [delete]
 func (s *Server) deleteProduct(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -241,8 +264,8 @@ func (s *Server) deleteProduct(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid product ID", err)
 		return
 	}
-	if err := s.productService.DeleteProduct(uint(id)); err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to delete product", err)
+	if err := s.productService.DeleteProduct(c.Request.Context(), uint(id)); err != nil {
+		s.errorResponse(c, "Failed to delete product", err)
 		return
 	}
 
@@ -250,7 +273,7 @@ func (s *Server) deleteProduct(c *gin.Context) {
 }
 
 // @Summary Upload product image
-// @Description Upload an image for a product (Admin only)
+// @Description Upload an image for a product (requires products:write)
 // @Tags Products
 // @Accept multipart/form-data
 // @Produce json
@@ -260,7 +283,7 @@ func (s *Server) deleteProduct(c *gin.Context) {
 // @Success 200 {object} utils.Response{data=map[string]string} "Image uploaded successfully"
 // @Failure 400 {object} utils.Response "Invalid request or file"
 // @Failure 401 {object} utils.Response "Unauthorized"
-// @Failure 403 {object} utils.Response "Admin access required"
+// @Failure 403 {object} utils.Response "Missing permission"
 // @Router /products/{id}/images [post]
 func (s *Server) uploadProductImage(c *gin.Context) {
 	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
@@ -275,14 +298,21 @@ func (s *Server) uploadProductImage(c *gin.Context) {
 		return
 	}
 
-	url, err := s.uploadService.UploadProductImage(uint(id), file)
+	src, err := file.Open()
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to upload image", err)
+		utils.BadRequestResponse(c, "No file uploaded", err)
 		return
 	}
+	defer src.Close()
 
-	if err := s.productService.AddProductImage(uint(id), url, file.Filename); err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to save image record", err)
+	url, err := s.uploadService.UploadProductImage(uint(id), file.Filename, src)
+	if err != nil {
+		s.errorResponse(c, "Failed to upload image", err)
+		return
+	}
+
+	if err := s.productService.AddProductImage(c.Request.Context(), uint(id), url, file.Filename); err != nil {
+		s.errorResponse(c, "Failed to save image record", err)
 		return
 	}
 
@@ -290,17 +320,20 @@ func (s *Server) uploadProductImage(c *gin.Context) {
 }
 
 // @Summary Search products
-// @Description Search products using full-text search with ranking
+// @Description Search products by relevance. Admin-managed synonyms are expanded, boosted products rank higher and buried ones lower, and products pinned to the query come first, marked as pinned. Typo handling depends on the search backend: the postgres backend returns products with similar names when nothing matches, and the opensearch backend matches words fuzzily. The response includes facet counts by category, price range, stock and attribute over all matching products; each facet ignores the filter on its own dimension. First pages are logged for search analytics and return a search_id to quote when reporting clicks and cart additions; pass it as search_id when fetching later pages so their products can be reported too. A bearer token is optional and links the search to the signed-in user.
 // @Tags Products
 // @Produce json
 // @Param q query string true "Search query"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param category_id query int false "Filter by category ID"
+// @Param category_id query int false "Filter by category, including its subcategories"
 // @Param min_price query number false "Minimum price filter"
 // @Param max_price query number false "Maximum price filter"
-// @Success 200 {object} utils.PaginatedResponse{data=[]dto.ProductSearchResult} "Search results"
-// @Failure 400 {object} utils.Response "Invalid search query"
+// @Param in_stock query bool false "Only products in stock"
+// @Param attr[name] query string false "Attribute value, e.g. attr[color]=red; repeat for several attributes"
+// @Param search_id query int false "search_id returned with the first page, when fetching later pages"
+// @Success 200 {object} utils.FacetedResponse{data=[]dto.ProductSearchResult,facets=dto.SearchFacets} "Search results"
+// @Failure 400 {object} utils.Response "Invalid search query or page beyond the first 10000 results"
 // @Failure 500 {object} utils.Response "Internal server error"
 // @Router /search [get]
 func (s *Server) searchProducts(c *gin.Context) {
@@ -309,13 +342,54 @@ func (s *Server) searchProducts(c *gin.Context) {
 		utils.BadRequestResponse(c, "Invalid search parameters", err)
 		return
 	}
+	req.Attributes = c.QueryMap("attr")
+
+	results, meta, err := s.productService.SearchProducts(c.Request.Context(), &req)
+	if err != nil {
+		s.errorResponse(c, "Search failed", err)
+		return
+	}
+
+	facets, err := s.productService.SearchFacets(c.Request.Context(), &req)
+	if err != nil {
+		s.errorResponse(c, "Search failed", err)
+		return
+	}
+
+	// Only first pages are logged as searches. Later pages quoting the first
+	// page's search_id add their products to its results.
+	var searchID uint
+	if meta.Page == 1 {
+		searchID = s.searchAnalyticsService.RecordSearch(c.Request.Context(), req.Query, meta.Total, services.SearchResultIDs(results))
+	} else if req.SearchID != 0 {
+		searchID = s.searchAnalyticsService.RecordResults(req.SearchID, req.Query, services.SearchResultIDs(results))
+	}
+
+	utils.FacetedSuccessResponse(c, "OK", results, facets, searchID, *meta)
+}
+
+// @Summary Suggest search completions
+// @Description Autocomplete a partial search query with category and product names in which a word starts with the query. Categories are listed first.
+// @Tags Products
+// @Produce json
+// @Param q query string true "Partial search query"
+// @Param limit query int false "Maximum suggestions, at most 20" default(8)
+// @Success 200 {object} utils.Response{data=[]dto.SearchSuggestion} "Suggestions"
+// @Failure 400 {object} utils.Response "Invalid search query"
+// @Failure 500 {object} utils.Response "Internal server error"
+// @Router /search/suggest [get]
+func (s *Server) suggestProducts(c *gin.Context) {
+	var req dto.SearchSuggestRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid search parameters", err)
+		return
+	}
 
-	results, meta, err := s.productService.SearchProducts(&req)
+	suggestions, err := s.productService.SuggestProducts(c.Request.Context(), &req)
 	if err != nil {
-		s.logger.Error().Err(err).Msg("Product search failed")
-		utils.InternalServerErrorResponse(c, "Search failed", errors.New("unable to complete search at this time"))
+		s.errorResponse(c, "Failed to fetch suggestions", err)
 		return
 	}
 
-	utils.PaginatedSuccessResponse(c, "OK", results, *meta)
+	utils.SuccessResponse(c, "OK", suggestions)
 }
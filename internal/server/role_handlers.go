@@ -0,0 +1,150 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List roles
+// @Description List all roles with their permissions (requires roles:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.RoleResponse} "Roles retrieved successfully"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/roles [get]
+func (s *Server) getRoles(c *gin.Context) {
+	roles, err := s.rbacService.GetRoles()
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch roles", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Roles retrieved successfully", roles)
+}
+
+// @Summary Get user roles
+// @Description List the roles assigned to a user (requires roles:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} utils.Response{data=[]dto.RoleResponse} "Roles retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid user ID"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "User not found"
+// @Router /admin/users/{id}/roles [get]
+func (s *Server) getUserRoles(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	roles, err := s.rbacService.GetUserRoles(uint(id))
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch user roles", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Roles retrieved successfully", roles)
+}
+
+// @Summary Assign role
+// @Description Grant a role to a user. Staff cannot change their own roles. (requires roles:manage)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.AssignRoleRequest true "Role to assign"
+// @Success 200 {object} utils.Response{data=[]dto.RoleResponse} "Role assigned successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/users/{id}/roles [post]
+func (s *Server) assignRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req dto.AssignRoleRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	roles, err := s.rbacService.AssignRole(c.Request.Context(), c.GetUint("user_id"), uint(id), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to assign role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Role assigned successfully", roles)
+}
+
+// @Summary Remove role
+// @Description Revoke a role from a user. Staff cannot change their own roles. (requires roles:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param role path string true "Role name"
+// @Success 200 {object} utils.Response{data=[]dto.RoleResponse} "Role removed successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/users/{id}/roles/{role} [delete]
+func (s *Server) removeRole(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	roles, err := s.rbacService.RemoveRole(c.Request.Context(), c.GetUint("user_id"), uint(id), c.Param("role"))
+	if err != nil {
+		s.errorResponse(c, "Failed to remove role", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Role removed successfully", roles)
+}
+
+// @Summary Set user roles
+// @Description Replace all roles of a user. Staff cannot change their own roles. (requires roles:manage)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.SetUserRolesRequest true "Role names"
+// @Success 200 {object} utils.Response{data=[]dto.RoleResponse} "Roles updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/users/{id}/roles [put]
+func (s *Server) setUserRoles(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid user ID", err)
+		return
+	}
+
+	var req dto.SetUserRolesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	roles, err := s.rbacService.SetUserRoles(c.Request.Context(), c.GetUint("user_id"), uint(id), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to update roles", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Roles updated successfully", roles)
+}
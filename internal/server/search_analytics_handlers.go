@@ -0,0 +1,62 @@
+package server
+
+import (
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Record a search result click
+// @Description Report that a shopper opened a product from the results of a search, for click-through rates in search analytics. The product must be one the search returned.
+// @Tags Products
+// @Accept json
+// @Produce json
+// @Param request body dto.SearchClickRequest true "Search and product clicked"
+// @Success 200 {object} utils.Response "Click recorded"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 404 {object} utils.Response "Search not found or did not return the product"
+// @Failure 429 {object} utils.Response "Rate limit exceeded"
+// @Router /search/clicks [post]
+func (s *Server) recordSearchClick(c *gin.Context) {
+	var req dto.SearchClickRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := s.searchAnalyticsService.RecordEvent(req.SearchID, req.ProductID, models.SearchEventClick); err != nil {
+		s.errorResponse(c, "Failed to record click", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Click recorded", nil)
+}
+
+// @Summary Search analytics report
+// @Description Top queries, queries that found nothing, and click-through and add-to-cart rates over a range of UTC days (requires search_analytics:read). Stats are refreshed hourly.
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param from query string false "First day (YYYY-MM-DD), defaults to 29 days before to"
+// @Param to query string false "Last day (YYYY-MM-DD), defaults to today"
+// @Param limit query int false "Queries per list, at most 100" default(20)
+// @Success 200 {object} utils.Response{data=dto.SearchReport} "Search report retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid query parameters"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/search/report [get]
+func (s *Server) getSearchReport(c *gin.Context) {
+	var req dto.SearchReportRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid query parameters", err)
+		return
+	}
+
+	report, err := s.searchAnalyticsService.GetSearchReport(&req)
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch search report", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Search report retrieved successfully", report)
+}
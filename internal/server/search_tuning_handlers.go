@@ -0,0 +1,249 @@
+package server
+
+import (
+	"strconv"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary List search synonym sets
+// @Description List the sets of terms that search treats as equivalent (requires search:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.SynonymSetResponse} "Synonym sets retrieved successfully"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/search/synonyms [get]
+func (s *Server) listSynonymSets(c *gin.Context) {
+	sets, err := s.searchTuningService.ListSynonymSets()
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch synonym sets", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Synonym sets retrieved successfully", sets)
+}
+
+// @Summary Create search synonym set
+// @Description Add a set of equivalent terms. A search for any term, or a phrase containing one, also matches the others. (requires search:manage)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SynonymSetRequest true "Synonym terms"
+// @Success 201 {object} utils.Response{data=dto.SynonymSetResponse} "Synonym set created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/search/synonyms [post]
+func (s *Server) createSynonymSet(c *gin.Context) {
+	var req dto.SynonymSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	set, err := s.searchTuningService.CreateSynonymSet(c.Request.Context(), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to create synonym set", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Synonym set created successfully", set)
+}
+
+// @Summary Update search synonym set
+// @Description Replace the terms of a synonym set (requires search:manage)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Synonym set ID"
+// @Param request body dto.SynonymSetRequest true "Synonym terms"
+// @Success 200 {object} utils.Response{data=dto.SynonymSetResponse} "Synonym set updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "Synonym set not found"
+// @Router /admin/search/synonyms/{id} [put]
+func (s *Server) updateSynonymSet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid synonym set ID", err)
+		return
+	}
+
+	var req dto.SynonymSetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	set, err := s.searchTuningService.UpdateSynonymSet(c.Request.Context(), uint(id), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to update synonym set", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Synonym set updated successfully", set)
+}
+
+// @Summary Delete search synonym set
+// @Description Remove a synonym set (requires search:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Synonym set ID"
+// @Success 200 {object} utils.Response "Synonym set deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid synonym set ID"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "Synonym set not found"
+// @Router /admin/search/synonyms/{id} [delete]
+func (s *Server) deleteSynonymSet(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid synonym set ID", err)
+		return
+	}
+
+	if err := s.searchTuningService.DeleteSynonymSet(c.Request.Context(), uint(id)); err != nil {
+		s.errorResponse(c, "Failed to delete synonym set", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Synonym set deleted successfully", nil)
+}
+
+// @Summary List product search boosts
+// @Description List the products whose search ranking is boosted or buried, most boosted first (requires search:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=[]dto.ProductBoostResponse} "Product boosts retrieved successfully"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/search/boosts [get]
+func (s *Server) listProductBoosts(c *gin.Context) {
+	boosts, err := s.searchTuningService.ListProductBoosts()
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch product boosts", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Product boosts retrieved successfully", boosts)
+}
+
+// @Summary Set product search boost
+// @Description Boost (positive) or bury (negative) a product in search results. Each point doubles or halves its relevance score; 0 clears the adjustment. (requires search:manage)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param product_id path int true "Product ID"
+// @Param request body dto.ProductBoostRequest true "Boost score between -10 and 10"
+// @Success 200 {object} utils.Response{data=dto.ProductBoostResponse} "Product boost updated successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Router /admin/search/boosts/{product_id} [put]
+func (s *Server) setProductBoost(c *gin.Context) {
+	productID, err := strconv.ParseUint(c.Param("product_id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid product ID", err)
+		return
+	}
+
+	var req dto.ProductBoostRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	boost, err := s.searchTuningService.SetProductBoost(c.Request.Context(), uint(productID), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to update product boost", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Product boost updated successfully", boost)
+}
+
+// @Summary List pinned search results
+// @Description List pinned results, optionally for a single query (requires search:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param query query string false "Search query"
+// @Success 200 {object} utils.Response{data=[]dto.SearchPinResponse} "Search pins retrieved successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Router /admin/search/pins [get]
+func (s *Server) listSearchPins(c *gin.Context) {
+	var req dto.SearchPinListRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	pins, err := s.searchTuningService.ListSearchPins(&req)
+	if err != nil {
+		s.errorResponse(c, "Failed to fetch search pins", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Search pins retrieved successfully", pins)
+}
+
+// @Summary Pin search result
+// @Description Pin a product to the top of the results for a query. Pins apply to the exact query, ignoring case and spacing, and are ordered by position. Pinned products still have to match the search filters. (requires search:manage)
+// @Tags Admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.SearchPinRequest true "Pin details"
+// @Success 201 {object} utils.Response{data=dto.SearchPinResponse} "Search pin created successfully"
+// @Failure 400 {object} utils.Response "Invalid request data"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "Product not found"
+// @Failure 409 {object} utils.Response "Product already pinned for the query"
+// @Router /admin/search/pins [post]
+func (s *Server) createSearchPin(c *gin.Context) {
+	var req dto.SearchPinRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	pin, err := s.searchTuningService.CreateSearchPin(c.Request.Context(), &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to create search pin", err)
+		return
+	}
+
+	utils.CreatedResponse(c, "Search pin created successfully", pin)
+}
+
+// @Summary Unpin search result
+// @Description Remove a pinned search result (requires search:manage)
+// @Tags Admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Search pin ID"
+// @Success 200 {object} utils.Response "Search pin deleted successfully"
+// @Failure 400 {object} utils.Response "Invalid search pin ID"
+// @Failure 403 {object} utils.Response "Missing permission"
+// @Failure 404 {object} utils.Response "Search pin not found"
+// @Router /admin/search/pins/{id} [delete]
+func (s *Server) deleteSearchPin(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 32)
+	if err != nil {
+		utils.BadRequestResponse(c, "Invalid search pin ID", err)
+		return
+	}
+
+	if err := s.searchTuningService.DeleteSearchPin(c.Request.Context(), uint(id)); err != nil {
+		s.errorResponse(c, "Failed to delete search pin", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Search pin deleted successfully", nil)
+}
@@ -1,10 +1,16 @@
 package server
 
 import (
+	"fmt"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
 
 	_ "github.com/abhilashdk2016/golang-ecommerce/docs"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/config"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/services"
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
@@ -14,103 +20,188 @@ import (
 )
 
 type Server struct {
-	config         *config.Config
-	logger         *zerolog.Logger
-	authService    services.AuthServiceInterface
-	productService services.ProductServiceInterface
-	userService    services.UserServiceInterface
-	uploadService  services.UploadServiceInterface
-	cartService    services.CartServiceInterface
-	orderService   services.OrderServiceInterface
+	config                 *config.Config
+	logger                 *zerolog.Logger
+	rateLimitStore         interfaces.RateLimitStore
+	authService            services.AuthServiceInterface
+	oidcService            services.OIDCServiceInterface
+	rbacService            services.RBACServiceInterface
+	apiKeyService          services.APIKeyServiceInterface
+	auditService           services.AuditServiceInterface
+	privacyService         services.PrivacyServiceInterface
+	productService         services.ProductServiceInterface
+	userService            services.UserServiceInterface
+	uploadService          services.UploadServiceInterface
+	cartService            services.CartServiceInterface
+	orderService           services.OrderServiceInterface
+	searchAnalyticsService services.SearchAnalyticsServiceInterface
+	searchTuningService    services.SearchTuningServiceInterface
 }
 
 func New(
 	cfg *config.Config,
 	logger *zerolog.Logger,
+	rateLimitStore interfaces.RateLimitStore,
 	authService services.AuthServiceInterface,
+	oidcService services.OIDCServiceInterface,
+	rbacService services.RBACServiceInterface,
+	apiKeyService services.APIKeyServiceInterface,
+	auditService services.AuditServiceInterface,
+	privacyService services.PrivacyServiceInterface,
 	productService services.ProductServiceInterface,
 	userService services.UserServiceInterface,
 	uploadService services.UploadServiceInterface,
 	cartService services.CartServiceInterface,
 	orderService services.OrderServiceInterface,
+	searchAnalyticsService services.SearchAnalyticsServiceInterface,
+	searchTuningService services.SearchTuningServiceInterface,
 ) *Server {
 	return &Server{
-		config:         cfg,
-		logger:         logger,
-		authService:    authService,
-		productService: productService,
-		userService:    userService,
-		uploadService:  uploadService,
-		cartService:    cartService,
-		orderService:   orderService,
+		config:                 cfg,
+		logger:                 logger,
+		rateLimitStore:         rateLimitStore,
+		authService:            authService,
+		oidcService:            oidcService,
+		rbacService:            rbacService,
+		apiKeyService:          apiKeyService,
+		auditService:           auditService,
+		privacyService:         privacyService,
+		productService:         productService,
+		userService:            userService,
+		uploadService:          uploadService,
+		cartService:            cartService,
+		orderService:           orderService,
+		searchAnalyticsService: searchAnalyticsService,
+		searchTuningService:    searchTuningService,
 	}
 }
 
 func (s *Server) SetupRoutes() *gin.Engine {
 	router := gin.New()
 
+	// Only trust X-Forwarded-For from known proxies, otherwise any client
+	// could pick its own address and evade per-IP limits
+	if err := router.SetTrustedProxies(s.config.Server.TrustedProxies); err != nil {
+		s.logger.Fatal().Err(err).Msg("invalid trusted proxies")
+	}
+
 	// Add middlewares
 	router.Use(gin.Logger())
 	router.Use(gin.Recovery())
+	router.Use(s.securityHeadersMiddleware())
 	router.Use(s.corsMiddleware())
+	router.Use(s.requestContextMiddleware())
 
 	// Add routes
 	router.GET("/health", s.healthCheck)
 	router.Static("/uploads", "./uploads")
 
-	router.GET("/playground", s.playgroundHandler())
-	router.GET("/playground/public", s.playgroundPublicHandler())
-	router.GET("/playground/protected", s.playgroundProtectedHandler())
-
-	graphqlPublic := router.Group("/graphql/public")
-	graphqlPublic.Use(s.graphqlMiddleware())
-	graphqlPublic.POST("/", s.graphqlHandler())
-
-	graphqlProtected := router.Group("/graphql")
-	graphqlProtected.Use(s.authMiddleware())
-	graphqlProtected.Use(s.graphqlMiddleware())
-	graphqlProtected.POST("/", s.graphqlHandler())
+	docs := router.Group("/")
+	docs.Use(s.docsSecurityHeadersMiddleware())
+	{
+		docRoutes := docs
+		docRoutes.GET("/playground", s.playgroundHandler())
 
-	// add documentation routes
-	router.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		// add documentation routes
+		docRoutes.GET("/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+		docRoutes.StaticFile("/api-docs", "./docs/rapidoc.html")
+	}
 
-	router.StaticFile("/api-docs", "./docs/rapidoc.html")
+	// A single GraphQL endpoint serves anonymous and authenticated clients.
+	// Access to individual fields is enforced by schema directives.
+	graphqlRoutes := router.Group("/graphql")
+	graphqlRoutes.Use(s.optionalAuthMiddleware())
+	graphqlRoutes.Use(s.rateLimitByAuth("user", "public"))
+	graphqlRoutes.Use(s.graphqlMiddleware())
+	graphqlHandler := s.graphqlHandler()
+	graphqlRoutes.GET("/", graphqlHandler)
+	graphqlRoutes.POST("/", graphqlHandler)
 
 	api := router.Group("/api/v1")
 	{
 		auth := api.Group("/auth")
+		auth.Use(s.rateLimit("auth"))
 		{
 			authRoutes := auth
 			authRoutes.POST("/register", s.register)
 			authRoutes.POST("/login", s.login)
+			authRoutes.POST("/login/2fa", s.verifyTwoFactorLogin)
 			authRoutes.POST("/refresh", s.refreshToken)
 			authRoutes.POST("/logout", s.logout)
+			authRoutes.POST("/verify-email", s.verifyEmail)
+			authRoutes.POST("/resend-verification", s.resendVerification)
+			authRoutes.POST("/confirm-email-change", s.confirmEmailChange)
+			authRoutes.GET("/oidc/providers", s.getOIDCProviders)
+			authRoutes.GET("/oidc/:provider/login", s.oidcLogin)
+			authRoutes.GET("/oidc/:provider/callback", s.oidcCallback)
 		}
 		protected := api.Group("/")
 		protected.Use(s.authMiddleware())
+		protected.Use(s.rateLimit("user"))
 		{
 			users := protected.Group("/users")
 			{
 				userRoutes := users
 				userRoutes.GET("/profile", s.getProfile)
 				userRoutes.PUT("/profile", s.updateProfile)
+				userRoutes.PUT("/password", s.requireUserSession(), s.changePassword)
+				userRoutes.POST("/email", s.requireUserSession(), s.requestEmailChange)
+				userRoutes.GET("/me/export", s.requireUserSession(), s.exportUserData)
+				userRoutes.DELETE("/me", s.requireUserSession(), s.deleteAccount)
+				userRoutes.POST("/2fa/setup", s.requireUserSession(), s.setupTwoFactor)
+				userRoutes.POST("/2fa/enable", s.requireUserSession(), s.enableTwoFactor)
+				userRoutes.POST("/2fa/disable", s.requireUserSession(), s.disableTwoFactor)
+				userRoutes.POST("/2fa/recovery-codes", s.requireUserSession(), s.regenerateRecoveryCodes)
 			}
 		}
+		admin := protected.Group("/admin")
+		{
+			adminRoutes := admin
+			adminRoutes.GET("/users", s.RequirePermission(models.PermissionUsersRead), s.searchUsers)
+			adminRoutes.GET("/users/:id", s.RequirePermission(models.PermissionUsersRead), s.getUser)
+			adminRoutes.PUT("/users/:id/status", s.RequirePermission(models.PermissionUsersWrite), s.updateUserStatus)
+			adminRoutes.PUT("/users/:id/service-account", s.RequirePermission(models.PermissionUsersWrite), s.updateServiceAccount)
+			adminRoutes.POST("/users/:id/unlock", s.RequirePermission(models.PermissionUsersWrite), s.unlockUser)
+			adminRoutes.POST("/users/:id/impersonate", s.RequirePermission(models.PermissionUsersImpersonate), s.impersonateUser)
+			adminRoutes.PUT("/users/:id/roles", s.RequirePermission(models.PermissionRolesManage), s.setUserRoles)
+			adminRoutes.GET("/roles", s.RequirePermission(models.PermissionRolesManage), s.getRoles)
+			adminRoutes.GET("/users/:id/roles", s.RequirePermission(models.PermissionRolesManage), s.getUserRoles)
+			adminRoutes.POST("/users/:id/roles", s.RequirePermission(models.PermissionRolesManage), s.assignRole)
+			adminRoutes.DELETE("/users/:id/roles/:role", s.RequirePermission(models.PermissionRolesManage), s.removeRole)
+			adminRoutes.POST("/api-keys", s.RequirePermission(models.PermissionAPIKeysManage), s.createAPIKey)
+			adminRoutes.GET("/api-keys", s.RequirePermission(models.PermissionAPIKeysManage), s.listAPIKeys)
+			adminRoutes.DELETE("/api-keys/:id", s.RequirePermission(models.PermissionAPIKeysManage), s.revokeAPIKey)
+			adminRoutes.GET("/orders", s.RequirePermission(models.PermissionOrdersRead), s.listAllOrders)
+			adminRoutes.GET("/orders/:id", s.RequirePermission(models.PermissionOrdersRead), s.getAnyOrder)
+			adminRoutes.PUT("/orders/:id/status", s.RequirePermission(models.PermissionOrdersWrite), s.updateOrderStatus)
+			adminRoutes.GET("/audit-logs", s.RequirePermission(models.PermissionAuditLogsRead), s.listAuditLogs)
+			adminRoutes.GET("/search/report", s.RequirePermission(models.PermissionSearchAnalyticsRead), s.getSearchReport)
+			adminRoutes.GET("/search/synonyms", s.RequirePermission(models.PermissionSearchManage), s.listSynonymSets)
+			adminRoutes.POST("/search/synonyms", s.RequirePermission(models.PermissionSearchManage), s.createSynonymSet)
+			adminRoutes.PUT("/search/synonyms/:id", s.RequirePermission(models.PermissionSearchManage), s.updateSynonymSet)
+			adminRoutes.DELETE("/search/synonyms/:id", s.RequirePermission(models.PermissionSearchManage), s.deleteSynonymSet)
+			adminRoutes.GET("/search/boosts", s.RequirePermission(models.PermissionSearchManage), s.listProductBoosts)
+			adminRoutes.PUT("/search/boosts/:product_id", s.RequirePermission(models.PermissionSearchManage), s.setProductBoost)
+			adminRoutes.GET("/search/pins", s.RequirePermission(models.PermissionSearchManage), s.listSearchPins)
+			adminRoutes.POST("/search/pins", s.RequirePermission(models.PermissionSearchManage), s.createSearchPin)
+			adminRoutes.DELETE("/search/pins/:id", s.RequirePermission(models.PermissionSearchManage), s.deleteSearchPin)
+		}
+
 		categories := protected.Group("/categories")
 		{
 			categoryRoute := categories
-			categoryRoute.POST("/", s.adminMiddleware(), s.createCategory)
-			categoryRoute.PUT("/:id", s.adminMiddleware(), s.updateCategory)
-			categoryRoute.DELETE("/:id", s.adminMiddleware(), s.deleteCategory)
+			categoryRoute.POST("/", s.RequirePermission(models.PermissionCategoriesWrite), s.createCategory)
+			categoryRoute.PUT("/:id", s.RequirePermission(models.PermissionCategoriesWrite), s.updateCategory)
+			categoryRoute.DELETE("/:id", s.RequirePermission(models.PermissionCategoriesWrite), s.deleteCategory)
 		}
 
 		products := protected.Group("/products")
 		{
 			productRoutes := products
-			productRoutes.POST("/", s.adminMiddleware(), s.createProduct)
-			productRoutes.PUT("/:id", s.adminMiddleware(), s.updateProduct)
-			productRoutes.DELETE("/:id", s.adminMiddleware(), s.deleteProduct)
-			productRoutes.POST("/:id/images", s.adminMiddleware(), s.uploadProductImage)
+			productRoutes.POST("/", s.RequirePermission(models.PermissionProductsWrite), s.createProduct)
+			productRoutes.PUT("/:id", s.RequirePermission(models.PermissionProductsWrite), s.updateProduct)
+			productRoutes.DELETE("/:id", s.RequirePermission(models.PermissionProductsWrite), s.deleteProduct)
+			productRoutes.POST("/:id/images", s.RequirePermission(models.PermissionProductsWrite), s.uploadProductImage)
 		}
 
 		cart := protected.Group("/cart")
@@ -125,15 +216,22 @@ func (s *Server) SetupRoutes() *gin.Engine {
 		orders := protected.Group("/orders")
 		{
 			orderRoutes := orders
-			orderRoutes.POST("/", s.createOrder)
+			orderRoutes.POST("/", s.requireUserSession(), s.createOrder)
 			orderRoutes.GET("/", s.getOrders)
 			orderRoutes.GET("/:id", s.getOrder)
 		}
 
-		api.GET("/categories", s.getCategories)
-		api.GET("/products", s.getProducts)
-		api.GET("/products/:id", s.getProduct)
-		api.GET("/search", s.searchProducts)
+		public := api.Group("/")
+		public.Use(s.rateLimit("public"))
+		{
+			publicRoutes := public
+			publicRoutes.GET("/categories", s.getCategories)
+			publicRoutes.GET("/products", s.getProducts)
+			publicRoutes.GET("/products/:id", s.getProduct)
+			publicRoutes.GET("/search", s.optionalAuthMiddleware(), s.searchProducts)
+			publicRoutes.GET("/search/suggest", s.suggestProducts)
+			publicRoutes.POST("/search/clicks", s.rateLimit("search_clicks"), s.recordSearchClick)
+		}
 	}
 	return router
 }
@@ -142,17 +240,93 @@ func (s *Server) healthCheck(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, gin.H{"status": "ok"})
 }
 
+// corsMiddleware answers cross-origin requests from the configured origins.
+// Preflight requests are always answered with 204; browsers enforce the
+// policy when the allow headers are missing.
 func (s *Server) corsMiddleware() gin.HandlerFunc {
+	cors := s.config.CORS
+	allowAnyOrigin := slices.Contains(cors.AllowedOrigins, "*")
+	allowMethods := strings.Join(cors.AllowedMethods, ", ")
+	allowHeaders := strings.Join(cors.AllowedHeaders, ", ")
+	exposeHeaders := strings.Join(cors.ExposedHeaders, ", ")
+	maxAge := strconv.Itoa(int(cors.MaxAge.Seconds()))
+
 	return func(c *gin.Context) {
-		c.Header("Access-Control-Allow-Origin", "*")
-		c.Header("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		c.Header("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		origin := c.GetHeader("Origin")
+		if !allowAnyOrigin {
+			c.Writer.Header().Add("Vary", "Origin")
+		}
+
+		if origin != "" && (allowAnyOrigin || slices.Contains(cors.AllowedOrigins, origin)) {
+			if allowAnyOrigin {
+				c.Header("Access-Control-Allow-Origin", "*")
+			} else {
+				c.Header("Access-Control-Allow-Origin", origin)
+				if cors.AllowCredentials {
+					c.Header("Access-Control-Allow-Credentials", "true")
+				}
+			}
+
+			if exposeHeaders != "" {
+				c.Header("Access-Control-Expose-Headers", exposeHeaders)
+			}
 
-		if c.Request.Method == "OPTIONS" {
-			c.AbortWithStatus(204)
+			if c.Request.Method == http.MethodOptions {
+				c.Header("Access-Control-Allow-Methods", allowMethods)
+				c.Header("Access-Control-Allow-Headers", allowHeaders)
+				c.Header("Access-Control-Max-Age", maxAge)
+			}
+		}
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusNoContent)
 			return
 		}
 
 		c.Next()
 	}
 }
+
+func (s *Server) securityHeadersMiddleware() gin.HandlerFunc {
+	security := s.config.Security
+
+	var hsts string
+	if security.HSTSMaxAge > 0 {
+		hsts = fmt.Sprintf("max-age=%d", int(security.HSTSMaxAge.Seconds()))
+		if security.HSTSIncludeSubdomains {
+			hsts += "; includeSubDomains"
+		}
+	}
+
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		if hsts != "" {
+			c.Header("Strict-Transport-Security", hsts)
+		}
+		if security.ContentSecurityPolicy != "" {
+			c.Header("Content-Security-Policy", security.ContentSecurityPolicy)
+		}
+		if security.FrameOptions != "" {
+			c.Header("X-Frame-Options", security.FrameOptions)
+		}
+		if security.ReferrerPolicy != "" {
+			c.Header("Referrer-Policy", security.ReferrerPolicy)
+		}
+
+		c.Next()
+	}
+}
+
+// docsSecurityHeadersMiddleware relaxes the content security policy for the
+// playground and documentation pages
+func (s *Server) docsSecurityHeadersMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if policy := s.config.Security.DocsContentSecurityPolicy; policy != "" {
+			c.Header("Content-Security-Policy", policy)
+		} else {
+			c.Writer.Header().Del("Content-Security-Policy")
+		}
+
+		c.Next()
+	}
+}
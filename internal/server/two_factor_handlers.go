@@ -0,0 +1,118 @@
+package server
+
+import (
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Set up two-factor authentication
+// @Description Generate a TOTP secret and otpauth URI for the current user. 2FA is enabled once a code is confirmed.
+// @Tags User
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} utils.Response{data=dto.TwoFactorSetupResponse} "Two-factor setup started"
+// @Failure 400 {object} utils.Response "Two-factor already enabled"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key"
+// @Router /users/2fa/setup [post]
+func (s *Server) setupTwoFactor(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	response, err := s.authService.SetupTwoFactor(userID)
+	if err != nil {
+		s.errorResponse(c, "Failed to set up two-factor authentication", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Two-factor setup started", response)
+}
+
+// @Summary Enable two-factor authentication
+// @Description Confirm the TOTP secret with a code and receive recovery codes
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} utils.Response{data=dto.RecoveryCodesResponse} "Two-factor authentication enabled"
+// @Failure 400 {object} utils.Response "Invalid code"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key"
+// @Router /users/2fa/enable [post]
+func (s *Server) enableTwoFactor(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	response, err := s.authService.EnableTwoFactor(c.Request.Context(), userID, &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to enable two-factor authentication", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Two-factor authentication enabled", response)
+}
+
+// @Summary Disable two-factor authentication
+// @Description Disable two-factor authentication using a TOTP or recovery code
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TwoFactorCodeRequest true "TOTP or recovery code"
+// @Success 200 {object} utils.Response "Two-factor authentication disabled"
+// @Failure 400 {object} utils.Response "Invalid code"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key"
+// @Router /users/2fa/disable [post]
+func (s *Server) disableTwoFactor(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := s.authService.DisableTwoFactor(c.Request.Context(), userID, &req); err != nil {
+		s.errorResponse(c, "Failed to disable two-factor authentication", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Two-factor authentication disabled", nil)
+}
+
+// @Summary Regenerate recovery codes
+// @Description Replace all recovery codes with a new set
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TwoFactorCodeRequest true "TOTP code"
+// @Success 200 {object} utils.Response{data=dto.RecoveryCodesResponse} "Recovery codes regenerated"
+// @Failure 400 {object} utils.Response "Invalid code"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key"
+// @Router /users/2fa/recovery-codes [post]
+func (s *Server) regenerateRecoveryCodes(c *gin.Context) {
+	userID := c.GetUint("user_id")
+
+	var req dto.TwoFactorCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	response, err := s.authService.RegenerateRecoveryCodes(userID, &req)
+	if err != nil {
+		s.errorResponse(c, "Failed to regenerate recovery codes", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Recovery codes regenerated", response)
+}
@@ -1,6 +1,12 @@
 package server
 
 import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"github.com/gin-gonic/gin"
@@ -20,7 +26,7 @@ func (s *Server) getProfile(c *gin.Context) {
 	userID := c.GetUint("user_id")
 	profile, err := s.userService.GetProfile(userID)
 	if err != nil {
-		utils.NotFoundResponse(c, "User not found")
+		s.errorResponse(c, "Failed to fetch profile", err)
 		return
 	}
 
@@ -49,8 +55,170 @@ func (s *Server) updateProfile(c *gin.Context) {
 
 	profile, err := s.userService.UpdateProfile(userID, &req)
 	if err != nil {
-		utils.InternalServerErrorResponse(c, "Failed to update profile", err)
+		s.errorResponse(c, "Failed to update profile", err)
 		return
 	}
 	utils.SuccessResponse(c, "Profile updated successfully", profile)
 }
+
+// @Summary Change password
+// @Description Change the current user's password. Every other session is revoked; pass the current refresh token to keep this session.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ChangePasswordRequest true "Current and new password"
+// @Success 200 {object} utils.Response "Password changed successfully"
+// @Failure 400 {object} utils.Response "Invalid request data or wrong password"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key"
+// @Router /users/password [put]
+func (s *Server) changePassword(c *gin.Context) {
+	var req dto.ChangePasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := s.authService.ChangePassword(c.Request.Context(), c.GetUint("user_id"), &req); err != nil {
+		s.errorResponse(c, "Failed to change password", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Password changed successfully", nil)
+}
+
+// @Summary Change email
+// @Description Send a confirmation link to a new email address. The email is only changed once the link is used.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ChangeEmailRequest true "New email and current password"
+// @Success 200 {object} utils.Response "Confirmation email sent"
+// @Failure 400 {object} utils.Response "Invalid request data, wrong password or email in use"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key"
+// @Router /users/email [post]
+func (s *Server) requestEmailChange(c *gin.Context) {
+	var req dto.ChangeEmailRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := s.authService.RequestEmailChange(c.GetUint("user_id"), &req); err != nil {
+		s.errorResponse(c, "Failed to change email", err)
+		return
+	}
+
+	utils.SuccessResponse(c, "Confirmation email sent", nil)
+}
+
+// @Summary Export personal data
+// @Description Download everything stored about the current user (profile, linked accounts, cart and order history) as JSON or as a ZIP archive
+// @Tags User
+// @Produce json
+// @Produce application/zip
+// @Security BearerAuth
+// @Param format query string false "Export format" Enums(json, zip) default(json)
+// @Success 200 {object} dto.UserDataExport "Data export"
+// @Failure 400 {object} utils.Response "Unsupported format"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key"
+// @Router /users/me/export [get]
+func (s *Server) exportUserData(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if format != "json" && format != "zip" {
+		utils.BadRequestResponse(c, "Unsupported format", nil)
+		return
+	}
+
+	userID := c.GetUint("user_id")
+	export, err := s.privacyService.ExportUserData(userID)
+	if err != nil {
+		s.errorResponse(c, "Failed to export data", err)
+		return
+	}
+
+	filename := fmt.Sprintf("account-data-%d-%s", userID, export.ExportedAt.Format("20060102"))
+
+	if format == "json" {
+		c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.json"`, filename))
+		c.IndentedJSON(http.StatusOK, export)
+		return
+	}
+
+	archive, err := buildExportArchive(export)
+	if err != nil {
+		s.errorResponse(c, "Failed to export data", err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, filename))
+	c.Data(http.StatusOK, "application/zip", archive)
+}
+
+// @Summary Delete account
+// @Description Close the current user's account. Sessions are revoked immediately; personal data is anonymised in the background and a confirmation email is sent. Orders are kept for accounting. Accounts with a password must confirm with it; social login accounts confirm with a two-factor code when 2FA is enabled, or otherwise must have signed in within the last five minutes.
+// @Tags User
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.DeleteAccountRequest true "Password, or for social login accounts a two-factor code"
+// @Success 202 {object} utils.Response "Account deletion scheduled"
+// @Failure 400 {object} utils.Response "Invalid password or two-factor code"
+// @Failure 401 {object} utils.Response "Unauthorized"
+// @Failure 403 {object} utils.Response "Not allowed while impersonating or with an API key, or recent sign-in required"
+// @Router /users/me [delete]
+func (s *Server) deleteAccount(c *gin.Context) {
+	var req dto.DeleteAccountRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequestResponse(c, "Invalid request data", err)
+		return
+	}
+
+	if err := s.privacyService.RequestAccountDeletion(c.Request.Context(), c.GetUint("user_id"), &req); err != nil {
+		s.errorResponse(c, "Failed to delete account", err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, utils.Response{
+		Success: true,
+		Message: "Account deletion scheduled",
+	})
+}
+
+func buildExportArchive(export *dto.UserDataExport) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+
+	files := []struct {
+		name string
+		data any
+	}{
+		{"profile.json", export.Profile},
+		{"linked_accounts.json", export.LinkedAccounts},
+		{"cart.json", export.Cart},
+		{"orders.json", export.Orders},
+	}
+
+	for _, file := range files {
+		w, err := zw.Create(file.name)
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(file.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,207 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+)
+
+var _ APIKeyServiceInterface = (*APIKeyService)(nil)
+
+const (
+	apiKeyPrefix    = "ek_"
+	apiKeyPrefixLen = len(apiKeyPrefix) + 8
+
+	// apiKeyTouchInterval limits how often last_used_at is written for busy keys
+	apiKeyTouchInterval = time.Minute
+)
+
+type APIKeyService struct {
+	apiKeyRepo   repository.APIKeyRepositoryInterface
+	userRepo     repository.UserRepositoryInterface
+	roleRepo     repository.RoleRepositoryInterface
+	auditService AuditServiceInterface
+}
+
+func NewAPIKeyService(
+	apiKeyRepo repository.APIKeyRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	auditService AuditServiceInterface) *APIKeyService {
+	return &APIKeyService{
+		apiKeyRepo:   apiKeyRepo,
+		userRepo:     userRepo,
+		roleRepo:     roleRepo,
+		auditService: auditService,
+	}
+}
+
+// CreateAPIKey issues a key for req.UserID, or for the creator when no user
+// is given. Keys for other users can only be issued to service accounts.
+// Scopes must be a subset of both the creator's and the owner's current
+// permissions, so a key never grants more than its creator holds.
+func (s *APIKeyService) CreateAPIKey(ctx context.Context, creatorID uint, req *dto.CreateAPIKeyRequest) (*dto.CreatedAPIKeyResponse, error) {
+	ownerID := req.UserID
+	if ownerID == 0 {
+		ownerID = creatorID
+	}
+
+	owner, err := s.userRepo.GetByID(ownerID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	if owner.ID != creatorID && !owner.IsServiceAccount {
+		return nil, apperror.Forbidden("API keys can only be created for yourself or a service account")
+	}
+
+	if !owner.IsActive {
+		return nil, apperror.InvalidInput("cannot create an API key for an inactive user")
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return nil, apperror.InvalidInput("expiry must be in the future")
+	}
+
+	permissions, err := s.roleRepo.GetPermissionNames(owner.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	creatorPermissions := permissions
+	if owner.ID != creatorID {
+		creatorPermissions, err = s.roleRepo.GetPermissionNames(creatorID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scopes := make([]string, 0, len(req.Scopes))
+	for _, scope := range req.Scopes {
+		if !slices.Contains(creatorPermissions, scope) {
+			return nil, apperror.Forbidden(fmt.Sprintf("you do not have the %q permission", scope))
+		}
+		if !slices.Contains(permissions, scope) {
+			return nil, apperror.InvalidInput(fmt.Sprintf("user does not have the %q permission", scope))
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	secret, err := utils.GenerateRandomToken(24)
+	if err != nil {
+		return nil, err
+	}
+	rawKey := apiKeyPrefix + secret
+
+	key := models.APIKey{
+		UserID:      owner.ID,
+		CreatedByID: &creatorID,
+		Name:        req.Name,
+		Prefix:      rawKey[:apiKeyPrefixLen],
+		KeyHash:     utils.HashToken(rawKey),
+		Scopes:      scopes,
+		ExpiresAt:   req.ExpiresAt,
+	}
+
+	if err := s.apiKeyRepo.Create(&key); err != nil {
+		return nil, err
+	}
+
+	response := convertToAPIKeyResponse(&key)
+	s.auditService.Record(ctx, AuditEntry{
+		ActorID:    &creatorID,
+		Action:     models.AuditActionAPIKeyCreate,
+		EntityType: models.AuditEntityAPIKey,
+		EntityID:   key.ID,
+		After:      response,
+	})
+
+	return &dto.CreatedAPIKeyResponse{
+		APIKeyResponse: response,
+		Key:            rawKey,
+	}, nil
+}
+
+func (s *APIKeyService) ListAPIKeys(userID uint) ([]dto.APIKeyResponse, error) {
+	keys, err := s.apiKeyRepo.List(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.APIKeyResponse, len(keys))
+	for i := range keys {
+		response[i] = convertToAPIKeyResponse(&keys[i])
+	}
+
+	return response, nil
+}
+
+func (s *APIKeyService) RevokeAPIKey(ctx context.Context, id uint) error {
+	key, err := s.apiKeyRepo.GetByID(id)
+	if err != nil {
+		return apperror.NotFound("API key not found")
+	}
+
+	if err := s.apiKeyRepo.Revoke(id); err != nil {
+		return err
+	}
+
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionAPIKeyRevoke,
+		EntityType: models.AuditEntityAPIKey,
+		EntityID:   id,
+		Before:     convertToAPIKeyResponse(key),
+	})
+
+	return nil
+}
+
+// Authenticate resolves a raw key to its owner, rejecting revoked and expired
+// keys as well as keys whose owner has been deactivated.
+func (s *APIKeyService) Authenticate(rawKey string) (*models.APIKey, error) {
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, apperror.Unauthorized("invalid API key")
+	}
+
+	key, err := s.apiKeyRepo.GetByHash(utils.HashToken(rawKey))
+	if err != nil {
+		return nil, apperror.Unauthorized("invalid API key")
+	}
+
+	now := time.Now()
+	if !key.IsUsable(now) || !key.User.IsActive {
+		return nil, apperror.Unauthorized("invalid API key")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) > apiKeyTouchInterval {
+		if err := s.apiKeyRepo.UpdateLastUsed(key.ID, now); err != nil {
+			return nil, err
+		}
+	}
+
+	return key, nil
+}
+
+func convertToAPIKeyResponse(key *models.APIKey) dto.APIKeyResponse {
+	return dto.APIKeyResponse{
+		ID:         key.ID,
+		UserID:     key.UserID,
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     key.Scopes,
+		ExpiresAt:  key.ExpiresAt,
+		LastUsedAt: key.LastUsedAt,
+		RevokedAt:  key.RevokedAt,
+		CreatedAt:  key.CreatedAt,
+	}
+}
@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+)
+
+var _ AuditServiceInterface = (*AuditService)(nil)
+
+// AuditEntry describes an action to record. Before and After may be any value
+// that marshals to a JSON object; when both are set only changed fields are kept.
+type AuditEntry struct {
+	ActorID    *uint
+	Action     string
+	EntityType string
+	EntityID   uint
+	Before     any
+	After      any
+}
+
+type AuditService struct {
+	auditLogRepo repository.AuditLogRepositoryInterface
+}
+
+func NewAuditService(auditLogRepo repository.AuditLogRepositoryInterface) *AuditService {
+	return &AuditService{
+		auditLogRepo: auditLogRepo,
+	}
+}
+
+// Record writes an audit log entry. The actor defaults to the authenticated
+// user in ctx, or the staff user behind an impersonation token. Failures are
+// logged rather than returned so auditing never blocks the action itself.
+func (s *AuditService) Record(ctx context.Context, entry AuditEntry) {
+	before, err := toAuditMap(entry.Before)
+	if err != nil {
+		log.Printf("Failed to encode audit state for %s: %v", entry.Action, err)
+	}
+
+	after, err := toAuditMap(entry.After)
+	if err != nil {
+		log.Printf("Failed to encode audit state for %s: %v", entry.Action, err)
+	}
+
+	if before != nil && after != nil {
+		before, after = diffAuditMaps(before, after)
+	}
+
+	actorID := entry.ActorID
+	if actorID == nil {
+		actorID = auditActorFromContext(ctx)
+	}
+
+	auditLog := &models.AuditLog{
+		ActorID:    actorID,
+		Action:     entry.Action,
+		EntityType: entry.EntityType,
+		Before:     before,
+		After:      after,
+		IPAddress:  contextString(ctx, utils.ClientIPKey),
+		UserAgent:  contextString(ctx, utils.UserAgentKey),
+		RequestID:  contextString(ctx, utils.RequestIDKey),
+	}
+	if entry.EntityID != 0 {
+		auditLog.EntityID = fmt.Sprintf("%d", entry.EntityID)
+	}
+
+	if err := s.auditLogRepo.Create(auditLog); err != nil {
+		log.Printf("Failed to write audit log for %s: %v", entry.Action, err)
+	}
+}
+
+func (s *AuditService) ListAuditLogs(req *dto.AuditLogSearchRequest) ([]dto.AuditLogResponse, *utils.PaginationMeta, error) {
+	page, limit := req.Page, req.Limit
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 20
+	}
+
+	logs, total, err := s.auditLogRepo.List(repository.AuditLogFilter{
+		ActorID:    req.ActorID,
+		Action:     req.Action,
+		EntityType: req.EntityType,
+		EntityID:   req.EntityID,
+		From:       req.From,
+		To:         req.To,
+		Offset:     (page - 1) * limit,
+		Limit:      limit,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.AuditLogResponse, len(logs))
+	for i, entry := range logs {
+		response[i] = dto.AuditLogResponse{
+			ID:         entry.ID,
+			ActorID:    entry.ActorID,
+			Action:     entry.Action,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Before:     entry.Before,
+			After:      entry.After,
+			IPAddress:  entry.IPAddress,
+			UserAgent:  entry.UserAgent,
+			RequestID:  entry.RequestID,
+			CreatedAt:  entry.CreatedAt,
+		}
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+func auditActorFromContext(ctx context.Context) *uint {
+	if impersonatorID, ok := ctx.Value(utils.ImpersonatorIDKey).(uint); ok && impersonatorID != 0 {
+		return &impersonatorID
+	}
+
+	if userID, ok := ctx.Value(utils.UserIDKey).(uint); ok && userID != 0 {
+		return &userID
+	}
+
+	return nil
+}
+
+func contextString(ctx context.Context, key utils.ContextKey) string {
+	value, _ := ctx.Value(key).(string)
+	return value
+}
+
+// toAuditMap round-trips a value through JSON so audit state is stored with
+// the same field names and redactions as API responses
+func toAuditMap(value any) (map[string]any, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	if m, ok := value.(map[string]any); ok {
+		return m, nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+
+	return m, nil
+}
+
+func diffAuditMaps(before, after map[string]any) (map[string]any, map[string]any) {
+	changedBefore := map[string]any{}
+	changedAfter := map[string]any{}
+
+	for key, oldValue := range before {
+		newValue, ok := after[key]
+		if !ok || !reflect.DeepEqual(oldValue, newValue) {
+			changedBefore[key] = oldValue
+			if ok {
+				changedAfter[key] = newValue
+			}
+		}
+	}
+
+	for key, newValue := range after {
+		if _, ok := before[key]; !ok {
+			changedAfter[key] = newValue
+		}
+	}
+
+	return changedBefore, changedAfter
+}
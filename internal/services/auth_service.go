@@ -1,44 +1,62 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"log"
+	"net"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/config"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/events"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/notifications"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"gorm.io/gorm"
 )
 
 var _ AuthServiceInterface = (*AuthService)(nil)
 
+const recoveryCodeCount = 10
+
 type AuthService struct {
-	userRepo       repository.UserRepositoryInterface
-	cartRepo       repository.CartRepositoryInterface
-	config         *config.Config
-	eventPublisher events.Publisher
+	userRepo          repository.UserRepositoryInterface
+	cartRepo          repository.CartRepositoryInterface
+	loginThrottleRepo repository.LoginThrottleRepositoryInterface
+	roleRepo          repository.RoleRepositoryInterface
+	auditService      AuditServiceInterface
+	config            *config.Config
+	eventPublisher    events.Publisher
 }
 
 func NewAuthService(
 	cfg *config.Config,
 	eventPublisher events.Publisher,
 	userRepo repository.UserRepositoryInterface,
-	cartRepo repository.CartRepositoryInterface) *AuthService {
+	cartRepo repository.CartRepositoryInterface,
+	loginThrottleRepo repository.LoginThrottleRepositoryInterface,
+	roleRepo repository.RoleRepositoryInterface,
+	auditService AuditServiceInterface) *AuthService {
 	return &AuthService{
-		config:         cfg,
-		eventPublisher: eventPublisher,
-		userRepo:       userRepo,
-		cartRepo:       cartRepo,
+		config:            cfg,
+		eventPublisher:    eventPublisher,
+		userRepo:          userRepo,
+		cartRepo:          cartRepo,
+		loginThrottleRepo: loginThrottleRepo,
+		roleRepo:          roleRepo,
+		auditService:      auditService,
 	}
 }
 
 func (a *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, error) {
 	if _, err := a.userRepo.GetByEmail(req.Email); err == nil {
-		return nil, errors.New("you cannot register with this email")
+		return nil, apperror.InvalidInput("you cannot register with this email")
 	}
 	hashedPassword, err := utils.HashPassword(req.Password)
 	if err != nil {
@@ -46,12 +64,13 @@ func (a *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 	}
 
 	user := models.User{
-		Email:     req.Email,
-		Password:  hashedPassword,
-		FirstName: req.FirstName,
-		LastName:  req.LastName,
-		Phone:     req.Phone,
-		Role:      models.UserRoleCustomer,
+		Email:       req.Email,
+		Password:    hashedPassword,
+		PasswordSet: true,
+		FirstName:   req.FirstName,
+		LastName:    req.LastName,
+		Phone:       req.Phone,
+		Role:        models.UserRoleCustomer,
 	}
 
 	if err := a.userRepo.Create(&user); err != nil {
@@ -63,36 +82,107 @@ func (a *AuthService) Register(req *dto.RegisterRequest) (*dto.AuthResponse, err
 		fmt.Println("Unable to create cart...")
 	}
 
-	return a.generateAuthResponse(&user)
+	// The account exists now, so a lost email must not fail the registration;
+	// the user can ask for another one
+	if err := a.sendVerificationEmail(&user); err != nil {
+		log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+	}
+
+	// Without a verified email the user cannot log in, so no tokens are issued yet
+	if a.config.Auth.RequireVerifiedEmailForLogin {
+		return &dto.AuthResponse{User: convertToUserResponse(&user)}, nil
+	}
+
+	return a.generateAuthResponse(&user, false, time.Now())
 }
 
-func (a *AuthService) Login(req *dto.LoginRequest) (*dto.AuthResponse, error) {
+func (a *AuthService) Login(ctx context.Context, req *dto.LoginRequest, clientIP string) (*dto.AuthResponse, error) {
+	if err := a.checkIPThrottle(clientIP); err != nil {
+		return nil, err
+	}
+
 	user, err := a.userRepo.GetByEmailAndActive(req.Email, true)
 	if err != nil {
-		return nil, errors.New("invalid credentials")
+		a.recordIPFailure(clientIP)
+		a.auditService.Record(ctx, AuditEntry{
+			Action:     models.AuditActionLoginFailed,
+			EntityType: models.AuditEntityUser,
+			After:      map[string]any{"email": req.Email, "reason": "unknown or inactive account"},
+		})
+		return nil, apperror.Unauthorized("invalid credentials")
+	}
+
+	if user.IsLocked(time.Now()) {
+		return nil, &LoginLockedError{RetryAfter: time.Until(*user.LockedUntil)}
 	}
 
 	if !utils.CheckPassword(req.Password, user.Password) {
-		return nil, errors.New("invalid credentials")
+		a.recordIPFailure(clientIP)
+		return nil, a.recordAccountFailure(ctx, user, apperror.Unauthorized("invalid credentials"))
+	}
+
+	if err := a.checkLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return a.generateTwoFactorChallenge(user)
+	}
+
+	a.resetAccountFailures(user)
+	a.recordLogin(ctx, user, "password")
+
+	return a.generateAuthResponse(user, false, time.Now())
+}
+
+// UnlockAccount clears a temporary lockout and the failed attempt counter
+func (a *AuthService) UnlockAccount(ctx context.Context, userID uint) error {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return apperror.NotFound("user not found")
 	}
 
-	return a.generateAuthResponse(user)
+	before := map[string]any{
+		"failed_login_attempts": user.FailedLoginAttempts,
+		"locked_until":          user.LockedUntil,
+	}
+
+	if err := a.userRepo.ResetLoginFailures(user.ID); err != nil {
+		return err
+	}
+
+	a.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionAccountUnlocked,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+		Before:     before,
+		After: map[string]any{
+			"failed_login_attempts": 0,
+			"locked_until":          nil,
+		},
+	})
+
+	return nil
 }
 
 func (a *AuthService) RefreshToken(req *dto.RefreshTokenRequest) (*dto.AuthResponse, error) {
 	claims, err := utils.ValidateToken(req.RefreshToken, a.config.JWT.Secret)
 	if err != nil {
-		return nil, errors.New("invalid refresh token")
+		return nil, apperror.Unauthorized("invalid refresh token")
 	}
 
 	refreshToken, err := a.userRepo.GetValidRefreshToken(req.RefreshToken)
 	if err != nil {
-		return nil, errors.New("refresh token not found or expired")
+		return nil, apperror.Unauthorized("refresh token not found or expired")
 	}
 
 	user, err := a.userRepo.GetByID(claims.UserID)
 	if err != nil {
-		return nil, errors.New("user not found")
+		return nil, apperror.NotFound("user not found")
+	}
+
+	if !user.IsActive {
+		return nil, apperror.Forbidden("account is disabled")
 	}
 
 	if err := a.userRepo.DeleteRefreshTokenByID(refreshToken.ID); err != nil {
@@ -100,19 +190,741 @@ func (a *AuthService) RefreshToken(req *dto.RefreshTokenRequest) (*dto.AuthRespo
 		_ = err
 	}
 
-	return a.generateAuthResponse(user)
+	var authTime time.Time
+	if claims.AuthTime != nil {
+		authTime = claims.AuthTime.Time
+	}
+
+	return a.generateAuthResponse(user, claims.TwoFactorVerified, authTime)
 }
 
 func (a *AuthService) Logout(refreshToken string) error {
 	return a.userRepo.DeleteRefreshToken(refreshToken)
 }
 
-func (a *AuthService) generateAuthResponse(user *models.User) (*dto.AuthResponse, error) {
+// AuthenticateToken validates an access token and checks that its user, and
+// the staff member behind an impersonation token, are still active. Disabling
+// or deleting an account therefore ends its sessions immediately rather than
+// when the access token expires.
+func (a *AuthService) AuthenticateToken(accessToken string) (*utils.Claims, error) {
+	claims, err := utils.ValidateToken(accessToken, a.config.JWT.Secret)
+	if err != nil {
+		return nil, apperror.Unauthorized("invalid token")
+	}
+
+	for _, userID := range []uint{claims.UserID, claims.ImpersonatorID} {
+		if userID == 0 {
+			continue
+		}
+
+		user, err := a.userRepo.GetByID(userID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.Unauthorized("invalid token")
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if !user.IsActive {
+			return nil, apperror.Unauthorized("account is disabled")
+		}
+	}
+
+	return claims, nil
+}
+
+// ImpersonateUser issues a short-lived access token for a customer account on
+// behalf of a staff member. Every token is recorded with the given reason.
+func (a *AuthService) ImpersonateUser(ctx context.Context, adminID, userID uint, req *dto.ImpersonateRequest, clientIP string) (*dto.ImpersonationResponse, error) {
+	if adminID == userID {
+		return nil, apperror.InvalidInput("you cannot impersonate yourself")
+	}
+
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	if !user.IsActive {
+		return nil, apperror.Forbidden("account is disabled")
+	}
+
+	permissions, err := a.roleRepo.GetPermissionNames(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(permissions) > 0 {
+		return nil, apperror.Forbidden("staff accounts cannot be impersonated")
+	}
+
+	accessToken, expiresAt, err := utils.GenerateImpersonationToken(
+		&a.config.JWT,
+		user.ID,
+		user.Email,
+		string(user.Role),
+		adminID,
+		a.config.Auth.ImpersonationTokenExpiresIn,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	impersonation := models.UserImpersonation{
+		AdminID:   adminID,
+		UserID:    user.ID,
+		Reason:    req.Reason,
+		IPAddress: clientIP,
+		ExpiresAt: expiresAt,
+	}
+
+	if err := a.userRepo.CreateImpersonation(&impersonation); err != nil {
+		return nil, err
+	}
+
+	a.auditService.Record(ctx, AuditEntry{
+		ActorID:    &adminID,
+		Action:     models.AuditActionUserImpersonate,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+		After: map[string]any{
+			"impersonation_id": impersonation.ID,
+			"reason":           req.Reason,
+			"expires_at":       expiresAt,
+		},
+	})
+
+	return &dto.ImpersonationResponse{
+		User:           convertToUserResponse(user),
+		AccessToken:    accessToken,
+		ExpiresAt:      expiresAt,
+		ImpersonatorID: adminID,
+	}, nil
+}
+
+func (a *AuthService) VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	token, err := a.userRepo.GetValidVerificationToken(utils.HashToken(req.Token), models.VerificationPurposeEmail)
+	if err != nil {
+		return nil, apperror.InvalidInput("invalid or expired verification token")
+	}
+
+	user, err := a.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	// The token is only valid for the address it was sent to
+	if user.Email != token.Email {
+		return nil, apperror.InvalidInput("invalid or expired verification token")
+	}
+
+	if !user.IsEmailVerified() {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		if err := a.userRepo.Update(user); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := a.userRepo.MarkVerificationTokenUsed(token.ID); err != nil {
+		log.Println(err)
+	}
+
+	response := convertToUserResponse(user)
+	return &response, nil
+}
+
+// ResendVerificationEmail sends a new verification email. It does not report
+// whether the email belongs to an account.
+func (a *AuthService) ResendVerificationEmail(req *dto.ResendVerificationRequest) error {
+	user, err := a.userRepo.GetByEmailAndActive(req.Email, true)
+	if err != nil || user.IsEmailVerified() {
+		return nil
+	}
+
+	return a.sendVerificationEmail(user)
+}
+
+// ChangePassword replaces the password after checking the current one and
+// revokes every other session of the user
+func (a *AuthService) ChangePassword(ctx context.Context, userID uint, req *dto.ChangePasswordRequest) error {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return apperror.NotFound("user not found")
+	}
+
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		return apperror.InvalidInput("current password is incorrect")
+	}
+
+	if req.CurrentPassword == req.NewPassword {
+		return apperror.InvalidInput("new password must be different from the current password")
+	}
+
+	hashedPassword, err := utils.HashPassword(req.NewPassword)
+	if err != nil {
+		return err
+	}
+
+	user.Password = hashedPassword
+	if err := a.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	a.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionPasswordChange,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+	})
+
+	if req.RefreshToken == "" {
+		return a.userRepo.DeleteRefreshTokensByUserID(user.ID)
+	}
+
+	return a.userRepo.DeleteOtherRefreshTokens(user.ID, req.RefreshToken)
+}
+
+// RequestEmailChange emails a confirmation link to the new address. The
+// account keeps its current email until the link is used.
+func (a *AuthService) RequestEmailChange(userID uint, req *dto.ChangeEmailRequest) error {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return apperror.NotFound("user not found")
+	}
+
+	if !utils.CheckPassword(req.CurrentPassword, user.Password) {
+		return apperror.InvalidInput("current password is incorrect")
+	}
+
+	if strings.EqualFold(req.NewEmail, user.Email) {
+		return apperror.InvalidInput("new email must be different from the current email")
+	}
+
+	if _, err := a.userRepo.GetByEmail(req.NewEmail); err == nil {
+		return apperror.Conflict("email is already in use")
+	}
+
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	// Only the latest change request is valid
+	if err := a.userRepo.DeleteVerificationTokens(user.ID, models.VerificationPurposeEmailChange); err != nil {
+		return err
+	}
+
+	changeToken := models.VerificationToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		Purpose:   models.VerificationPurposeEmailChange,
+		Email:     req.NewEmail,
+		ExpiresAt: time.Now().Add(a.config.Auth.EmailVerificationExpiresIn),
+	}
+	if err := a.userRepo.CreateVerificationToken(&changeToken); err != nil {
+		return err
+	}
+
+	err = a.eventPublisher.Publish(notifications.EmailChangeRequested, notifications.EmailChangeMessage{
+		Email:      req.NewEmail,
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		ConfirmURL: fmt.Sprintf("%s?token=%s", a.config.Auth.EmailChangeURL, url.QueryEscape(token)),
+	}, map[string]string{})
+	if err != nil {
+		return fmt.Errorf("unable to publish email change event: %w", err)
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange swaps the user's email for the address the token was
+// sent to and notifies the previous address
+func (a *AuthService) ConfirmEmailChange(ctx context.Context, req *dto.VerifyEmailRequest) (*dto.UserResponse, error) {
+	token, err := a.userRepo.GetValidVerificationToken(utils.HashToken(req.Token), models.VerificationPurposeEmailChange)
+	if err != nil {
+		return nil, apperror.InvalidInput("invalid or expired token")
+	}
+
+	user, err := a.userRepo.GetByID(token.UserID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	// The address may have been registered since the change was requested
+	if existing, err := a.userRepo.GetByEmail(token.Email); err == nil && existing.ID != user.ID {
+		return nil, apperror.Conflict("email is already in use")
+	}
+
+	previousEmail := user.Email
+	now := time.Now()
+	user.Email = token.Email
+	user.EmailVerifiedAt = &now
+	if err := a.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	if err := a.userRepo.MarkVerificationTokenUsed(token.ID); err != nil {
+		log.Println(err)
+	}
+
+	a.auditService.Record(ctx, AuditEntry{
+		ActorID:    &user.ID,
+		Action:     models.AuditActionEmailChange,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+		Before:     map[string]any{"email": previousEmail},
+		After:      map[string]any{"email": user.Email},
+	})
+
+	err = a.eventPublisher.Publish(notifications.EmailChanged, notifications.EmailChangedMessage{
+		Email:     previousEmail,
+		NewEmail:  user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+	}, map[string]string{})
+	if err != nil {
+		log.Println(err)
+	}
+
+	response := convertToUserResponse(user)
+	return &response, nil
+}
+
+func (a *AuthService) sendVerificationEmail(user *models.User) error {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	// Only the latest verification email is valid
+	if err := a.userRepo.DeleteVerificationTokens(user.ID, models.VerificationPurposeEmail); err != nil {
+		return err
+	}
+
+	verificationToken := models.VerificationToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		Purpose:   models.VerificationPurposeEmail,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(a.config.Auth.EmailVerificationExpiresIn),
+	}
+	if err := a.userRepo.CreateVerificationToken(&verificationToken); err != nil {
+		return err
+	}
+
+	err = a.eventPublisher.Publish(notifications.EmailVerificationRequested, notifications.EmailVerificationMessage{
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		VerifyURL: fmt.Sprintf("%s?token=%s", a.config.Auth.EmailVerificationURL, url.QueryEscape(token)),
+	}, map[string]string{})
+	if err != nil {
+		return fmt.Errorf("unable to publish email verification event: %w", err)
+	}
+
+	return nil
+}
+
+// VerifyTwoFactorLogin completes a login started by Login for a user with
+// two-factor authentication enabled. The code may be a TOTP or a recovery code.
+func (a *AuthService) VerifyTwoFactorLogin(ctx context.Context, req *dto.TwoFactorLoginRequest) (*dto.AuthResponse, error) {
+	challenge, err := a.userRepo.GetValidVerificationToken(utils.HashToken(req.ChallengeToken), models.VerificationPurposeTwoFactorChallenge)
+	if err != nil {
+		return nil, apperror.Unauthorized("invalid or expired challenge token")
+	}
+
+	user, err := a.userRepo.GetByID(challenge.UserID)
+	if err != nil || !user.IsActive {
+		return nil, apperror.Unauthorized("invalid credentials")
+	}
+
+	if user.IsLocked(time.Now()) {
+		return nil, &LoginLockedError{RetryAfter: time.Until(*user.LockedUntil)}
+	}
+
+	if !a.checkTwoFactorCode(user, req.Code) {
+		return nil, a.recordAccountFailure(ctx, user, apperror.Unauthorized("invalid two-factor code"))
+	}
+
+	if err := a.userRepo.MarkVerificationTokenUsed(challenge.ID); err != nil {
+		return nil, err
+	}
+
+	a.resetAccountFailures(user)
+	a.recordLogin(ctx, user, "two_factor")
+
+	return a.generateAuthResponse(user, true, time.Now())
+}
+
+// SetupTwoFactor generates a new TOTP secret. Two-factor authentication is not
+// enabled until the secret is confirmed with EnableTwoFactor.
+func (a *AuthService) SetupTwoFactor(userID uint) (*dto.TwoFactorSetupResponse, error) {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, apperror.Conflict("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	user.TwoFactorSecret = secret
+	if err := a.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	return &dto.TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPAuthURI(a.config.Auth.TOTPIssuer, user.Email, secret),
+	}, nil
+}
+
+func (a *AuthService) EnableTwoFactor(ctx context.Context, userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	if user.TwoFactorEnabled {
+		return nil, apperror.Conflict("two-factor authentication is already enabled")
+	}
+
+	if user.TwoFactorSecret == "" {
+		return nil, apperror.InvalidInput("two-factor authentication has not been set up")
+	}
+
+	if !utils.ValidateTOTP(user.TwoFactorSecret, req.Code, time.Now()) {
+		return nil, apperror.InvalidInput("invalid two-factor code")
+	}
+
+	user.TwoFactorEnabled = true
+	if err := a.userRepo.Update(user); err != nil {
+		return nil, err
+	}
+
+	a.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionTwoFactorEnable,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+	})
+
+	return a.generateRecoveryCodes(user.ID)
+}
+
+func (a *AuthService) DisableTwoFactor(ctx context.Context, userID uint, req *dto.TwoFactorCodeRequest) error {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return apperror.NotFound("user not found")
+	}
+
+	if !user.TwoFactorEnabled {
+		return apperror.InvalidInput("two-factor authentication is not enabled")
+	}
+
+	if a.config.Auth.RequireAdminTwoFactor {
+		permissions, err := a.roleRepo.GetPermissionNames(user.ID)
+		if err != nil {
+			return err
+		}
+		if len(permissions) > 0 {
+			return apperror.Forbidden("two-factor authentication is required for staff accounts")
+		}
+	}
+
+	if !a.checkTwoFactorCode(user, req.Code) {
+		return apperror.InvalidInput("invalid two-factor code")
+	}
+
+	user.TwoFactorEnabled = false
+	user.TwoFactorSecret = ""
+	if err := a.userRepo.Update(user); err != nil {
+		return err
+	}
+
+	a.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionTwoFactorDisable,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+	})
+
+	return a.userRepo.DeleteRecoveryCodes(user.ID)
+}
+
+func (a *AuthService) RegenerateRecoveryCodes(userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error) {
+	user, err := a.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	if !user.TwoFactorEnabled {
+		return nil, apperror.InvalidInput("two-factor authentication is not enabled")
+	}
+
+	if !utils.ValidateTOTP(user.TwoFactorSecret, req.Code, time.Now()) {
+		return nil, apperror.InvalidInput("invalid two-factor code")
+	}
+
+	return a.generateRecoveryCodes(user.ID)
+}
+
+// checkIPThrottle rejects logins from a locked address. Lookup failures
+// other than a missing record reject the attempt rather than skip the check.
+func (a *AuthService) checkIPThrottle(clientIP string) error {
+	key := loginThrottleKey(clientIP)
+	if key == "" {
+		return nil
+	}
+
+	throttle, err := a.loginThrottleRepo.GetByIP(key)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if throttle.LockedUntil != nil && throttle.LockedUntil.After(time.Now()) {
+		return &LoginLockedError{RetryAfter: time.Until(*throttle.LockedUntil)}
+	}
+
+	return nil
+}
+
+func (a *AuthService) recordIPFailure(clientIP string) {
+	key := loginThrottleKey(clientIP)
+	if key == "" {
+		return
+	}
+
+	now := time.Now()
+	throttle, err := a.loginThrottleRepo.RecordFailure(key, now, a.config.Auth.FailedLoginWindow)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+
+	if lockout := a.lockoutDuration(throttle.FailedAttempts, a.config.Auth.MaxFailedLoginAttemptsPerIP); lockout > 0 {
+		if err := a.loginThrottleRepo.Lock(throttle.ID, now.Add(lockout)); err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// loginThrottleKey groups IPv6 clients by /64, the smallest block usually
+// assigned to a single subscriber, so rotating addresses within it does not
+// reset the count. IPv4 addresses are used as they are.
+func loginThrottleKey(clientIP string) string {
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return clientIP
+	}
+
+	if ip.To4() != nil {
+		return ip.String()
+	}
+
+	prefix := &net.IPNet{IP: ip.Mask(net.CIDRMask(64, 128)), Mask: net.CIDRMask(64, 128)}
+	return prefix.String()
+}
+
+// recordAccountFailure counts a failed attempt against the account and locks
+// it once the limit is reached. It returns loginErr, or a LoginLockedError if
+// this attempt locked the account.
+func (a *AuthService) recordAccountFailure(ctx context.Context, user *models.User, loginErr error) error {
+	now := time.Now()
+	failure, err := a.userRepo.RecordLoginFailure(user.ID, now, a.config.Auth.FailedLoginWindow, a.loginLockouts(now))
+	if err != nil {
+		log.Println(err)
+		return loginErr
+	}
+
+	user.FailedLoginAttempts = failure.FailedLoginAttempts
+	user.LastFailedLoginAt = failure.LastFailedLoginAt
+	user.LockedUntil = failure.LockedUntil
+	lockout := a.lockoutDuration(user.FailedLoginAttempts, a.config.Auth.MaxFailedLoginAttempts)
+
+	a.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionLoginFailed,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+		After: map[string]any{
+			"reason":          loginErr.Error(),
+			"failed_attempts": user.FailedLoginAttempts,
+		},
+	})
+
+	if lockout == 0 {
+		return loginErr
+	}
+
+	a.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionAccountLocked,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+		After:      map[string]any{"locked_until": user.LockedUntil},
+	})
+
+	err = a.eventPublisher.Publish(notifications.AccountLocked, notifications.AccountLockedMessage{
+		Email:       user.Email,
+		FirstName:   user.FirstName,
+		LastName:    user.LastName,
+		LockedUntil: *user.LockedUntil,
+	}, map[string]string{})
+	if err != nil {
+		log.Println(err)
+	}
+
+	return &LoginLockedError{RetryAfter: lockout}
+}
+
+// checkLoginAllowed applies the checks every sign-in method runs once the
+// user has authenticated: the account must not be locked and, when required,
+// its email must be verified
+func (a *AuthService) checkLoginAllowed(user *models.User) error {
+	if user.IsLocked(time.Now()) {
+		return &LoginLockedError{RetryAfter: time.Until(*user.LockedUntil)}
+	}
+
+	if a.config.Auth.RequireVerifiedEmailForLogin && !user.IsEmailVerified() {
+		return apperror.Forbidden("email address has not been verified")
+	}
+
+	return nil
+}
+
+// recordLogin audits a successful sign-in. method identifies how the user
+// authenticated, e.g. "password", "two_factor" or "oidc:<provider>".
+func (a *AuthService) recordLogin(ctx context.Context, user *models.User, method string) {
+	a.auditService.Record(ctx, AuditEntry{
+		ActorID:    &user.ID,
+		Action:     models.AuditActionLogin,
+		EntityType: models.AuditEntityUser,
+		EntityID:   user.ID,
+		After:      map[string]any{"method": method},
+	})
+}
+
+func (a *AuthService) resetAccountFailures(user *models.User) {
+	if user.FailedLoginAttempts == 0 && user.LockedUntil == nil {
+		return
+	}
+
+	user.FailedLoginAttempts = 0
+	user.LastFailedLoginAt = nil
+	user.LockedUntil = nil
+	if err := a.userRepo.ResetLoginFailures(user.ID); err != nil {
+		log.Println(err)
+	}
+}
+
+// lockoutDuration doubles the lockout for every failure past the limit, up to
+// the configured maximum. It returns zero while the limit has not been reached.
+func (a *AuthService) lockoutDuration(failures, limit int) time.Duration {
+	if limit <= 0 || failures < limit {
+		return 0
+	}
+
+	lockout := a.config.Auth.LoginLockoutBase
+	for i := limit; i < failures && lockout < a.config.Auth.LoginLockoutMax; i++ {
+		lockout *= 2
+	}
+
+	return min(lockout, a.config.Auth.LoginLockoutMax)
+}
+
+// loginLockouts lists the account lockout reached at each failure count from
+// the limit onwards, ending with the first count that reaches the maximum
+func (a *AuthService) loginLockouts(now time.Time) []repository.LoginLockout {
+	limit := a.config.Auth.MaxFailedLoginAttempts
+	if limit <= 0 || a.config.Auth.LoginLockoutBase <= 0 {
+		return nil
+	}
+
+	var lockouts []repository.LoginLockout
+	for failures := limit; ; failures++ {
+		lockout := a.lockoutDuration(failures, limit)
+		lockouts = append(lockouts, repository.LoginLockout{Attempts: failures, Until: now.Add(lockout)})
+		if lockout >= a.config.Auth.LoginLockoutMax {
+			return lockouts
+		}
+	}
+}
+
+func (a *AuthService) generateTwoFactorChallenge(user *models.User) (*dto.AuthResponse, error) {
+	token, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge := models.VerificationToken{
+		UserID:    user.ID,
+		TokenHash: utils.HashToken(token),
+		Purpose:   models.VerificationPurposeTwoFactorChallenge,
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(a.config.Auth.TwoFactorChallengeExpiresIn),
+	}
+	if err := a.userRepo.CreateVerificationToken(&challenge); err != nil {
+		return nil, err
+	}
+
+	return &dto.AuthResponse{
+		User:              convertToUserResponse(user),
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+	}, nil
+}
+
+// checkTwoFactorCode accepts either a current TOTP code or an unused recovery code
+func (a *AuthService) checkTwoFactorCode(user *models.User, code string) bool {
+	if utils.ValidateTOTP(user.TwoFactorSecret, code, time.Now()) {
+		return true
+	}
+
+	return a.userRepo.UseRecoveryCode(user.ID, utils.HashToken(normalizeRecoveryCode(code))) == nil
+}
+
+func (a *AuthService) generateRecoveryCodes(userID uint) (*dto.RecoveryCodesResponse, error) {
+	plain := make([]string, recoveryCodeCount)
+	codes := make([]models.RecoveryCode, recoveryCodeCount)
+	for i := range plain {
+		code, err := utils.GenerateRandomToken(5)
+		if err != nil {
+			return nil, err
+		}
+
+		plain[i] = code[:5] + "-" + code[5:]
+		codes[i] = models.RecoveryCode{
+			UserID:   userID,
+			CodeHash: utils.HashToken(normalizeRecoveryCode(plain[i])),
+		}
+	}
+
+	if err := a.userRepo.ReplaceRecoveryCodes(userID, codes); err != nil {
+		return nil, err
+	}
+
+	return &dto.RecoveryCodesResponse{RecoveryCodes: plain}, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+}
+
+// generateAuthResponse issues tokens for a user who signed in at authTime
+func (a *AuthService) generateAuthResponse(user *models.User, twoFactorVerified bool, authTime time.Time) (*dto.AuthResponse, error) {
 	accessToken, refreshToken, err := utils.GenerateTokenPair(
 		&a.config.JWT,
 		user.ID,
 		user.Email,
 		string(user.Role),
+		twoFactorVerified,
+		authTime,
 	)
 	if err != nil {
 		return nil, err
@@ -134,17 +946,7 @@ func (a *AuthService) generateAuthResponse(user *models.User) (*dto.AuthResponse
 		return nil, fmt.Errorf("unable to publish user login event: %w", err)
 	}
 	return &dto.AuthResponse{
-		User: dto.UserResponse{
-			ID:        user.ID,
-			Email:     user.Email,
-			FirstName: user.FirstName,
-			LastName:  user.LastName,
-			Phone:     user.Phone,
-			Role:      string(user.Role),
-			IsActive:  user.IsActive,
-			CreatedAt: user.CreatedAt,
-			UpdatedAt: user.UpdatedAt,
-		},
+		User:         convertToUserResponse(user),
 		AccessToken:  accessToken,
 		RefreshToken: refreshToken,
 	}, nil
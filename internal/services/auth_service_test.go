@@ -0,0 +1,228 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/config"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/notifications"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"gorm.io/gorm"
+)
+
+func TestAuthServiceRegisterSurvivesLostVerificationEmail(t *testing.T) {
+	tests := []struct {
+		name          string
+		requireVerify bool
+		wantTokens    bool
+	}{
+		{name: "login allowed before verification", wantTokens: true},
+		{name: "login blocked until verification", requireVerify: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t, &models.User{}, &models.Cart{}, &models.RefreshToken{}, &models.VerificationToken{})
+
+			cfg := &config.Config{
+				JWT: config.JWTConfig{Secret: "test-secret", ExpiresIn: time.Hour, RefreshTokenExpiresIn: time.Hour},
+				Auth: config.AuthConfig{
+					EmailVerificationExpiresIn:   time.Hour,
+					RequireVerifiedEmailForLogin: tt.requireVerify,
+				},
+			}
+			publisher := &recordingPublisher{failing: []string{notifications.EmailVerificationRequested}}
+			service := NewAuthService(cfg, publisher, repository.NewUserRepository(db), repository.NewCartRepository(db),
+				repository.NewLoginThrottleRepository(db), repository.NewRoleRepository(db), nil)
+
+			resp, err := service.Register(&dto.RegisterRequest{
+				Email:     "ann@example.com",
+				Password:  "correct horse",
+				FirstName: "Ann",
+				LastName:  "Lee",
+			})
+			if err != nil {
+				t.Fatalf("Register: %v", err)
+			}
+
+			if gotTokens := resp.AccessToken != ""; gotTokens != tt.wantTokens {
+				t.Errorf("tokens issued = %v, want %v", gotTokens, tt.wantTokens)
+			}
+
+			var carts int64
+			db.Model(&models.Cart{}).Where("user_id = ?", resp.User.ID).Count(&carts)
+			if carts != 1 {
+				t.Errorf("%d carts, want 1", carts)
+			}
+		})
+	}
+}
+
+func TestAuthServiceAuthenticateToken(t *testing.T) {
+	tests := []struct {
+		name               string
+		change             func(db *gorm.DB) error
+		wantAccessErr      bool
+		wantImpersonateErr bool
+	}{
+		{
+			name:   "active accounts",
+			change: func(db *gorm.DB) error { return nil },
+		},
+		{
+			name: "deactivated account",
+			change: func(db *gorm.DB) error {
+				return db.Model(&models.User{ID: 2}).Update("is_active", false).Error
+			},
+			wantAccessErr:      true,
+			wantImpersonateErr: true,
+		},
+		{
+			name: "deleted account",
+			change: func(db *gorm.DB) error {
+				return db.Delete(&models.User{ID: 2}).Error
+			},
+			wantAccessErr:      true,
+			wantImpersonateErr: true,
+		},
+		{
+			name: "deactivated impersonator",
+			change: func(db *gorm.DB) error {
+				return db.Model(&models.User{ID: 1}).Update("is_active", false).Error
+			},
+			wantImpersonateErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openTestDB(t, &models.User{})
+			users := []models.User{
+				{ID: 1, Email: "staff@example.com", Password: "x", FirstName: "Sam", LastName: "Staff", IsActive: true},
+				{ID: 2, Email: "ann@example.com", Password: "x", FirstName: "Ann", LastName: "Lee", IsActive: true},
+			}
+			if err := db.Create(&users).Error; err != nil {
+				t.Fatalf("create users: %v", err)
+			}
+
+			cfg := &config.Config{JWT: config.JWTConfig{Secret: "test-secret", ExpiresIn: time.Hour, RefreshTokenExpiresIn: time.Hour}}
+			service := NewAuthService(cfg, &recordingPublisher{}, repository.NewUserRepository(db), repository.NewCartRepository(db),
+				repository.NewLoginThrottleRepository(db), repository.NewRoleRepository(db), nil)
+
+			accessToken, _, err := utils.GenerateTokenPair(&cfg.JWT, 2, "ann@example.com", "customer", false, time.Now())
+			if err != nil {
+				t.Fatalf("GenerateTokenPair: %v", err)
+			}
+
+			impersonationToken, _, err := utils.GenerateImpersonationToken(&cfg.JWT, 2, "ann@example.com", "customer", 1, time.Hour)
+			if err != nil {
+				t.Fatalf("GenerateImpersonationToken: %v", err)
+			}
+
+			if err := tt.change(db); err != nil {
+				t.Fatalf("change users: %v", err)
+			}
+
+			if _, err := service.AuthenticateToken(accessToken); (err != nil) != tt.wantAccessErr {
+				t.Errorf("access token error = %v, want error %v", err, tt.wantAccessErr)
+			}
+
+			if _, err := service.AuthenticateToken(impersonationToken); (err != nil) != tt.wantImpersonateErr {
+				t.Errorf("impersonation token error = %v, want error %v", err, tt.wantImpersonateErr)
+			}
+		})
+	}
+}
+
+func TestAuthServiceAccountLockout(t *testing.T) {
+	db := openTestDB(t, &models.User{}, &models.Cart{}, &models.RefreshToken{}, &models.LoginThrottle{}, &models.AuditLog{})
+
+	password, err := utils.HashPassword("correct horse")
+	if err != nil {
+		t.Fatalf("HashPassword: %v", err)
+	}
+
+	user := models.User{Email: "ann@example.com", Password: password, FirstName: "Ann", LastName: "Lee", IsActive: true}
+	if err := db.Create(&user).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{Secret: "test-secret", ExpiresIn: time.Hour, RefreshTokenExpiresIn: time.Hour},
+		Auth: config.AuthConfig{
+			MaxFailedLoginAttempts: 3,
+			FailedLoginWindow:      time.Hour,
+			LoginLockoutBase:       time.Minute,
+			LoginLockoutMax:        4 * time.Minute,
+		},
+	}
+	service := NewAuthService(cfg, &recordingPublisher{}, repository.NewUserRepository(db), repository.NewCartRepository(db),
+		repository.NewLoginThrottleRepository(db), repository.NewRoleRepository(db), NewAuditService(repository.NewAuditLogRepository(db)))
+
+	ctx := context.Background()
+	login := func(password string) error {
+		_, err := service.Login(ctx, &dto.LoginRequest{Email: "ann@example.com", Password: password}, "")
+		return err
+	}
+
+	// Loaded before the failures below, as a concurrent request would have
+	stale, err := repository.NewUserRepository(db).GetByID(user.ID)
+	if err != nil {
+		t.Fatalf("GetByID: %v", err)
+	}
+
+	for attempt := 1; attempt < 3; attempt++ {
+		if err := login("wrong"); err == nil || errors.As(err, new(*LoginLockedError)) {
+			t.Fatalf("attempt %d: err = %v, want invalid credentials", attempt, err)
+		}
+	}
+
+	// A failure counted from a stale copy of the account still adds to the
+	// stored count and leaves the other columns alone
+	if err := db.Model(&user).Update("first_name", "Anne").Error; err != nil {
+		t.Fatalf("rename user: %v", err)
+	}
+
+	var locked *LoginLockedError
+	if err := service.recordAccountFailure(ctx, stale, errors.New("invalid credentials")); !errors.As(err, &locked) {
+		t.Fatalf("third failure: err = %v, want the account locked", err)
+	}
+
+	if locked.RetryAfter != time.Minute {
+		t.Errorf("retry after = %v, want %v", locked.RetryAfter, time.Minute)
+	}
+
+	var stored models.User
+	if err := db.First(&stored, user.ID).Error; err != nil {
+		t.Fatalf("load user: %v", err)
+	}
+
+	if stored.FailedLoginAttempts != 3 || stored.FirstName != "Anne" || stored.LockedUntil == nil {
+		t.Errorf("stored attempts = %d, first name = %q, locked until = %v; want 3, Anne and a lockout",
+			stored.FailedLoginAttempts, stored.FirstName, stored.LockedUntil)
+	}
+
+	if err := login("correct horse"); !errors.As(err, &locked) {
+		t.Errorf("login while locked: err = %v, want the account locked", err)
+	}
+
+	// Later failures double the lockout up to the maximum
+	for _, want := range []time.Duration{2 * time.Minute, 4 * time.Minute, 4 * time.Minute} {
+		if err := service.recordAccountFailure(ctx, &stored, errors.New("invalid credentials")); !errors.As(err, &locked) || locked.RetryAfter != want {
+			t.Errorf("err = %v, want the account locked for %v", err, want)
+		}
+	}
+
+	if err := service.UnlockAccount(ctx, user.ID); err != nil {
+		t.Fatalf("UnlockAccount: %v", err)
+	}
+
+	if err := login("correct horse"); err != nil {
+		t.Errorf("login after unlock: %v", err)
+	}
+}
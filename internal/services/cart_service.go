@@ -1,9 +1,12 @@
 package services
 
 import (
-	"errors"
+	"context"
+	"log"
 
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
 	"gorm.io/gorm"
 )
@@ -11,11 +14,12 @@ import (
 var _ CartServiceInterface = (*CartService)(nil)
 
 type CartService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	pubSub interfaces.PubSub
 }
 
-func NewCartService(db *gorm.DB) *CartService {
-	return &CartService{db: db}
+func NewCartService(db *gorm.DB, pubSub interfaces.PubSub) *CartService {
+	return &CartService{db: db, pubSub: pubSub}
 }
 
 func (s *CartService) GetCart(userID uint) (*dto.CartResponse, error) {
@@ -34,11 +38,11 @@ func (s *CartService) AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.Ca
 	// Check if product exists
 	var product models.Product
 	if err := s.db.First(&product, req.ProductID).Error; err != nil {
-		return nil, errors.New("product not found")
+		return nil, apperror.NotFound("product not found")
 	}
 
 	if product.Stock < req.Quantity {
-		return nil, errors.New("insufficient stock")
+		return nil, apperror.OutOfStock("insufficient stock")
 	}
 
 	// Get or create cart
@@ -64,11 +68,13 @@ func (s *CartService) AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.Ca
 		// cartItem available - update existing cart item
 		cartItem.Quantity += req.Quantity
 		if cartItem.Quantity > product.Stock {
-			return nil, errors.New("insufficient stock")
+			return nil, apperror.OutOfStock("insufficient stock")
 		}
 		s.db.Save(&cartItem)
 	}
 
+	publish(s.pubSub, cartTopic(userID), nil)
+
 	return s.GetCart(userID)
 }
 
@@ -77,16 +83,16 @@ func (s *CartService) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartIte
 	if err := s.db.Joins("JOIN carts ON cart_items.cart_id = carts.id").
 		Where("cart_items.id = ? AND carts.user_id = ?", itemID, userID).
 		First(&cartItem).Error; err != nil {
-		return nil, errors.New("cart item not found")
+		return nil, apperror.NotFound("cart item not found")
 	}
 
 	var product models.Product
 	if err := s.db.First(&product, cartItem.ProductID).Error; err != nil {
-		return nil, errors.New("product not found")
+		return nil, apperror.NotFound("product not found")
 	}
 
 	if product.Stock < req.Quantity {
-		return nil, errors.New("insufficient stock")
+		return nil, apperror.OutOfStock("insufficient stock")
 	}
 
 	cartItem.Quantity = req.Quantity
@@ -94,14 +100,54 @@ func (s *CartService) UpdateCartItem(userID, itemID uint, req *dto.UpdateCartIte
 		return nil, err
 	}
 
+	publish(s.pubSub, cartTopic(userID), nil)
+
 	return s.GetCart(userID)
 }
 
 func (s *CartService) RemoveFromCart(userID, itemID uint) error {
-	return s.db.Where("id = ? AND cart_id IN (?)", itemID,
+	result := s.db.Where("id = ? AND cart_id IN (?)", itemID,
 		s.db.Select("id").Table("carts").
 			Where("user_id = ?", userID)).
-		Delete(&models.CartItem{}).Error
+		Delete(&models.CartItem{})
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		publish(s.pubSub, cartTopic(userID), nil)
+	}
+
+	return nil
+}
+
+// WatchCart streams the user's cart each time it changes, until ctx is done
+func (s *CartService) WatchCart(ctx context.Context, userID uint) (<-chan *dto.CartResponse, error) {
+	events, err := s.pubSub.Subscribe(ctx, cartTopic(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	carts := make(chan *dto.CartResponse, 1)
+	go func() {
+		defer close(carts)
+
+		for range events {
+			cart, err := s.GetCart(userID)
+			if err != nil {
+				log.Printf("Failed to load cart for user %d: %v", userID, err)
+				continue
+			}
+
+			select {
+			case carts <- cart:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return carts, nil
 }
 
 func (s *CartService) convertToCartResponse(cart *models.Cart) *dto.CartResponse {
@@ -0,0 +1,23 @@
+package services
+
+import (
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+)
+
+var errLoginLocked = apperror.TooManyRequests("too many failed login attempts, try again later")
+
+// LoginLockedError is returned when login is blocked after too many failed
+// attempts from an account or IP address.
+type LoginLockedError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LoginLockedError) Error() string {
+	return errLoginLocked.Message
+}
+
+func (e *LoginLockedError) Unwrap() error {
+	return errLoginLocked
+}
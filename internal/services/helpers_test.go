@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// openTestDB returns an in-memory database, private to the test, with tables
+// for the given models
+func openTestDB(t *testing.T, models ...any) *gorm.DB {
+	t.Helper()
+
+	db, err := gorm.Open(sqlite.Open(fmt.Sprintf("file:%s?mode=memory&cache=shared", t.Name())), &gorm.Config{
+		Logger: logger.Default.LogMode(logger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open database: %v", err)
+	}
+
+	if err := db.AutoMigrate(models...); err != nil {
+		t.Fatalf("migrate database: %v", err)
+	}
+
+	return db
+}
+
+// recordingPublisher records published event types, failing those listed in
+// failing
+type recordingPublisher struct {
+	events  []string
+	failing []string
+}
+
+func (p *recordingPublisher) Publish(eventType string, payload interface{}, metadata map[string]string) error {
+	if slices.Contains(p.failing, eventType) {
+		return errors.New("broker unavailable")
+	}
+
+	p.events = append(p.events, eventType)
+	return nil
+}
+
+func (p *recordingPublisher) Close() error {
+	return nil
+}
@@ -1,38 +1,103 @@
 package services
 
 import (
-	"mime/multipart"
+	"context"
+	"io"
 
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 )
 
 type AuthServiceInterface interface {
 	Register(req *dto.RegisterRequest) (*dto.AuthResponse, error)
-	Login(req *dto.LoginRequest) (*dto.AuthResponse, error)
+	Login(ctx context.Context, req *dto.LoginRequest, clientIP string) (*dto.AuthResponse, error)
 	RefreshToken(req *dto.RefreshTokenRequest) (*dto.AuthResponse, error)
 	Logout(refreshToken string) error
+	AuthenticateToken(accessToken string) (*utils.Claims, error)
+	UnlockAccount(ctx context.Context, userID uint) error
+	ImpersonateUser(ctx context.Context, adminID, userID uint, req *dto.ImpersonateRequest, clientIP string) (*dto.ImpersonationResponse, error)
+	VerifyEmail(req *dto.VerifyEmailRequest) (*dto.UserResponse, error)
+	ResendVerificationEmail(req *dto.ResendVerificationRequest) error
+	ChangePassword(ctx context.Context, userID uint, req *dto.ChangePasswordRequest) error
+	RequestEmailChange(userID uint, req *dto.ChangeEmailRequest) error
+	ConfirmEmailChange(ctx context.Context, req *dto.VerifyEmailRequest) (*dto.UserResponse, error)
+
+	VerifyTwoFactorLogin(ctx context.Context, req *dto.TwoFactorLoginRequest) (*dto.AuthResponse, error)
+	SetupTwoFactor(userID uint) (*dto.TwoFactorSetupResponse, error)
+	EnableTwoFactor(ctx context.Context, userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+	DisableTwoFactor(ctx context.Context, userID uint, req *dto.TwoFactorCodeRequest) error
+	RegenerateRecoveryCodes(userID uint, req *dto.TwoFactorCodeRequest) (*dto.RecoveryCodesResponse, error)
+}
+
+type AuditServiceInterface interface {
+	Record(ctx context.Context, entry AuditEntry)
+	ListAuditLogs(req *dto.AuditLogSearchRequest) ([]dto.AuditLogResponse, *utils.PaginationMeta, error)
+}
+
+type OIDCServiceInterface interface {
+	Providers() []string
+	BeginLogin(ctx context.Context, provider string) (authURL, flowState string, err error)
+	CompleteLogin(ctx context.Context, req *dto.OIDCCallbackRequest) (*dto.AuthResponse, error)
+}
+
+type RBACServiceInterface interface {
+	GetPermissions(userID uint) ([]string, error)
+	HasPermission(userID uint, permission string) (bool, error)
+	GetRoles() ([]dto.RoleResponse, error)
+	GetUserRoles(userID uint) ([]dto.RoleResponse, error)
+	AssignRole(ctx context.Context, actorID, userID uint, req *dto.AssignRoleRequest) ([]dto.RoleResponse, error)
+	RemoveRole(ctx context.Context, actorID, userID uint, roleName string) ([]dto.RoleResponse, error)
+	SetUserRoles(ctx context.Context, actorID, userID uint, req *dto.SetUserRolesRequest) ([]dto.RoleResponse, error)
+}
+
+type APIKeyServiceInterface interface {
+	CreateAPIKey(ctx context.Context, creatorID uint, req *dto.CreateAPIKeyRequest) (*dto.CreatedAPIKeyResponse, error)
+	ListAPIKeys(userID uint) ([]dto.APIKeyResponse, error)
+	RevokeAPIKey(ctx context.Context, id uint) error
+	Authenticate(rawKey string) (*models.APIKey, error)
 }
 
 type UserServiceInterface interface {
 	GetProfile(userID uint) (*dto.UserResponse, error)
 	UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.UserResponse, error)
+
+	SearchUsers(req *dto.AdminUserSearchRequest) ([]dto.AdminUserResponse, *utils.PaginationMeta, error)
+	GetUser(id uint) (*dto.AdminUserResponse, error)
+	GetUsersByIDs(ids []uint) ([]dto.UserResponse, error)
+	SetUserActive(ctx context.Context, actorID, id uint, req *dto.UpdateUserStatusRequest) (*dto.AdminUserResponse, error)
+	SetServiceAccount(ctx context.Context, actorID, id uint, req *dto.UpdateServiceAccountRequest) (*dto.AdminUserResponse, error)
+}
+
+type PrivacyServiceInterface interface {
+	ExportUserData(userID uint) (*dto.UserDataExport, error)
+	RequestAccountDeletion(ctx context.Context, userID uint, req *dto.DeleteAccountRequest) error
 }
 
 type ProductServiceInterface interface {
-	CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
+	CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error)
 	GetCategories() ([]dto.CategoryResponse, error)
-	UpdateCategory(id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
-	DeleteCategory(id uint) error
+	GetCategory(id uint) (*dto.CategoryResponse, error)
+	UpdateCategory(ctx context.Context, id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error)
+	DeleteCategory(ctx context.Context, id uint) error
 
-	CreateProduct(req *dto.CreateProductRequest) (*dto.ProductResponse, error)
-	GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error)
+	CreateProduct(ctx context.Context, req *dto.CreateProductRequest) (*dto.ProductResponse, error)
+	GetProducts(req *dto.ProductListRequest) ([]dto.ProductResponse, *utils.PaginationMeta, error)
+	ListProducts(req *dto.ProductListRequest) ([]dto.ProductResponse, *utils.PaginationMeta, error)
+	GetProductsByCursor(req *dto.ProductListRequest, page *dto.CursorPageRequest) ([]dto.ProductResponse, *utils.CursorMeta, error)
+	ListProductsByCursor(req *dto.ProductListRequest, page *dto.CursorPageRequest) ([]dto.ProductResponse, *utils.CursorMeta, error)
 	GetProduct(id uint) (*dto.ProductResponse, error)
-	UpdateProduct(id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error)
-	DeleteProduct(id uint) error
+	FindProduct(id uint) (*dto.ProductResponse, error)
+	GetProductsByIDs(ids []uint) ([]dto.ProductResponse, error)
+	GetCategoriesByIDs(ids []uint) ([]dto.CategoryResponse, error)
+	GetImagesByProductIDs(productIDs []uint) (map[uint][]dto.ProductImageResponse, error)
+	UpdateProduct(ctx context.Context, id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error)
+	DeleteProduct(ctx context.Context, id uint) error
 
-	AddProductImage(productID uint, url, altText string) error
-	SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error)
+	AddProductImage(ctx context.Context, productID uint, url, altText string) error
+	SearchProducts(ctx context.Context, req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error)
+	SearchFacets(ctx context.Context, req *dto.SearchProductsRequest) (*dto.SearchFacets, error)
+	SuggestProducts(ctx context.Context, req *dto.SearchSuggestRequest) ([]dto.SearchSuggestion, error)
 }
 
 type CartServiceInterface interface {
@@ -40,14 +105,46 @@ type CartServiceInterface interface {
 	AddToCart(userID uint, req *dto.AddToCartRequest) (*dto.CartResponse, error)
 	UpdateCartItem(userID, itemID uint, req *dto.UpdateCartItemRequest) (*dto.CartResponse, error)
 	RemoveFromCart(userID, itemID uint) error
+	WatchCart(ctx context.Context, userID uint) (<-chan *dto.CartResponse, error)
 }
 
 type OrderServiceInterface interface {
 	CreateOrder(userID uint) (*dto.OrderResponse, error)
 	GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error)
+	ListOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error)
+	GetOrdersByCursor(userID uint, req *dto.CursorPageRequest) ([]dto.OrderResponse, *utils.CursorMeta, error)
+	ListOrdersByCursor(userID uint, req *dto.CursorPageRequest) ([]dto.OrderResponse, *utils.CursorMeta, error)
 	GetOrder(userID, orderID uint) (*dto.OrderResponse, error)
+	FindOrder(userID, orderID uint) (*dto.OrderResponse, error)
+	GetAllOrders(req *dto.AdminOrderListRequest) ([]dto.OrderResponse, *utils.PaginationMeta, error)
+	ListAllOrders(req *dto.AdminOrderListRequest) ([]dto.OrderResponse, *utils.PaginationMeta, error)
+	GetAnyOrder(orderID uint) (*dto.OrderResponse, error)
+	FindAnyOrder(orderID uint) (*dto.OrderResponse, error)
+	UpdateOrderStatus(orderID uint, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error)
+	WatchOrder(ctx context.Context, userID, orderID uint) (<-chan *dto.OrderResponse, error)
+}
+
+type SearchAnalyticsServiceInterface interface {
+	RecordSearch(ctx context.Context, query string, resultCount int64, productIDs []uint) uint
+	RecordResults(searchID uint, query string, productIDs []uint) uint
+	RecordEvent(searchID, productID uint, eventType string) error
+	GetSearchReport(req *dto.SearchReportRequest) (*dto.SearchReport, error)
+}
+
+type SearchTuningServiceInterface interface {
+	ListSynonymSets() ([]dto.SynonymSetResponse, error)
+	CreateSynonymSet(ctx context.Context, req *dto.SynonymSetRequest) (*dto.SynonymSetResponse, error)
+	UpdateSynonymSet(ctx context.Context, id uint, req *dto.SynonymSetRequest) (*dto.SynonymSetResponse, error)
+	DeleteSynonymSet(ctx context.Context, id uint) error
+
+	ListProductBoosts() ([]dto.ProductBoostResponse, error)
+	SetProductBoost(ctx context.Context, productID uint, req *dto.ProductBoostRequest) (*dto.ProductBoostResponse, error)
+
+	ListSearchPins(req *dto.SearchPinListRequest) ([]dto.SearchPinResponse, error)
+	CreateSearchPin(ctx context.Context, req *dto.SearchPinRequest) (*dto.SearchPinResponse, error)
+	DeleteSearchPin(ctx context.Context, id uint) error
 }
 
 type UploadServiceInterface interface {
-	UploadProductImage(productID uint, file *multipart.FileHeader) (string, error)
+	UploadProductImage(productID uint, filename string, file io.Reader) (string, error)
 }
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/auth/oidc"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/config"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+)
+
+var _ OIDCServiceInterface = (*OIDCService)(nil)
+
+const oidcFlowTTL = 10 * time.Minute
+
+type OIDCService struct {
+	providers   map[string]*oidc.Client
+	authService *AuthService
+	userRepo    repository.UserRepositoryInterface
+	cartRepo    repository.CartRepositoryInterface
+	stateSecret []byte
+}
+
+func NewOIDCService(
+	cfg *config.Config,
+	providers []*oidc.Client,
+	authService *AuthService,
+	userRepo repository.UserRepositoryInterface,
+	cartRepo repository.CartRepositoryInterface) *OIDCService {
+	byName := make(map[string]*oidc.Client, len(providers))
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+
+	// Flow state tokens use their own key so they can never pass as access tokens
+	stateSecret := sha256.Sum256([]byte("oidc_flow_state:" + cfg.JWT.Secret))
+
+	return &OIDCService{
+		providers:   byName,
+		authService: authService,
+		userRepo:    userRepo,
+		cartRepo:    cartRepo,
+		stateSecret: stateSecret[:],
+	}
+}
+
+func (s *OIDCService) Providers() []string {
+	names := make([]string, 0, len(s.providers))
+	for name := range s.providers {
+		names = append(names, name)
+	}
+	return names
+}
+
+// BeginLogin returns the provider authorization URL and a signed flow state
+// that must be presented again on the callback.
+func (s *OIDCService) BeginLogin(ctx context.Context, provider string) (authURL, flowState string, err error) {
+	client, ok := s.providers[provider]
+	if !ok {
+		return "", "", oidc.ErrUnknownProvider
+	}
+
+	state, err := oidc.GenerateState()
+	if err != nil {
+		return "", "", err
+	}
+
+	verifier, err := oidc.GenerateCodeVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	nonce, err := oidc.GenerateNonce()
+	if err != nil {
+		return "", "", err
+	}
+
+	authURL, err = client.AuthCodeURL(ctx, state, oidc.CodeChallengeS256(verifier), nonce)
+	if err != nil {
+		return "", "", err
+	}
+
+	flowState, err = oidc.SignFlowState(s.stateSecret, oidc.FlowState{
+		Provider:     provider,
+		State:        state,
+		CodeVerifier: verifier,
+		Nonce:        nonce,
+	}, oidcFlowTTL)
+	if err != nil {
+		return "", "", err
+	}
+
+	return authURL, flowState, nil
+}
+
+// CompleteLogin exchanges the authorization code, links the external identity
+// to a user and issues the normal token pair. OpenID Connect providers must
+// return a valid ID token for the same subject as the userinfo response. The
+// account lockout and verified email requirement apply as for password login.
+func (s *OIDCService) CompleteLogin(ctx context.Context, req *dto.OIDCCallbackRequest) (*dto.AuthResponse, error) {
+	client, ok := s.providers[req.Provider]
+	if !ok {
+		return nil, oidc.ErrUnknownProvider
+	}
+
+	flow, err := oidc.ParseFlowState(s.stateSecret, req.FlowState)
+	if err != nil || flow.Provider != req.Provider || flow.State != req.State {
+		return nil, apperror.Unauthorized("invalid or expired login state")
+	}
+
+	token, err := client.Exchange(ctx, req.Code, flow.CodeVerifier)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeUnauthorized, "identity provider rejected the login", err)
+	}
+
+	var idToken *oidc.IDTokenClaims
+	if client.IsOpenID() {
+		idToken, err = client.VerifyIDToken(ctx, token.IDToken, flow.Nonce)
+		if err != nil {
+			return nil, apperror.Wrap(apperror.CodeUnauthorized, "identity provider rejected the login", err)
+		}
+	}
+
+	info, err := client.UserInfo(ctx, token.AccessToken)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.CodeUnauthorized, "identity provider rejected the login", err)
+	}
+
+	if idToken != nil && info.Subject != idToken.Subject {
+		return nil, apperror.Unauthorized("identity provider returned mismatched identities")
+	}
+
+	user, err := s.resolveUser(req.Provider, info)
+	if err != nil {
+		return nil, err
+	}
+
+	if !user.IsActive {
+		return nil, apperror.Forbidden("account is disabled")
+	}
+
+	if err := s.authService.checkLoginAllowed(user); err != nil {
+		return nil, err
+	}
+
+	if user.TwoFactorEnabled {
+		return s.authService.generateTwoFactorChallenge(user)
+	}
+
+	s.authService.recordLogin(ctx, user, "oidc:"+req.Provider)
+
+	return s.authService.generateAuthResponse(user, false, time.Now())
+}
+
+// resolveUser finds the user linked to the identity. Unlinked identities are
+// linked to an existing account with the same email only when the provider
+// has verified that email; otherwise a new account is created.
+func (s *OIDCService) resolveUser(provider string, info *oidc.UserInfo) (*models.User, error) {
+	if identity, err := s.userRepo.GetIdentity(provider, info.Subject); err == nil {
+		return s.userRepo.GetByID(identity.UserID)
+	}
+
+	if info.Email == "" {
+		return nil, apperror.Unauthorized("identity provider did not return an email address")
+	}
+
+	user, err := s.userRepo.GetByEmail(info.Email)
+	if err == nil {
+		if !info.EmailVerified {
+			return nil, apperror.Conflict("an account with this email already exists")
+		}
+
+		if !user.IsEmailVerified() {
+			now := time.Now()
+			user.EmailVerifiedAt = &now
+			if err := s.userRepo.Update(user); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		user, err = s.createUser(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	identity := models.UserIdentity{
+		UserID:   user.ID,
+		Provider: provider,
+		Subject:  info.Subject,
+		Email:    info.Email,
+	}
+	if err := s.userRepo.CreateIdentity(&identity); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *OIDCService) createUser(info *oidc.UserInfo) (*models.User, error) {
+	// Social accounts have no usable password until the user sets one
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName, lastName := info.GivenName, info.FamilyName
+	if firstName == "" && lastName == "" {
+		firstName, lastName, _ = strings.Cut(info.Name, " ")
+	}
+
+	user := models.User{
+		Email:     info.Email,
+		Password:  hashedPassword,
+		FirstName: firstName,
+		LastName:  lastName,
+		Role:      models.UserRoleCustomer,
+	}
+
+	if info.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+
+	if err := s.userRepo.Create(&user); err != nil {
+		return nil, err
+	}
+
+	cart := models.Cart{UserID: user.ID}
+	if err := s.cartRepo.Create(&cart); err != nil {
+		return nil, err
+	}
+
+	// As in Register, a lost email can be resent and must not fail the login
+	if !info.EmailVerified {
+		if err := s.authService.sendVerificationEmail(&user); err != nil {
+			log.Printf("Failed to send verification email to user %d: %v", user.ID, err)
+		}
+	}
+
+	return &user, nil
+}
@@ -0,0 +1,606 @@
+package services
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/auth/oidc"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/config"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"gorm.io/gorm"
+)
+
+const (
+	stubClientID     = "ecommerce"
+	stubClientSecret = "secret"
+	stubRedirectURL  = "http://localhost/api/v1/auth/oidc/stub/callback"
+)
+
+type stubIdentity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+type stubAuthorization struct {
+	identity  stubIdentity
+	challenge string
+	nonce     string
+}
+
+// stubOIDCProvider is an OpenID Connect provider serving discovery, token,
+// userinfo and key set endpoints. Logins are authorized by calling authorize
+// with the URL the user would have been redirected to.
+type stubOIDCProvider struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	// idToken, when set, may alter the claims or signing key of issued ID
+	// tokens, or return nil to leave the ID token out
+	idToken func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims
+
+	mu             sync.Mutex
+	codes          map[string]stubAuthorization
+	accessTokens   map[string]stubIdentity
+	tokenExchanges int
+}
+
+func newStubOIDCProvider(t *testing.T) *stubOIDCProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("generate key: %v", err)
+	}
+
+	p := &stubOIDCProvider{
+		t:            t,
+		key:          key,
+		codes:        make(map[string]stubAuthorization),
+		accessTokens: make(map[string]stubIdentity),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("POST /token", p.token)
+	mux.HandleFunc("GET /userinfo", p.userInfo)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+// authorize checks the authorization request and returns the code the
+// provider would redirect back with
+func (p *stubOIDCProvider) authorize(authURL string, identity stubIdentity) string {
+	p.t.Helper()
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		p.t.Fatalf("parse auth URL: %v", err)
+	}
+
+	if !strings.HasPrefix(authURL, p.server.URL+"/authorize?") {
+		p.t.Fatalf("auth URL %s is not the provider's authorization endpoint", authURL)
+	}
+
+	query := parsed.Query()
+	for name, want := range map[string]string{
+		"response_type":         "code",
+		"client_id":             stubClientID,
+		"redirect_uri":          stubRedirectURL,
+		"code_challenge_method": "S256",
+	} {
+		if got := query.Get(name); got != want {
+			p.t.Fatalf("auth URL %s = %q, want %q", name, got, want)
+		}
+	}
+
+	if query.Get("state") == "" || query.Get("code_challenge") == "" || query.Get("nonce") == "" {
+		p.t.Fatalf("auth URL %s lacks state, code challenge or nonce", authURL)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	code := "code-" + query.Get("state")
+	p.codes[code] = stubAuthorization{
+		identity:  identity,
+		challenge: query.Get("code_challenge"),
+		nonce:     query.Get("nonce"),
+	}
+
+	return code
+}
+
+func (p *stubOIDCProvider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.server.URL,
+		"authorization_endpoint": p.server.URL + "/authorize",
+		"token_endpoint":         p.server.URL + "/token",
+		"userinfo_endpoint":      p.server.URL + "/userinfo",
+		"jwks_uri":               p.server.URL + "/jwks",
+	})
+}
+
+func (p *stubOIDCProvider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeStubJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	if r.PostForm.Get("client_id") != stubClientID || r.PostForm.Get("client_secret") != stubClientSecret {
+		writeStubJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.tokenExchanges++
+
+	// Codes are single use, and only the holder of the PKCE verifier may
+	// redeem them
+	authorization, ok := p.codes[r.PostForm.Get("code")]
+	delete(p.codes, r.PostForm.Get("code"))
+	if !ok || r.PostForm.Get("redirect_uri") != stubRedirectURL ||
+		oidc.CodeChallengeS256(r.PostForm.Get("code_verifier")) != authorization.challenge {
+		writeStubJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	accessToken := "access-" + r.PostForm.Get("code")
+	p.accessTokens[accessToken] = authorization.identity
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":   p.server.URL,
+		"aud":   stubClientID,
+		"sub":   authorization.identity.Subject,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": authorization.nonce,
+	}
+	key := p.key
+	if p.idToken != nil {
+		claims = p.idToken(claims, &key)
+	}
+
+	response := map[string]any{
+		"access_token": accessToken,
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+	}
+
+	if claims != nil {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "stub"
+		idToken, err := token.SignedString(key)
+		if err != nil {
+			p.t.Errorf("sign id token: %v", err)
+		}
+		response["id_token"] = idToken
+	}
+
+	writeStubJSON(w, http.StatusOK, response)
+}
+
+func (p *stubOIDCProvider) userInfo(w http.ResponseWriter, r *http.Request) {
+	p.mu.Lock()
+	identity, ok := p.accessTokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+	p.mu.Unlock()
+
+	if !ok {
+		writeStubJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+
+	writeStubJSON(w, http.StatusOK, map[string]any{
+		"sub":            identity.Subject,
+		"email":          identity.Email,
+		"email_verified": identity.EmailVerified,
+		"name":           identity.Name,
+	})
+}
+
+func (p *stubOIDCProvider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeStubJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": "stub",
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func writeStubJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+func newTestOIDCService(t *testing.T) (*OIDCService, *stubOIDCProvider, *gorm.DB) {
+	t.Helper()
+
+	db := openTestDB(t,
+		&models.User{}, &models.UserIdentity{}, &models.Cart{}, &models.RefreshToken{},
+		&models.VerificationToken{}, &models.AuditLog{},
+	)
+
+	cfg := &config.Config{
+		JWT: config.JWTConfig{
+			Secret:                "test-secret",
+			ExpiresIn:             time.Hour,
+			RefreshTokenExpiresIn: 24 * time.Hour,
+		},
+		Auth: config.AuthConfig{
+			EmailVerificationURL:        "http://localhost/verify",
+			EmailVerificationExpiresIn:  time.Hour,
+			TwoFactorChallengeExpiresIn: time.Minute,
+		},
+	}
+
+	userRepo := repository.NewUserRepository(db)
+	cartRepo := repository.NewCartRepository(db)
+	authService := NewAuthService(cfg, &recordingPublisher{}, userRepo, cartRepo,
+		repository.NewLoginThrottleRepository(db), repository.NewRoleRepository(db),
+		NewAuditService(repository.NewAuditLogRepository(db)))
+
+	provider := newStubOIDCProvider(t)
+	client := oidc.NewClient(oidc.ProviderConfig{
+		Name:         "stub",
+		IssuerURL:    provider.server.URL,
+		ClientID:     stubClientID,
+		ClientSecret: stubClientSecret,
+		RedirectURL:  stubRedirectURL,
+	}, provider.server.Client())
+
+	return NewOIDCService(cfg, []*oidc.Client{client}, authService, userRepo, cartRepo), provider, db
+}
+
+// beginStubLogin starts a login and has the provider authorize it, returning
+// the callback request the browser would make
+func beginStubLogin(t *testing.T, service *OIDCService, provider *stubOIDCProvider, identity stubIdentity) *dto.OIDCCallbackRequest {
+	t.Helper()
+
+	authURL, flowState, err := service.BeginLogin(context.Background(), "stub")
+	if err != nil {
+		t.Fatalf("BeginLogin: %v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("parse auth URL: %v", err)
+	}
+
+	return &dto.OIDCCallbackRequest{
+		Provider:  "stub",
+		Code:      provider.authorize(authURL, identity),
+		State:     parsed.Query().Get("state"),
+		FlowState: flowState,
+	}
+}
+
+func TestOIDCServiceLogin(t *testing.T) {
+	service, provider, db := newTestOIDCService(t)
+	ctx := context.Background()
+
+	existing := models.User{Email: "ann@example.com", Password: "hash", PasswordSet: true, FirstName: "Ann", Role: models.UserRoleCustomer}
+	if err := db.Create(&existing).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		identity  stubIdentity
+		wantUser  func(*dto.AuthResponse) bool
+		wantUsers int64
+	}{
+		{
+			name:      "new identity creates an account",
+			identity:  stubIdentity{Subject: "bob-1", Email: "bob@example.com", EmailVerified: true, Name: "Bob Stone"},
+			wantUser:  func(r *dto.AuthResponse) bool { return r.User.Email == "bob@example.com" && r.User.LastName == "Stone" },
+			wantUsers: 2,
+		},
+		{
+			name:      "returning identity signs in to the same account",
+			identity:  stubIdentity{Subject: "bob-1", Email: "bob@example.com", EmailVerified: true},
+			wantUser:  func(r *dto.AuthResponse) bool { return r.User.Email == "bob@example.com" },
+			wantUsers: 2,
+		},
+		{
+			name:      "verified email links the existing account",
+			identity:  stubIdentity{Subject: "ann-1", Email: "ann@example.com", EmailVerified: true},
+			wantUser:  func(r *dto.AuthResponse) bool { return r.User.ID == existing.ID && r.User.EmailVerifiedAt != nil },
+			wantUsers: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := service.CompleteLogin(ctx, beginStubLogin(t, service, provider, tt.identity))
+			if err != nil {
+				t.Fatalf("CompleteLogin: %v", err)
+			}
+
+			if resp.AccessToken == "" || resp.RefreshToken == "" {
+				t.Errorf("response %+v lacks tokens", resp)
+			}
+
+			if !tt.wantUser(resp) {
+				t.Errorf("signed in as %+v", resp.User)
+			}
+
+			var identity models.UserIdentity
+			if err := db.Where("provider = ? AND subject = ?", "stub", tt.identity.Subject).First(&identity).Error; err != nil {
+				t.Fatalf("identity not linked: %v", err)
+			}
+
+			if identity.UserID != resp.User.ID {
+				t.Errorf("identity linked to user %d, want %d", identity.UserID, resp.User.ID)
+			}
+
+			var users int64
+			db.Model(&models.User{}).Count(&users)
+			if users != tt.wantUsers {
+				t.Errorf("%d users, want %d", users, tt.wantUsers)
+			}
+		})
+	}
+}
+
+func TestOIDCServiceUnverifiedEmailDoesNotLink(t *testing.T) {
+	service, provider, db := newTestOIDCService(t)
+
+	existing := models.User{Email: "ann@example.com", Password: "hash", PasswordSet: true, Role: models.UserRoleCustomer}
+	if err := db.Create(&existing).Error; err != nil {
+		t.Fatalf("create user: %v", err)
+	}
+
+	req := beginStubLogin(t, service, provider, stubIdentity{Subject: "ann-1", Email: "ann@example.com"})
+	_, err := service.CompleteLogin(context.Background(), req)
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Code != apperror.CodeConflict {
+		t.Fatalf("CompleteLogin error = %v, want a conflict", err)
+	}
+
+	var identities int64
+	db.Model(&models.UserIdentity{}).Count(&identities)
+	if identities != 0 {
+		t.Errorf("%d identities linked, want none", identities)
+	}
+}
+
+func TestOIDCServiceRejectsLogin(t *testing.T) {
+	identity := stubIdentity{Subject: "bob-1", Email: "bob@example.com", EmailVerified: true}
+
+	tests := []struct {
+		name string
+		// prepare may alter the callback request or the provider's ID token
+		prepare func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest)
+		// exchanged is whether the code should reach the token endpoint
+		exchanged bool
+	}{
+		{
+			name: "state does not match the flow state",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				req.State = "forged"
+			},
+		},
+		{
+			name: "tampered flow state",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				req.FlowState += "x"
+			},
+		},
+		{
+			name: "missing flow state",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				req.FlowState = ""
+			},
+		},
+		{
+			name: "code redeemed with another login's PKCE verifier",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				other := beginStubLogin(t, service, provider, identity)
+				req.State, req.FlowState = other.State, other.FlowState
+			},
+			exchanged: true,
+		},
+		{
+			name: "missing ID token",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims { return nil }
+			},
+			exchanged: true,
+		},
+		{
+			name: "ID token nonce from another login",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims {
+					claims["nonce"] = "replayed"
+					return claims
+				}
+			},
+			exchanged: true,
+		},
+		{
+			name: "ID token from another issuer",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims {
+					claims["iss"] = "https://attacker.example.com"
+					return claims
+				}
+			},
+			exchanged: true,
+		},
+		{
+			name: "ID token for another client",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims {
+					claims["aud"] = "another-client"
+					return claims
+				}
+			},
+			exchanged: true,
+		},
+		{
+			name: "ID token shared with another client that holds it",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims {
+					claims["aud"] = []string{stubClientID, "another-client"}
+					claims["azp"] = "another-client"
+					return claims
+				}
+			},
+			exchanged: true,
+		},
+		{
+			name: "expired ID token",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims {
+					claims["iat"] = time.Now().Add(-2 * time.Hour).Unix()
+					claims["exp"] = time.Now().Add(-time.Hour).Unix()
+					return claims
+				}
+			},
+			exchanged: true,
+		},
+		{
+			name: "ID token signed with another key",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				other, err := rsa.GenerateKey(rand.Reader, 2048)
+				if err != nil {
+					t.Fatalf("generate key: %v", err)
+				}
+
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims {
+					*key = other
+					return claims
+				}
+			},
+			exchanged: true,
+		},
+		{
+			name: "ID token for another subject than userinfo",
+			prepare: func(t *testing.T, service *OIDCService, provider *stubOIDCProvider, req *dto.OIDCCallbackRequest) {
+				provider.idToken = func(claims jwt.MapClaims, key **rsa.PrivateKey) jwt.MapClaims {
+					claims["sub"] = "someone-else"
+					return claims
+				}
+			},
+			exchanged: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, provider, db := newTestOIDCService(t)
+
+			req := beginStubLogin(t, service, provider, identity)
+			tt.prepare(t, service, provider, req)
+
+			resp, err := service.CompleteLogin(context.Background(), req)
+
+			var appErr *apperror.Error
+			if !errors.As(err, &appErr) || appErr.Code != apperror.CodeUnauthorized {
+				t.Fatalf("CompleteLogin = %+v, %v; want an unauthorized error", resp, err)
+			}
+
+			if exchanged := provider.tokenExchanges > 0; exchanged != tt.exchanged {
+				t.Errorf("code exchanged = %v, want %v", exchanged, tt.exchanged)
+			}
+
+			var identities int64
+			db.Model(&models.UserIdentity{}).Count(&identities)
+			if identities != 0 {
+				t.Errorf("%d identities linked, want none", identities)
+			}
+		})
+	}
+}
+
+func TestOIDCServiceLoginChecks(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Hour)
+
+	tests := []struct {
+		name          string
+		requireVerify bool
+		existing      *models.User
+		identity      stubIdentity
+		wantErr       func(error) bool
+	}{
+		{
+			name:          "unverified provider email with verification required",
+			requireVerify: true,
+			identity:      stubIdentity{Subject: "bob-1", Email: "bob@example.com", Name: "Bob Stone"},
+			wantErr: func(err error) bool {
+				var appErr *apperror.Error
+				return errors.As(err, &appErr) && appErr.Code == apperror.CodeForbidden
+			},
+		},
+		{
+			name:     "locked account",
+			existing: &models.User{Email: "ann@example.com", Password: "hash", PasswordSet: true, Role: models.UserRoleCustomer, LockedUntil: &lockedUntil},
+			identity: stubIdentity{Subject: "ann-1", Email: "ann@example.com", EmailVerified: true},
+			wantErr: func(err error) bool {
+				var locked *LoginLockedError
+				return errors.As(err, &locked)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, provider, db := newTestOIDCService(t)
+			service.authService.config.Auth.RequireVerifiedEmailForLogin = tt.requireVerify
+
+			if tt.existing != nil {
+				if err := db.Create(tt.existing).Error; err != nil {
+					t.Fatalf("create user: %v", err)
+				}
+			}
+
+			resp, err := service.CompleteLogin(context.Background(), beginStubLogin(t, service, provider, tt.identity))
+			if !tt.wantErr(err) {
+				t.Fatalf("CompleteLogin = %+v, %v; want the login refused", resp, err)
+			}
+
+			var tokens int64
+			db.Model(&models.RefreshToken{}).Count(&tokens)
+			if tokens != 0 {
+				t.Errorf("%d refresh tokens issued, want none", tokens)
+			}
+
+			// A new account is kept so its owner can verify the email
+			var users int64
+			db.Model(&models.User{}).Where("email = ?", tt.identity.Email).Count(&users)
+			if users != 1 {
+				t.Errorf("%d accounts for %s, want 1", users, tt.identity.Email)
+			}
+		})
+	}
+}
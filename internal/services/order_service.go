@@ -1,10 +1,14 @@
 package services
 
 import (
-	"errors"
+	"context"
 	"fmt"
+	"log"
 
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/config"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"gorm.io/gorm"
@@ -13,25 +17,38 @@ import (
 var _ OrderServiceInterface = (*OrderService)(nil)
 
 type OrderService struct {
-	db *gorm.DB
+	db     *gorm.DB
+	config *config.Config
+	pubSub interfaces.PubSub
 }
 
-func NewOrderService(db *gorm.DB) *OrderService {
-	return &OrderService{db: db}
+func NewOrderService(cfg *config.Config, db *gorm.DB, pubSub interfaces.PubSub) *OrderService {
+	return &OrderService{db: db, config: cfg, pubSub: pubSub}
 }
 
 func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
 	var orderResponse *dto.OrderResponse
 
+	if s.config.Auth.RequireVerifiedEmailForCheckout {
+		var user models.User
+		if err := s.db.First(&user, userID).Error; err != nil {
+			return nil, apperror.NotFound("user not found")
+		}
+
+		if !user.IsEmailVerified() {
+			return nil, apperror.Forbidden("email address must be verified before checkout")
+		}
+	}
+
 	err := s.db.Transaction(func(tx *gorm.DB) error {
 
 		var cart models.Cart
 		if err := tx.Preload("CartItems.Product").Where("user_id = ?", userID).First(&cart).Error; err != nil {
-			return errors.New("cart not found")
+			return apperror.NotFound("cart not found")
 		}
 
 		if len(cart.CartItems) == 0 {
-			return errors.New("cart is empty")
+			return apperror.InvalidInput("cart is empty")
 		}
 
 		var totalAmount float64
@@ -41,7 +58,7 @@ func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
 			cartItem := &cart.CartItems[i]
 
 			if cartItem.Product.Stock < cartItem.Quantity {
-				return fmt.Errorf("insufficient stock for product: %s", cartItem.Product.Name)
+				return apperror.OutOfStock(fmt.Sprintf("insufficient stock for product: %s", cartItem.Product.Name))
 			}
 
 			itemTotal := float64(cartItem.Quantity) * cartItem.Product.Price
@@ -54,6 +71,7 @@ func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
 			})
 
 			cartItem.Product.Stock -= cartItem.Quantity
+			cartItem.Product.SalesCount += cartItem.Quantity
 			if err := tx.Save(&cartItem.Product).Error; err != nil {
 				return err
 			}
@@ -90,11 +108,132 @@ func (s *OrderService) CreateOrder(userID uint) (*dto.OrderResponse, error) {
 		return nil, err
 	}
 
+	publish(s.pubSub, cartTopic(userID), nil)
+	for _, item := range orderResponse.OrderItems {
+		publishProductChange(s.pubSub, item.ProductID)
+	}
+
 	return orderResponse, nil
 
 }
 
 func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	return s.findOrders(userID, page, limit, "OrderItems.Product.Category")
+}
+
+// ListOrders is GetOrders without the products of each order item, for
+// callers that load products separately
+func (s *OrderService) ListOrders(userID uint, page, limit int) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	return s.findOrders(userID, page, limit, "OrderItems")
+}
+
+// GetOrdersByCursor pages through the user's orders, newest first, using a
+// keyset cursor
+func (s *OrderService) GetOrdersByCursor(userID uint, req *dto.CursorPageRequest) ([]dto.OrderResponse, *utils.CursorMeta, error) {
+	return s.findOrdersByCursor(userID, req, "OrderItems.Product.Category")
+}
+
+// ListOrdersByCursor is GetOrdersByCursor without the products of each order item
+func (s *OrderService) ListOrdersByCursor(userID uint, req *dto.CursorPageRequest) ([]dto.OrderResponse, *utils.CursorMeta, error) {
+	return s.findOrdersByCursor(userID, req, "OrderItems")
+}
+
+// orderKeyset lists orders newest first
+var orderKeyset = keyset{key: "newest", column: "created_at", desc: true, kind: cursorTime}
+
+// OrderCursor returns the cursor pointing at order
+func OrderCursor(order *dto.OrderResponse) string {
+	return orderKeyset.cursor(order.CreatedAt, order.ID)
+}
+
+func (s *OrderService) GetOrder(userID, orderID uint) (*dto.OrderResponse, error) {
+	return s.findOrder(userID, orderID, "OrderItems.Product.Category")
+}
+
+// FindOrder is GetOrder without the products of each order item
+func (s *OrderService) FindOrder(userID, orderID uint) (*dto.OrderResponse, error) {
+	return s.findOrder(userID, orderID, "OrderItems")
+}
+
+// GetAllOrders pages through the orders of every customer, newest first, for
+// staff
+func (s *OrderService) GetAllOrders(req *dto.AdminOrderListRequest) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	return s.findAllOrders(req, "OrderItems.Product.Category")
+}
+
+// ListAllOrders is GetAllOrders without the products of each order item
+func (s *OrderService) ListAllOrders(req *dto.AdminOrderListRequest) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	return s.findAllOrders(req, "OrderItems")
+}
+
+// GetAnyOrder returns an order of any customer, for staff
+func (s *OrderService) GetAnyOrder(orderID uint) (*dto.OrderResponse, error) {
+	return s.findAnyOrder(orderID, "OrderItems.Product.Category")
+}
+
+// FindAnyOrder is GetAnyOrder without the products of each order item
+func (s *OrderService) FindAnyOrder(orderID uint) (*dto.OrderResponse, error) {
+	return s.findAnyOrder(orderID, "OrderItems")
+}
+
+// UpdateOrderStatus moves an order to a new status and notifies subscribers
+func (s *OrderService) UpdateOrderStatus(orderID uint, req *dto.UpdateOrderStatusRequest) (*dto.OrderResponse, error) {
+	status := models.OrderStatus(req.Status)
+	if !status.IsValid() {
+		return nil, apperror.InvalidInput(fmt.Sprintf("invalid order status: %s", req.Status))
+	}
+
+	var order models.Order
+	if err := s.db.First(&order, orderID).Error; err != nil {
+		return nil, apperror.NotFound("order not found")
+	}
+
+	if order.Status != status {
+		if err := s.db.Model(&order).Update("status", status).Error; err != nil {
+			return nil, err
+		}
+
+		publish(s.pubSub, orderStatusTopic(order.ID), []byte(status))
+	}
+
+	return s.getOrderResponse(s.db, order.ID)
+}
+
+// WatchOrder streams the order, without the products of each order item,
+// each time its status changes until ctx is done
+func (s *OrderService) WatchOrder(ctx context.Context, userID, orderID uint) (<-chan *dto.OrderResponse, error) {
+	if _, err := s.FindOrder(userID, orderID); err != nil {
+		return nil, apperror.NotFound("order not found")
+	}
+
+	events, err := s.pubSub.Subscribe(ctx, orderStatusTopic(orderID))
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make(chan *dto.OrderResponse, 1)
+	go func() {
+		defer close(orders)
+
+		for range events {
+			order, err := s.FindOrder(userID, orderID)
+			if err != nil {
+				log.Printf("Failed to load order %d: %v", orderID, err)
+				continue
+			}
+
+			select {
+			case orders <- order:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return orders, nil
+}
+
+func (s *OrderService) findOrders(userID uint, page, limit int, preload string) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
 	if page < 1 {
 		page = 1
 	}
@@ -113,9 +252,9 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 
 	s.db.Model(&models.Order{}).Where("user_id = ?", userID).Count(&total)
 
-	if err := s.db.Preload("OrderItems.Product.Category").
+	if err := s.db.Preload(preload).
 		Where("user_id = ?", userID).
-		Order("created_at DESC").
+		Order("created_at DESC, id DESC").
 		Offset(offset).Limit(limit).
 		Find(&orders).Error; err != nil {
 		return nil, nil, err
@@ -138,9 +277,92 @@ func (s *OrderService) GetOrders(userID uint, page, limit int) ([]dto.OrderRespo
 	return response, meta, nil
 }
 
-func (s *OrderService) GetOrder(userID, orderID uint) (*dto.OrderResponse, error) {
+func (s *OrderService) findAllOrders(req *dto.AdminOrderListRequest, preload string) ([]dto.OrderResponse, *utils.PaginationMeta, error) {
+	if req.Status != "" && !models.OrderStatus(req.Status).IsValid() {
+		return nil, nil, apperror.InvalidInput(fmt.Sprintf("invalid order status: %s", req.Status))
+	}
+
+	page, limit := req.Page, req.Limit
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	if limit > 100 {
+		limit = 100
+	}
+
+	query := s.db.Model(&models.Order{})
+
+	if req.UserID != nil {
+		query = query.Where("user_id = ?", *req.UserID)
+	}
+
+	if req.Status != "" {
+		query = query.Where("status = ?", req.Status)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var orders []models.Order
+	if err := query.Preload(preload).
+		Order("created_at DESC, id DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&orders).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.OrderResponse, len(orders))
+	for i := range orders {
+		response[i] = s.convertToOrderResponse(&orders[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+func (s *OrderService) findOrdersByCursor(userID uint, req *dto.CursorPageRequest, preload string) ([]dto.OrderResponse, *utils.CursorMeta, error) {
+	var total int64
+	s.db.Model(&models.Order{}).Where("user_id = ?", userID).Count(&total)
+
+	query, page, err := orderKeyset.apply(
+		s.db.Preload(preload).Where("user_id = ?", userID), req)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var orders []models.Order
+	if err := query.Find(&orders).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.OrderResponse, len(orders))
+	for i := range orders {
+		response[i] = s.convertToOrderResponse(&orders[i])
+	}
+
+	response, meta := finishKeysetPage(page, response, OrderCursor)
+	meta.Total = total
+
+	return response, &meta, nil
+}
+
+func (s *OrderService) findOrder(userID, orderID uint, preload string) (*dto.OrderResponse, error) {
 	var order models.Order
-	if err := s.db.Preload("OrderItems.Product.Category").
+	if err := s.db.Preload(preload).
 		Where("id = ? AND user_id = ?", orderID, userID).
 		First(&order).Error; err != nil {
 		return nil, err
@@ -151,6 +373,17 @@ func (s *OrderService) GetOrder(userID, orderID uint) (*dto.OrderResponse, error
 	return &response, nil
 }
 
+func (s *OrderService) findAnyOrder(orderID uint, preload string) (*dto.OrderResponse, error) {
+	var order models.Order
+	if err := s.db.Preload(preload).First(&order, orderID).Error; err != nil {
+		return nil, apperror.NotFound("order not found")
+	}
+
+	response := s.convertToOrderResponse(&order)
+
+	return &response, nil
+}
+
 func (s *OrderService) getOrderResponse(tx *gorm.DB, orderID uint) (*dto.OrderResponse, error) {
 	var order models.Order
 	if err := tx.Preload("OrderItems.Product.Category").First(&order, orderID).Error; err != nil {
@@ -168,7 +401,8 @@ func (s *OrderService) convertToOrderResponse(order *models.Order) dto.OrderResp
 		item := order.OrderItems[i]
 
 		orderItems[i] = dto.OrderItemResponse{
-			ID: item.ID,
+			ID:        item.ID,
+			ProductID: item.ProductID,
 			Product: dto.ProductResponse{
 				ID:          item.Product.ID,
 				CategoryID:  item.Product.CategoryID,
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	defaultPageLimit = 10
+	maxPageLimit     = 100
+)
+
+// cursorKind is the type of the sort column values held in cursors
+type cursorKind int
+
+const (
+	cursorNone cursorKind = iota
+	cursorNumber
+	cursorString
+	cursorTime
+)
+
+// keyset is the sort order of a keyset-paginated list. Rows are ordered by
+// column, if any, and then by id so that every row has a unique position.
+// Cursors carry key and are only accepted by the keyset that issued them.
+type keyset struct {
+	key    string
+	column string
+	desc   bool
+	kind   cursorKind
+}
+
+type keysetPage struct {
+	limit     int
+	backward  bool
+	hasCursor bool
+}
+
+// apply restricts query to the rows after (or before) the requested cursor.
+// One extra row is fetched to tell whether another page follows.
+func (k keyset) apply(query *gorm.DB, req *dto.CursorPageRequest) (*gorm.DB, *keysetPage, error) {
+	forward := req.First > 0 || req.After != ""
+	backward := req.Last > 0 || req.Before != ""
+	if forward && backward {
+		return nil, nil, apperror.InvalidInput("first/after cannot be combined with last/before")
+	}
+
+	page := &keysetPage{limit: req.First, backward: backward}
+	raw := req.After
+	if backward {
+		page.limit = req.Last
+		raw = req.Before
+	}
+
+	if page.limit < 1 {
+		page.limit = defaultPageLimit
+	}
+
+	if page.limit > maxPageLimit {
+		page.limit = maxPageLimit
+	}
+
+	op := ">"
+	if k.desc != backward {
+		op = "<"
+	}
+
+	if raw != "" {
+		cursor, err := utils.DecodeCursor(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		value, err := k.cursorValue(cursor)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if k.column == "" {
+			query = query.Where("id "+op+" ?", cursor.ID)
+		} else {
+			query = query.Where(fmt.Sprintf("(%s, id) %s (?, ?)", k.column, op), value, cursor.ID)
+		}
+		page.hasCursor = true
+	}
+
+	return k.order(query, backward).Limit(page.limit + 1), page, nil
+}
+
+// cursor returns the cursor pointing at the row with the given sort column
+// value and ID
+func (k keyset) cursor(value any, id uint) string {
+	if k.kind == cursorNone {
+		value = nil
+	}
+
+	return utils.EncodeCursor(utils.Cursor{Sort: k.key, Value: value, ID: id})
+}
+
+// cursorValue returns the cursor's sort column value as the column's type.
+// Cursors issued for another sort, or holding a value of the wrong type, are
+// rejected rather than compared against the column.
+func (k keyset) cursorValue(cursor *utils.Cursor) (any, error) {
+	if cursor.Sort != k.key {
+		return nil, utils.ErrInvalidCursor
+	}
+
+	switch k.kind {
+	case cursorNumber:
+		if value, ok := cursor.Value.(float64); ok {
+			return value, nil
+		}
+	case cursorString:
+		if value, ok := cursor.Value.(string); ok {
+			return value, nil
+		}
+	case cursorTime:
+		if value, ok := cursor.Value.(string); ok {
+			if t, err := time.Parse(time.RFC3339Nano, value); err == nil {
+				return t, nil
+			}
+		}
+	default:
+		if cursor.Value == nil {
+			return nil, nil
+		}
+	}
+
+	return nil, utils.ErrInvalidCursor
+}
+
+// order sorts query in the keyset order, or in reverse
+func (k keyset) order(query *gorm.DB, reverse bool) *gorm.DB {
+	direction := "ASC"
+	if k.desc != reverse {
+		direction = "DESC"
+	}
+
+	if k.column != "" {
+		query = query.Order(k.column + " " + direction)
+	}
+
+	return query.Order("id " + direction)
+}
+
+// finishKeysetPage drops the extra row fetched by apply, restores the list order when
+// paging backwards and builds the page metadata
+func finishKeysetPage[T any](page *keysetPage, rows []T, cursor func(*T) string) ([]T, utils.CursorMeta) {
+	hasMore := len(rows) > page.limit
+	if hasMore {
+		rows = rows[:page.limit]
+	}
+
+	if page.backward {
+		slices.Reverse(rows)
+	}
+
+	meta := utils.CursorMeta{Limit: page.limit}
+	if page.backward {
+		meta.HasPreviousPage = hasMore
+		meta.HasNextPage = page.hasCursor
+	} else {
+		meta.HasNextPage = hasMore
+		meta.HasPreviousPage = page.hasCursor
+	}
+
+	if len(rows) > 0 {
+		meta.StartCursor = cursor(&rows[0])
+		meta.EndCursor = cursor(&rows[len(rows)-1])
+	}
+
+	return rows, meta
+}
@@ -0,0 +1,127 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+)
+
+func TestKeysetCursorValue(t *testing.T) {
+	created := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+
+	tests := []struct {
+		name    string
+		sort    string
+		cursor  string
+		want    any
+		wantErr bool
+	}{
+		{name: "price", sort: dto.ProductSortPriceAsc, cursor: productSorts[dto.ProductSortPriceAsc].cursor(9.5, 1), want: 9.5},
+		{name: "name", sort: dto.ProductSortName, cursor: productSorts[dto.ProductSortName].cursor("Boot", 1), want: "Boot"},
+		{name: "newest", sort: dto.ProductSortNewest, cursor: productSorts[dto.ProductSortNewest].cursor(created, 1), want: created},
+		{name: "default", sort: "", cursor: productSorts[""].cursor(nil, 1)},
+		{
+			name:    "cursor from another sort",
+			sort:    dto.ProductSortPriceAsc,
+			cursor:  productSorts[dto.ProductSortName].cursor("Boot", 1),
+			wantErr: true,
+		},
+		{
+			name:    "sorted cursor in the default order",
+			sort:    "",
+			cursor:  productSorts[dto.ProductSortPriceDesc].cursor(9.5, 1),
+			wantErr: true,
+		},
+		{
+			name:    "value of the wrong type",
+			sort:    dto.ProductSortPriceAsc,
+			cursor:  utils.EncodeCursor(utils.Cursor{Sort: dto.ProductSortPriceAsc, Value: "Boot", ID: 1}),
+			wantErr: true,
+		},
+		{
+			name:    "time that does not parse",
+			sort:    dto.ProductSortNewest,
+			cursor:  utils.EncodeCursor(utils.Cursor{Sort: dto.ProductSortNewest, Value: "yesterday", ID: 1}),
+			wantErr: true,
+		},
+		{
+			name:    "value without a sort column",
+			sort:    "",
+			cursor:  utils.EncodeCursor(utils.Cursor{Value: 9.5, ID: 1}),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cursor, err := utils.DecodeCursor(tt.cursor)
+			if err != nil {
+				t.Fatalf("DecodeCursor: %v", err)
+			}
+
+			value, err := productSorts[tt.sort].cursorValue(cursor)
+			if tt.wantErr {
+				if !errors.Is(err, utils.ErrInvalidCursor) {
+					t.Errorf("err = %v, want an invalid cursor", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("cursorValue: %v", err)
+			}
+
+			if want, ok := tt.want.(time.Time); ok {
+				if got, _ := value.(time.Time); !got.Equal(want) {
+					t.Errorf("value = %v, want %v", value, want)
+				}
+			} else if value != tt.want {
+				t.Errorf("value = %v, want %v", value, tt.want)
+			}
+		})
+	}
+}
+
+func TestProductServiceCursorPaging(t *testing.T) {
+	db := openTestDB(t, &models.Category{}, &models.Product{})
+	createTestProducts(t, db,
+		models.Product{ID: 1, CategoryID: 1, Name: "Coat", SKU: "COAT-1", Price: 90, IsActive: true},
+		models.Product{ID: 2, CategoryID: 1, Name: "Anorak", SKU: "ANORAK-1", Price: 70, IsActive: true},
+		models.Product{ID: 3, CategoryID: 1, Name: "Boot", SKU: "BOOT-1", Price: 80, IsActive: true},
+	)
+	service := NewProductService(db, nil, nil, nil)
+
+	byName := &dto.ProductListRequest{Sort: dto.ProductSortName}
+	first, meta, err := service.ListProductsByCursor(byName, &dto.CursorPageRequest{First: 2})
+	if err != nil {
+		t.Fatalf("first page: %v", err)
+	}
+
+	if len(first) != 2 || first[0].Name != "Anorak" || first[1].Name != "Boot" || !meta.HasNextPage {
+		t.Fatalf("first page = %+v, %+v; want Anorak and Boot with a next page", first, meta)
+	}
+
+	next, _, err := service.ListProductsByCursor(byName, &dto.CursorPageRequest{First: 2, After: meta.EndCursor})
+	if err != nil {
+		t.Fatalf("next page: %v", err)
+	}
+
+	if len(next) != 1 || next[0].Name != "Coat" {
+		t.Errorf("next page = %+v, want Coat", next)
+	}
+
+	// A name cursor replayed against the price sort is rejected before it
+	// reaches the database
+	byPrice := &dto.ProductListRequest{Sort: dto.ProductSortPriceAsc}
+	_, _, err = service.ListProductsByCursor(byPrice, &dto.CursorPageRequest{First: 2, After: meta.EndCursor})
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Code != apperror.CodeInvalidInput {
+		t.Errorf("replayed cursor: err = %v, want invalid input", err)
+	}
+}
@@ -0,0 +1,326 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"maps"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/events"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/notifications"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+	"gorm.io/gorm"
+)
+
+var _ PrivacyServiceInterface = (*PrivacyService)(nil)
+
+const (
+	exportOrdersPageSize = 100
+
+	// accountDeletionReauthWindow is how recently a social login account
+	// without 2FA must have signed in to delete itself
+	accountDeletionReauthWindow = 5 * time.Minute
+
+	// accountDeletionInterval is how often accounts pending deletion are
+	// anonymised, so a deletion completes at most this long after the request
+	accountDeletionInterval = time.Minute
+
+	// redactedAuditValue replaces personal data in the audit log entries of
+	// deleted accounts
+	redactedAuditValue = "[redacted]"
+)
+
+type PrivacyService struct {
+	db             *gorm.DB
+	eventPublisher events.Publisher
+	cartService    CartServiceInterface
+	orderService   OrderServiceInterface
+}
+
+func NewPrivacyService(
+	db *gorm.DB,
+	eventPublisher events.Publisher,
+	cartService CartServiceInterface,
+	orderService OrderServiceInterface) *PrivacyService {
+	return &PrivacyService{
+		db:             db,
+		eventPublisher: eventPublisher,
+		cartService:    cartService,
+		orderService:   orderService,
+	}
+}
+
+// ExportUserData collects the profile, linked accounts, cart and full order
+// history of a user
+func (s *PrivacyService) ExportUserData(userID uint) (*dto.UserDataExport, error) {
+	var user models.User
+	if err := s.db.Preload("Identities").First(&user, userID).Error; err != nil {
+		return nil, err
+	}
+
+	linkedAccounts := make([]dto.LinkedIdentityResponse, len(user.Identities))
+	for i, identity := range user.Identities {
+		linkedAccounts[i] = dto.LinkedIdentityResponse{
+			Provider:  identity.Provider,
+			Email:     identity.Email,
+			CreatedAt: identity.CreatedAt,
+		}
+	}
+
+	export := &dto.UserDataExport{
+		ExportedAt:     time.Now().UTC(),
+		Profile:        convertToUserResponse(&user),
+		LinkedAccounts: linkedAccounts,
+		Orders:         []dto.OrderResponse{},
+	}
+
+	if cart, err := s.cartService.GetCart(userID); err == nil {
+		export.Cart = cart
+	}
+
+	for page := 1; ; page++ {
+		orders, meta, err := s.orderService.GetOrders(userID, page, exportOrdersPageSize)
+		if err != nil {
+			return nil, err
+		}
+
+		export.Orders = append(export.Orders, orders...)
+		if page >= meta.TotalPages {
+			break
+		}
+	}
+
+	return export, nil
+}
+
+// RequestAccountDeletion deactivates the account and revokes its sessions
+// immediately. The account is anonymised by Run shortly afterwards.
+func (s *PrivacyService) RequestAccountDeletion(ctx context.Context, userID uint, req *dto.DeleteAccountRequest) error {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return apperror.NotFound("user not found")
+	}
+
+	if err := confirmAccountDeletion(ctx, &user, req); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	return s.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(&user).Updates(map[string]any{
+			"is_active":             false,
+			"deletion_requested_at": now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&models.RefreshToken{}).Error
+	})
+}
+
+// confirmAccountDeletion re-authenticates the account holder. Social login
+// accounts never chose a password, so they confirm with a two-factor code
+// or, without 2FA, by having just signed in with their identity provider.
+func confirmAccountDeletion(ctx context.Context, user *models.User, req *dto.DeleteAccountRequest) error {
+	switch {
+	case user.PasswordSet:
+		if !utils.CheckPassword(req.Password, user.Password) {
+			return apperror.InvalidInput("invalid password")
+		}
+	case user.TwoFactorEnabled:
+		if !utils.ValidateTOTP(user.TwoFactorSecret, req.Code, time.Now()) {
+			return apperror.InvalidInput("invalid two-factor code")
+		}
+	default:
+		authTime, _ := ctx.Value(utils.AuthTimeKey).(time.Time)
+		if time.Since(authTime) > accountDeletionReauthWindow {
+			return apperror.Forbidden("sign in again to delete your account")
+		}
+	}
+
+	return nil
+}
+
+// Run anonymises accounts whose deletion was requested until ctx is done.
+// Accounts that fail, e.g. because the server stopped in the middle, are
+// retried on the next run.
+func (s *PrivacyService) Run(ctx context.Context) {
+	ticker := time.NewTicker(accountDeletionInterval)
+	defer ticker.Stop()
+
+	for {
+		s.processPendingDeletions(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *PrivacyService) processPendingDeletions(ctx context.Context) {
+	var userIDs []uint
+	if err := s.db.Model(&models.User{}).
+		Where("deletion_requested_at IS NOT NULL AND anonymized_at IS NULL").
+		Pluck("id", &userIDs).Error; err != nil {
+		log.Printf("failed to find accounts pending deletion: %v", err)
+		return
+	}
+
+	for _, userID := range userIDs {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := s.ProcessAccountDeletion(userID); err != nil {
+			log.Printf("failed to delete account %d: %v", userID, err)
+		}
+	}
+}
+
+// ProcessAccountDeletion removes personal data and credentials of a user.
+// Orders are kept for accounting and stay linked to the anonymised user.
+func (s *PrivacyService) ProcessAccountDeletion(userID uint) error {
+	var user models.User
+	if err := s.db.First(&user, userID).Error; err != nil {
+		return err
+	}
+
+	if user.DeletionRequestedAt == nil || user.AnonymizedAt != nil {
+		return nil
+	}
+
+	randomPassword, err := utils.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	hashedPassword, err := utils.HashPassword(randomPassword)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	err = s.db.Transaction(func(tx *gorm.DB) error {
+		for _, model := range []any{
+			&models.RefreshToken{},
+			&models.VerificationToken{},
+			&models.RecoveryCode{},
+			&models.UserIdentity{},
+			&models.APIKey{},
+		} {
+			if err := tx.Unscoped().Where("user_id = ?", userID).Delete(model).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Exec("DELETE FROM user_roles WHERE user_id = ?", userID).Error; err != nil {
+			return err
+		}
+
+		if err := redactAuditLogs(tx, &user); err != nil {
+			return err
+		}
+
+		// Searches stay in the analytics but no longer identify the user
+		if err := tx.Model(&models.SearchQuery{}).Where("user_id = ?", userID).Update("user_id", nil).Error; err != nil {
+			return err
+		}
+
+		var cart models.Cart
+		if err := tx.Where("user_id = ?", userID).First(&cart).Error; err == nil {
+			if err := tx.Unscoped().Where("cart_id = ?", cart.ID).Delete(&models.CartItem{}).Error; err != nil {
+				return err
+			}
+			if err := tx.Unscoped().Delete(&cart).Error; err != nil {
+				return err
+			}
+		}
+
+		if err := tx.Model(&models.User{}).Where("id = ?", userID).Updates(map[string]any{
+			"email":              fmt.Sprintf("deleted-user-%d@deleted.invalid", userID),
+			"password":           hashedPassword,
+			"first_name":         "Deleted",
+			"last_name":          "User",
+			"phone":              "",
+			"is_active":          false,
+			"email_verified_at":  nil,
+			"two_factor_enabled": false,
+			"two_factor_secret":  "",
+			"anonymized_at":      now,
+		}).Error; err != nil {
+			return err
+		}
+
+		return tx.Delete(&models.User{}, userID).Error
+	})
+	if err != nil {
+		return err
+	}
+
+	return s.eventPublisher.Publish(notifications.AccountDeleted, notifications.AccountDeletedMessage{
+		Email:     user.Email,
+		FirstName: user.FirstName,
+		LastName:  user.LastName,
+		DeletedAt: now,
+	}, map[string]string{})
+}
+
+// redactAuditLogs removes the email addresses of a user from the audit log:
+// from entries about the account, such as email changes, and from failed
+// logins that named one of its addresses. The entries themselves are kept.
+func redactAuditLogs(tx *gorm.DB, user *models.User) error {
+	var logs []models.AuditLog
+	if err := tx.Where("entity_type = ? AND entity_id = ?", models.AuditEntityUser, strconv.FormatUint(uint64(user.ID), 10)).
+		Find(&logs).Error; err != nil {
+		return err
+	}
+
+	emails := []string{strings.ToLower(user.Email)}
+	for _, entry := range logs {
+		for _, state := range []map[string]any{entry.Before, entry.After} {
+			if email, ok := state["email"].(string); ok {
+				emails = append(emails, strings.ToLower(email))
+			}
+		}
+	}
+
+	var failedLogins []models.AuditLog
+	if err := tx.Where("action = ? AND LOWER(after->>'email') IN ?", models.AuditActionLoginFailed, emails).
+		Find(&failedLogins).Error; err != nil {
+		return err
+	}
+
+	for _, entry := range append(logs, failedLogins...) {
+		before, redactedBefore := redactAuditEmail(entry.Before)
+		after, redactedAfter := redactAuditEmail(entry.After)
+		if !redactedBefore && !redactedAfter {
+			continue
+		}
+
+		entry.Before, entry.After = before, after
+		if err := tx.Model(&entry).Select("Before", "After").Updates(&entry).Error; err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// redactAuditEmail returns state with its email replaced, and whether it had one
+func redactAuditEmail(state map[string]any) (map[string]any, bool) {
+	if _, ok := state["email"]; !ok {
+		return state, false
+	}
+
+	redacted := maps.Clone(state)
+	redacted["email"] = redactedAuditValue
+	return redacted, true
+}
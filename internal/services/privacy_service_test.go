@@ -0,0 +1,92 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/notifications"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+)
+
+func TestPrivacyServiceProcessPendingDeletions(t *testing.T) {
+	db := openTestDB(t, &models.User{}, &models.RefreshToken{}, &models.VerificationToken{}, &models.RecoveryCode{},
+		&models.UserIdentity{}, &models.APIKey{}, &models.SearchQuery{}, &models.Cart{}, &models.CartItem{}, &models.AuditLog{})
+
+	requestedAt := time.Now().Add(-time.Hour)
+	users := []models.User{
+		{ID: 1, Email: "ann@example.com", Password: "x", FirstName: "Ann", LastName: "Lee", DeletionRequestedAt: &requestedAt},
+		{ID: 2, Email: "bob@example.com", Password: "x", FirstName: "Bob", LastName: "Roe", IsActive: true},
+	}
+	if err := db.Create(&users).Error; err != nil {
+		t.Fatalf("create users: %v", err)
+	}
+
+	ctx := context.Background()
+	audit := NewAuditService(repository.NewAuditLogRepository(db))
+	for _, entry := range []AuditEntry{
+		{
+			Action:     models.AuditActionEmailChange,
+			EntityType: models.AuditEntityUser,
+			EntityID:   1,
+			Before:     map[string]any{"email": "ann.old@example.com"},
+			After:      map[string]any{"email": "ann@example.com"},
+		},
+		{
+			Action:     models.AuditActionLoginFailed,
+			EntityType: models.AuditEntityUser,
+			After:      map[string]any{"email": "Ann.Old@example.com", "reason": "unknown or inactive account"},
+		},
+		{
+			Action:     models.AuditActionLoginFailed,
+			EntityType: models.AuditEntityUser,
+			After:      map[string]any{"email": "bob@example.com", "reason": "unknown or inactive account"},
+		},
+	} {
+		audit.Record(ctx, entry)
+	}
+
+	publisher := &recordingPublisher{}
+	service := NewPrivacyService(db, publisher, nil, nil)
+	service.processPendingDeletions(ctx)
+
+	var deleted models.User
+	if err := db.Unscoped().First(&deleted, 1).Error; err != nil {
+		t.Fatalf("load deleted user: %v", err)
+	}
+
+	if deleted.AnonymizedAt == nil || deleted.Email == "ann@example.com" {
+		t.Errorf("anonymized at = %v, email = %q; want the account anonymised", deleted.AnonymizedAt, deleted.Email)
+	}
+
+	if len(publisher.events) != 1 || publisher.events[0] != notifications.AccountDeleted {
+		t.Errorf("events = %v, want one %s", publisher.events, notifications.AccountDeleted)
+	}
+
+	var logs []models.AuditLog
+	if err := db.Order("id").Find(&logs).Error; err != nil {
+		t.Fatalf("load audit logs: %v", err)
+	}
+
+	want := []struct{ before, after any }{
+		{before: redactedAuditValue, after: redactedAuditValue},
+		{before: nil, after: redactedAuditValue},
+		{before: nil, after: "bob@example.com"},
+	}
+	if len(logs) != len(want) {
+		t.Fatalf("%d audit logs, want %d", len(logs), len(want))
+	}
+
+	for i, entry := range logs {
+		if entry.Before["email"] != want[i].before || entry.After["email"] != want[i].after {
+			t.Errorf("entry %d emails = %v, %v; want %v, %v", i, entry.Before["email"], entry.After["email"], want[i].before, want[i].after)
+		}
+	}
+
+	// The account is only processed once
+	service.processPendingDeletions(ctx)
+	if len(publisher.events) != 1 {
+		t.Errorf("%d events after a second run, want 1", len(publisher.events))
+	}
+}
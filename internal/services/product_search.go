@@ -0,0 +1,202 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	defaultSuggestionLimit = 8
+	maxSuggestionLimit     = 20
+
+	// maxCategorySuggestions caps the categories listed before products
+	maxCategorySuggestions = 3
+
+	// maxSynonymWords is the longest phrase, in words, looked up in the
+	// synonym sets
+	maxSynonymWords = 3
+
+	// maxSearchAlternatives caps the phrasings synonyms expand a query into
+	maxSearchAlternatives = 10
+
+	// maxSearchResults is how deep search results can be paged, matching
+	// the default index.max_result_window of OpenSearch
+	maxSearchResults = 10000
+)
+
+// SuggestProducts autocompletes a partial query with the names of matching
+// categories followed by products. Names match when any of their words start
+// with the query.
+func (s *ProductService) SuggestProducts(ctx context.Context, req *dto.SearchSuggestRequest) ([]dto.SearchSuggestion, error) {
+	limit := req.Limit
+	if limit < 1 {
+		limit = defaultSuggestionLimit
+	}
+
+	if limit > maxSuggestionLimit {
+		limit = maxSuggestionLimit
+	}
+
+	text := strings.TrimSpace(req.Query)
+	if text == "" {
+		return []dto.SearchSuggestion{}, nil
+	}
+
+	prefix := escapeLike(text) + "%"
+	wordPrefix := "% " + prefix
+
+	db := s.db.WithContext(ctx)
+
+	var categories []models.Category
+	if err := db.
+		Where("is_active = ?", true).
+		Where("name ILIKE ? OR name ILIKE ?", prefix, wordPrefix).
+		Order("name").
+		Limit(min(limit, maxCategorySuggestions)).
+		Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	// Names starting with the query come first, then the best sellers
+	var products []models.Product
+	if remaining := limit - len(categories); remaining > 0 {
+		if err := db.
+			Where("is_active = ?", true).
+			Where("name ILIKE ? OR name ILIKE ?", prefix, wordPrefix).
+			Order(clause.OrderBy{Expression: clause.Expr{
+				SQL:  "name ILIKE ? DESC, sales_count DESC, name",
+				Vars: []any{prefix},
+			}}).
+			Limit(remaining).
+			Find(&products).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	suggestions := make([]dto.SearchSuggestion, 0, len(categories)+len(products))
+	for _, category := range categories {
+		suggestions = append(suggestions, dto.SearchSuggestion{
+			Type: dto.SuggestionTypeCategory,
+			ID:   category.ID,
+			Text: category.Name,
+		})
+	}
+
+	for _, product := range products {
+		suggestions = append(suggestions, dto.SearchSuggestion{
+			Type: dto.SuggestionTypeProduct,
+			ID:   product.ID,
+			Text: product.Name,
+		})
+	}
+
+	return suggestions, nil
+}
+
+// searchAlternatives returns the phrasings of query with its words and
+// phrases replaced by their synonyms
+func (s *ProductService) searchAlternatives(ctx context.Context, query string) ([]string, error) {
+	words := strings.Fields(normalizeSearchQuery(query))
+
+	var phrases []string
+	for i := range words {
+		for n := 1; n <= maxSynonymWords && i+n <= len(words); n++ {
+			phrases = append(phrases, strings.Join(words[i:i+n], " "))
+		}
+	}
+
+	if len(phrases) == 0 {
+		return nil, nil
+	}
+
+	var sets []models.SearchSynonymSet
+	if err := s.db.WithContext(ctx).Where("jsonb_exists_any(terms, ARRAY[?])", phrases).Find(&sets).Error; err != nil {
+		return nil, err
+	}
+
+	if len(sets) == 0 {
+		return nil, nil
+	}
+
+	synonyms := make(map[string][]string)
+	for _, set := range sets {
+		for _, term := range set.Terms {
+			for _, other := range set.Terms {
+				if other != term && !slices.Contains(synonyms[term], other) {
+					synonyms[term] = append(synonyms[term], other)
+				}
+			}
+		}
+	}
+
+	return expandSynonyms(words, synonyms), nil
+}
+
+// expandSynonyms returns up to maxSearchAlternatives phrasings of words with
+// the longest phrases that have synonyms replaced by each of them
+func expandSynonyms(words []string, synonyms map[string][]string) []string {
+	// The unchanged phrasing stays first and is left out of the result
+	variants := [][]string{{}}
+	for i := 0; i < len(words); {
+		n, alternatives := 1, []string(nil)
+		for size := min(maxSynonymWords, len(words)-i); size > 0; size-- {
+			if found, ok := synonyms[strings.Join(words[i:i+size], " ")]; ok {
+				n, alternatives = size, found
+				break
+			}
+		}
+
+		var next [][]string
+		for _, variant := range variants {
+			for _, term := range append([]string{strings.Join(words[i:i+n], " ")}, alternatives...) {
+				if len(next) > maxSearchAlternatives {
+					break
+				}
+				next = append(next, append(slices.Clone(variant), term))
+			}
+		}
+
+		variants = next
+		i += n
+	}
+
+	result := make([]string, 0, len(variants)-1)
+	for _, variant := range variants[1:] {
+		result = append(result, strings.Join(variant, " "))
+	}
+
+	return result
+}
+
+// pinnedProducts returns the IDs of the active products pinned for query
+// that match filter, in pin order
+func (s *ProductService) pinnedProducts(ctx context.Context, query string, filter *dto.ProductFilter) ([]uint, error) {
+	var ids []uint
+	err := repository.FilterProducts(s.db.WithContext(ctx).Model(&models.Product{}), filter).
+		Joins("JOIN search_pins ON search_pins.product_id = products.id").
+		Where("search_pins.query = ?", normalizeSearchQuery(query)).
+		Order("search_pins.position, search_pins.id").
+		Pluck("products.id", &ids).Error
+	return ids, err
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
+// SearchResultIDs returns the IDs of the products in a page of search
+// results, for search analytics
+func SearchResultIDs(results []dto.ProductSearchResult) []uint {
+	ids := make([]uint, len(results))
+	for i := range results {
+		ids[i] = results[i].ID
+	}
+	return ids
+}
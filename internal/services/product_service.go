@@ -1,25 +1,53 @@
 package services
 
 import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 var _ ProductServiceInterface = (*ProductService)(nil)
 
+// productSorts maps the sort keys of product listings to their keyset order.
+// Without a sort key products are listed by ID.
+var productSorts = map[string]keyset{
+	"":                         {},
+	dto.ProductSortPriceAsc:    {key: dto.ProductSortPriceAsc, column: "price", kind: cursorNumber},
+	dto.ProductSortPriceDesc:   {key: dto.ProductSortPriceDesc, column: "price", desc: true, kind: cursorNumber},
+	dto.ProductSortNewest:      {key: dto.ProductSortNewest, column: "created_at", desc: true, kind: cursorTime},
+	dto.ProductSortName:        {key: dto.ProductSortName, column: "name", kind: cursorString},
+	dto.ProductSortBestSelling: {key: dto.ProductSortBestSelling, column: "sales_count", desc: true, kind: cursorNumber},
+}
+
 type ProductService struct {
-	db *gorm.DB
+	db           *gorm.DB
+	pubSub       interfaces.PubSub
+	searchIndex  interfaces.SearchIndex
+	auditService AuditServiceInterface
 }
 
-func NewProductService(db *gorm.DB) *ProductService {
-	return &ProductService{db: db}
+func NewProductService(db *gorm.DB, pubSub interfaces.PubSub, searchIndex interfaces.SearchIndex, auditService AuditServiceInterface) *ProductService {
+	return &ProductService{
+		db:           db,
+		pubSub:       pubSub,
+		searchIndex:  searchIndex,
+		auditService: auditService,
+	}
 }
 
-func (s *ProductService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
+func (s *ProductService) CreateCategory(ctx context.Context, req *dto.CreateCategoryRequest) (*dto.CategoryResponse, error) {
 
 	category := models.Category{
+		ParentID:    req.ParentID,
 		Name:        req.Name,
 		Description: req.Description,
 	}
@@ -28,12 +56,15 @@ func (s *ProductService) CreateCategory(req *dto.CreateCategoryRequest) (*dto.Ca
 		return nil, err
 	}
 
-	return &dto.CategoryResponse{
-		ID:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-		IsActive:    category.IsActive,
-	}, nil
+	response := convertToCategoryResponse(&category)
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionCategoryCreate,
+		EntityType: models.AuditEntityCategory,
+		EntityID:   category.ID,
+		After:      response,
+	})
+
+	return &response, nil
 
 }
 
@@ -45,24 +76,42 @@ func (s *ProductService) GetCategories() ([]dto.CategoryResponse, error) {
 
 	response := make([]dto.CategoryResponse, len(categories))
 	for i := range categories {
-		response[i] = dto.CategoryResponse{
-			ID:          categories[i].ID,
-			Name:        categories[i].Name,
-			Description: categories[i].Description,
-			IsActive:    categories[i].IsActive,
-		}
+		response[i] = convertToCategoryResponse(&categories[i])
 	}
 
 	return response, nil
 }
 
-func (s *ProductService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
+func (s *ProductService) GetCategory(id uint) (*dto.CategoryResponse, error) {
+	var category models.Category
+	if err := s.db.First(&category, id).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToCategoryResponse(&category)
+	return &response, nil
+}
+
+func (s *ProductService) UpdateCategory(ctx context.Context, id uint, req *dto.UpdateCategoryRequest) (*dto.CategoryResponse, error) {
 
 	var category models.Category
 	if err := s.db.First(&category, id).Error; err != nil {
 		return nil, err
 	}
+	before := convertToCategoryResponse(&category)
+
+	if req.ParentID != nil {
+		var count int64
+		if err := s.db.Raw("SELECT COUNT(*) FROM ("+repository.CategorySubtreeSQL+") subtree WHERE id = ?", id, *req.ParentID).Scan(&count).Error; err != nil {
+			return nil, err
+		}
 
+		if count > 0 {
+			return nil, apperror.InvalidInput("a category cannot be moved under itself or one of its descendants")
+		}
+	}
+
+	category.ParentID = req.ParentID
 	category.Name = req.Name
 	category.Description = req.Description
 	if req.IsActive != nil {
@@ -73,19 +122,41 @@ func (s *ProductService) UpdateCategory(id uint, req *dto.UpdateCategoryRequest)
 		return nil, err
 	}
 
-	return &dto.CategoryResponse{
-		ID:          category.ID,
-		Name:        category.Name,
-		Description: category.Description,
-		IsActive:    category.IsActive,
-	}, nil
+	publishCategoryChange(s.pubSub, category.ID)
+
+	response := convertToCategoryResponse(&category)
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionCategoryUpdate,
+		EntityType: models.AuditEntityCategory,
+		EntityID:   category.ID,
+		Before:     before,
+		After:      response,
+	})
+
+	return &response, nil
 }
 
-func (s *ProductService) DeleteCategory(id uint) error {
-	return s.db.Delete(&models.Category{}, id).Error
+func (s *ProductService) DeleteCategory(ctx context.Context, id uint) error {
+	var category models.Category
+	result := s.db.Clauses(clause.Returning{}).Delete(&category, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		publishCategoryChange(s.pubSub, id)
+		s.auditService.Record(ctx, AuditEntry{
+			Action:     models.AuditActionCategoryDelete,
+			EntityType: models.AuditEntityCategory,
+			EntityID:   id,
+			Before:     convertToCategoryResponse(&category),
+		})
+	}
+
+	return nil
 }
 
-func (s *ProductService) CreateProduct(req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
+func (s *ProductService) CreateProduct(ctx context.Context, req *dto.CreateProductRequest) (*dto.ProductResponse, error) {
 	product := models.Product{
 		CategoryID:  req.CategoryID,
 		Name:        req.Name,
@@ -93,16 +164,135 @@ func (s *ProductService) CreateProduct(req *dto.CreateProductRequest) (*dto.Prod
 		Price:       req.Price,
 		Stock:       req.Stock,
 		SKU:         req.SKU,
+		Attributes:  req.Attributes,
+	}
+	if product.Attributes == nil {
+		product.Attributes = map[string]string{}
 	}
 
 	if err := s.db.Create(&product).Error; err != nil {
 		return nil, err
 	}
+	publishProductChange(s.pubSub, product.ID)
+
+	response, err := s.GetProduct(product.ID)
+	if err != nil {
+		return nil, err
+	}
 
-	return s.GetProduct(product.ID)
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionProductCreate,
+		EntityType: models.AuditEntityProduct,
+		EntityID:   product.ID,
+		After:      response,
+	})
+
+	return response, nil
 }
 
-func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+// GetProducts lists the active products matching req, sorted and paged as
+// requested
+func (s *ProductService) GetProducts(req *dto.ProductListRequest) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+	return s.findProducts(req, "Category", "Images")
+}
+
+// ListProducts is GetProducts without the category and images, for callers
+// that load relations separately
+func (s *ProductService) ListProducts(req *dto.ProductListRequest) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+	return s.findProducts(req)
+}
+
+// GetProductsByCursor is GetProducts paged with a keyset cursor, which stays
+// stable while products are added or removed. Cursors are only valid for the
+// sort order they were issued for.
+func (s *ProductService) GetProductsByCursor(req *dto.ProductListRequest, page *dto.CursorPageRequest) ([]dto.ProductResponse, *utils.CursorMeta, error) {
+	return s.findProductsByCursor(req, page, "Category", "Images")
+}
+
+// ListProductsByCursor is GetProductsByCursor without the category and images
+func (s *ProductService) ListProductsByCursor(req *dto.ProductListRequest, page *dto.CursorPageRequest) ([]dto.ProductResponse, *utils.CursorMeta, error) {
+	return s.findProductsByCursor(req, page)
+}
+
+// ProductCursor returns the cursor pointing at product in a listing with the
+// given sort key
+func ProductCursor(sort string, product *dto.ProductResponse) string {
+	var value any
+	switch sort {
+	case dto.ProductSortPriceAsc, dto.ProductSortPriceDesc:
+		value = product.Price
+	case dto.ProductSortNewest:
+		value = product.CreatedAt
+	case dto.ProductSortName:
+		value = product.Name
+	case dto.ProductSortBestSelling:
+		value = product.SalesCount
+	}
+
+	return productSorts[sort].cursor(value, product.ID)
+}
+
+func (s *ProductService) GetProduct(id uint) (*dto.ProductResponse, error) {
+	return s.findProduct(id, "Category", "Images")
+}
+
+// FindProduct is GetProduct without the category and images
+func (s *ProductService) FindProduct(id uint) (*dto.ProductResponse, error) {
+	return s.findProduct(id)
+}
+
+// GetProductsByIDs returns the products with the given IDs, including soft
+// deleted ones so order history still resolves. Relations are not loaded.
+func (s *ProductService) GetProductsByIDs(ids []uint) ([]dto.ProductResponse, error) {
+	var products []models.Product
+	if err := s.db.Unscoped().Where("id IN ?", ids).Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ProductResponse, len(products))
+	for i := range products {
+		response[i] = s.convertToProductResponse(&products[i])
+	}
+
+	return response, nil
+}
+
+func (s *ProductService) GetCategoriesByIDs(ids []uint) ([]dto.CategoryResponse, error) {
+	var categories []models.Category
+	if err := s.db.Unscoped().Where("id IN ?", ids).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.CategoryResponse, len(categories))
+	for i := range categories {
+		response[i] = convertToCategoryResponse(&categories[i])
+	}
+
+	return response, nil
+}
+
+// GetImagesByProductIDs returns the images of each product keyed by product ID
+func (s *ProductService) GetImagesByProductIDs(productIDs []uint) (map[uint][]dto.ProductImageResponse, error) {
+	var images []models.ProductImage
+	if err := s.db.Where("product_id IN ?", productIDs).Order("id").Find(&images).Error; err != nil {
+		return nil, err
+	}
+
+	response := make(map[uint][]dto.ProductImageResponse, len(productIDs))
+	for i := range images {
+		response[images[i].ProductID] = append(response[images[i].ProductID], convertToProductImageResponse(&images[i]))
+	}
+
+	return response, nil
+}
+
+func (s *ProductService) findProducts(req *dto.ProductListRequest, preloads ...string) ([]dto.ProductResponse, *utils.PaginationMeta, error) {
+	sort, ok := productSorts[req.Sort]
+	if !ok {
+		return nil, nil, apperror.InvalidInput(fmt.Sprintf("unknown sort: %s", req.Sort))
+	}
+
+	page, limit := req.Page, req.Limit
 	if page < 1 {
 		page = 1
 	}
@@ -115,10 +305,16 @@ func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *u
 	var products []models.Product
 	var total int64
 
-	s.db.Model(&models.Product{}).Where("is_active = ?", true).Count(&total)
+	if err := repository.FilterProducts(s.db.Model(&models.Product{}), &req.ProductFilter).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
 
-	if err := s.db.Preload("Category").Preload("Images").
-		Where("is_active = ?", true).
+	query := repository.FilterProducts(s.db, &req.ProductFilter)
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+
+	if err := sort.order(query, false).
 		Offset(offset).Limit(limit).
 		Find(&products).Error; err != nil {
 		return nil, nil, err
@@ -140,9 +336,53 @@ func (s *ProductService) GetProducts(page, limit int) ([]dto.ProductResponse, *u
 	return response, meta, nil
 }
 
-func (s *ProductService) GetProduct(id uint) (*dto.ProductResponse, error) {
+func (s *ProductService) findProductsByCursor(req *dto.ProductListRequest, pageReq *dto.CursorPageRequest, preloads ...string) ([]dto.ProductResponse, *utils.CursorMeta, error) {
+	sort, ok := productSorts[req.Sort]
+	if !ok {
+		return nil, nil, apperror.InvalidInput(fmt.Sprintf("unknown sort: %s", req.Sort))
+	}
+
+	var total int64
+	if err := repository.FilterProducts(s.db.Model(&models.Product{}), &req.ProductFilter).Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	query := repository.FilterProducts(s.db, &req.ProductFilter)
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+
+	query, page, err := sort.apply(query, pageReq)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var products []models.Product
+	if err := query.Find(&products).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.ProductResponse, len(products))
+	for i := range products {
+		response[i] = s.convertToProductResponse(&products[i])
+	}
+
+	response, meta := finishKeysetPage(page, response, func(product *dto.ProductResponse) string {
+		return ProductCursor(req.Sort, product)
+	})
+	meta.Total = total
+
+	return response, &meta, nil
+}
+
+func (s *ProductService) findProduct(id uint, preloads ...string) (*dto.ProductResponse, error) {
+	query := s.db
+	for _, preload := range preloads {
+		query = query.Preload(preload)
+	}
+
 	var product models.Product
-	if err := s.db.Preload("Category").Preload("Images").First(&product, id).Error; err != nil {
+	if err := query.First(&product, id).Error; err != nil {
 		return nil, err
 	}
 
@@ -150,11 +390,12 @@ func (s *ProductService) GetProduct(id uint) (*dto.ProductResponse, error) {
 	return &response, nil
 }
 
-func (s *ProductService) UpdateProduct(id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
+func (s *ProductService) UpdateProduct(ctx context.Context, id uint, req *dto.UpdateProductRequest) (*dto.ProductResponse, error) {
 	var product models.Product
-	if err := s.db.First(&product, id).Error; err != nil {
+	if err := s.db.Preload("Category").Preload("Images").First(&product, id).Error; err != nil {
 		return nil, err
 	}
+	before := s.convertToProductResponse(&product)
 
 	product.CategoryID = req.CategoryID
 	product.Name = req.Name
@@ -164,19 +405,52 @@ func (s *ProductService) UpdateProduct(id uint, req *dto.UpdateProductRequest) (
 	if req.IsActive != nil {
 		product.IsActive = *req.IsActive
 	}
+	if req.Attributes != nil {
+		product.Attributes = req.Attributes
+	}
+
+	if err := s.db.Omit(clause.Associations).Save(&product).Error; err != nil {
+		return nil, err
+	}
+	publishProductChange(s.pubSub, product.ID)
 
-	if err := s.db.Save(&product).Error; err != nil {
+	response, err := s.GetProduct(id)
+	if err != nil {
 		return nil, err
 	}
 
-	return s.GetProduct(id)
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionProductUpdate,
+		EntityType: models.AuditEntityProduct,
+		EntityID:   id,
+		Before:     before,
+		After:      response,
+	})
+
+	return response, nil
 }
 
-func (s *ProductService) DeleteProduct(id uint) error {
-	return s.db.Delete(&models.Product{}, id).Error
+func (s *ProductService) DeleteProduct(ctx context.Context, id uint) error {
+	var product models.Product
+	result := s.db.Clauses(clause.Returning{}).Delete(&product, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected > 0 {
+		publishProductChange(s.pubSub, id)
+		s.auditService.Record(ctx, AuditEntry{
+			Action:     models.AuditActionProductDelete,
+			EntityType: models.AuditEntityProduct,
+			EntityID:   id,
+			Before:     s.convertToProductResponse(&product),
+		})
+	}
+
+	return nil
 }
 
-func (s *ProductService) AddProductImage(productID uint, url, altText string) error {
+func (s *ProductService) AddProductImage(ctx context.Context, productID uint, url, altText string) error {
 
 	var count int64
 	s.db.Model(&models.ProductImage{}).Where("product_id = ?", productID).Count(&count)
@@ -188,11 +462,29 @@ func (s *ProductService) AddProductImage(productID uint, url, altText string) er
 		IsPrimary: count == 0,
 	}
 
-	return s.db.Create(&image).Error
-}
+	if err := s.db.Create(&image).Error; err != nil {
+		return err
+	}
 
-func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error) {
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionProductImageCreate,
+		EntityType: models.AuditEntityProduct,
+		EntityID:   productID,
+		After: dto.ProductImageResponse{
+			ID:        image.ID,
+			URL:       image.URL,
+			AltText:   image.AltText,
+			IsPrimary: image.IsPrimary,
+		},
+	})
 
+	return nil
+}
+
+// SearchProducts ranks the products matching req.Query, or the query with
+// synonyms swapped in, by relevance as judged by the search index. Products
+// pinned for the query come first.
+func (s *ProductService) SearchProducts(ctx context.Context, req *dto.SearchProductsRequest) ([]dto.ProductSearchResult, *utils.PaginationMeta, error) {
 	if req.Page < 1 {
 		req.Page = 1
 	}
@@ -201,55 +493,79 @@ func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.P
 		req.Limit = 10
 	}
 
-	offset := (req.Page - 1) * req.Limit
-
-	query := s.db.Model(&models.Product{}).
-		Select("products.*, ts_rank(search_vector, plainto_tsquery('english', ?)) as rank", req.Query).
-		Where("search_vector @@ plainto_tsquery('english', ?)", req.Query).
-		Where("is_active = ?", true)
-
-	if req.CategoryID != nil {
-		query = query.Where("category_id = ?", *req.CategoryID)
+	if req.Limit > maxSearchResults || req.Page > maxSearchResults/req.Limit {
+		return nil, nil, apperror.InvalidInput(fmt.Sprintf("only the first %d results can be paged through; refine the search", maxSearchResults))
 	}
 
-	if req.MinPrice != nil {
-		query = query.Where("price >= ?", *req.MinPrice)
+	alternatives, err := s.searchAlternatives(ctx, req.Query)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	if req.MaxPrice != nil {
-		query = query.Where("price <= ?", *req.MaxPrice)
+	pinned, err := s.pinnedProducts(ctx, req.Query, &req.ProductFilter)
+	if err != nil {
+		return nil, nil, err
 	}
 
-	// Count total results
-	var total int64
-	query.Count(&total)
+	// Pinned products take the first places and the index fills the rest
+	offset := (req.Page - 1) * req.Limit
+	pinnedPage := pinned[min(offset, len(pinned)):min(offset+req.Limit, len(pinned))]
+
+	hits, err := s.searchIndex.Search(ctx, &dto.SearchQuery{
+		Text:         req.Query,
+		Alternatives: alternatives,
+		Filter:       req.ProductFilter,
+		ExcludeIDs:   pinned,
+		Offset:       max(offset-len(pinned), 0),
+		Limit:        req.Limit - len(pinnedPage),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
 
-	// Execute query with ranking and create product slices
-	type productsWithRank struct {
-		models.Product
-		Rank float32 `gorm:"column:rank"`
+	ids := slices.Clone(pinnedPage)
+	for _, hit := range hits.Hits {
+		ids = append(ids, hit.ID)
 	}
-	var rows []productsWithRank
-	if err := query.
-		Order("rank DESC, created_at DESC"). // order by relevance
+
+	// The index may briefly lag behind, so products deactivated since they
+	// were indexed are left out
+	var products []models.Product
+	if err := s.db.WithContext(ctx).
 		Preload("Category").
 		Preload("Images").
-		Offset(offset).
-		Limit(req.Limit).
-		Find(&rows).Error; err != nil {
+		Where("id IN ? AND is_active = ?", ids, true).
+		Find(&products).Error; err != nil {
 		return nil, nil, err
 	}
 
-	// Build output response
-	results := make([]dto.ProductSearchResult, len(rows))
-	for i := range rows {
-		results[i] = dto.ProductSearchResult{
-			ProductResponse: s.convertToProductResponse(&rows[i].Product),
-			Rank:            rows[i].Rank,
+	byID := make(map[uint]*models.Product, len(products))
+	for i := range products {
+		byID[products[i].ID] = &products[i]
+	}
+
+	// Build output response in the order of the pins and hits
+	results := make([]dto.ProductSearchResult, 0, len(products))
+	for _, id := range pinnedPage {
+		if product, ok := byID[id]; ok {
+			results = append(results, dto.ProductSearchResult{
+				ProductResponse: s.convertToProductResponse(product),
+				Pinned:          true,
+			})
+		}
+	}
+
+	for _, hit := range hits.Hits {
+		if product, ok := byID[hit.ID]; ok {
+			results = append(results, dto.ProductSearchResult{
+				ProductResponse: s.convertToProductResponse(product),
+				Rank:            float32(hit.Score),
+			})
 		}
 	}
 
 	// build pagination meta
+	total := hits.Total + int64(len(pinned))
 	totalPages := int((total + int64(req.Limit) - 1) / int64(req.Limit))
 	meta := &utils.PaginationMeta{
 		Page:       req.Page,
@@ -261,16 +577,32 @@ func (s *ProductService) SearchProducts(req *dto.SearchProductsRequest) ([]dto.P
 	return results, meta, nil
 }
 
+// SearchFacets counts the products matching a search by category, price
+// range, stock and attribute value. Pinned products only count when they
+// match the query.
+func (s *ProductService) SearchFacets(ctx context.Context, req *dto.SearchProductsRequest) (*dto.SearchFacets, error) {
+	alternatives, err := s.searchAlternatives(ctx, req.Query)
+	if err != nil {
+		return nil, err
+	}
+
+	hits, err := s.searchIndex.Search(ctx, &dto.SearchQuery{
+		Text:         req.Query,
+		Alternatives: alternatives,
+		Filter:       req.ProductFilter,
+		Facets:       true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return hits.Facets, nil
+}
+
 func (s *ProductService) convertToProductResponse(product *models.Product) dto.ProductResponse {
 	images := make([]dto.ProductImageResponse, len(product.Images))
 	for i := range product.Images {
-		images[i] = dto.ProductImageResponse{
-			ID:        product.Images[i].ID,
-			URL:       product.Images[i].URL,
-			AltText:   product.Images[i].AltText,
-			IsPrimary: product.Images[i].IsPrimary,
-			CreatedAt: product.CreatedAt,
-		}
+		images[i] = convertToProductImageResponse(&product.Images[i])
 	}
 
 	return dto.ProductResponse{
@@ -282,8 +614,11 @@ func (s *ProductService) convertToProductResponse(product *models.Product) dto.P
 		Stock:       product.Stock,
 		SKU:         product.SKU,
 		IsActive:    product.IsActive,
+		Attributes:  product.Attributes,
+		SalesCount:  product.SalesCount,
 		Category: dto.CategoryResponse{
 			ID:          product.Category.ID,
+			ParentID:    product.Category.ParentID,
 			Name:        product.Category.Name,
 			Description: product.Category.Description,
 			IsActive:    product.Category.IsActive,
@@ -295,3 +630,25 @@ func (s *ProductService) convertToProductResponse(product *models.Product) dto.P
 		UpdatedAt: product.UpdatedAt,
 	}
 }
+
+func convertToCategoryResponse(category *models.Category) dto.CategoryResponse {
+	return dto.CategoryResponse{
+		ID:          category.ID,
+		ParentID:    category.ParentID,
+		Name:        category.Name,
+		Description: category.Description,
+		IsActive:    category.IsActive,
+		CreatedAt:   category.CreatedAt,
+		UpdatedAt:   category.UpdatedAt,
+	}
+}
+
+func convertToProductImageResponse(image *models.ProductImage) dto.ProductImageResponse {
+	return dto.ProductImageResponse{
+		ID:        image.ID,
+		URL:       image.URL,
+		AltText:   image.AltText,
+		IsPrimary: image.IsPrimary,
+		CreatedAt: image.CreatedAt,
+	}
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+)
+
+var _ RBACServiceInterface = (*RBACService)(nil)
+
+type RBACService struct {
+	roleRepo     repository.RoleRepositoryInterface
+	userRepo     repository.UserRepositoryInterface
+	auditService AuditServiceInterface
+}
+
+func NewRBACService(
+	roleRepo repository.RoleRepositoryInterface,
+	userRepo repository.UserRepositoryInterface,
+	auditService AuditServiceInterface) *RBACService {
+	return &RBACService{
+		roleRepo:     roleRepo,
+		userRepo:     userRepo,
+		auditService: auditService,
+	}
+}
+
+func (s *RBACService) GetPermissions(userID uint) ([]string, error) {
+	return s.roleRepo.GetPermissionNames(userID)
+}
+
+func (s *RBACService) HasPermission(userID uint, permission string) (bool, error) {
+	permissions, err := s.roleRepo.GetPermissionNames(userID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(permissions, permission), nil
+}
+
+func (s *RBACService) GetRoles() ([]dto.RoleResponse, error) {
+	roles, err := s.roleRepo.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToRoleResponses(roles), nil
+}
+
+func (s *RBACService) GetUserRoles(userID uint) ([]dto.RoleResponse, error) {
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	roles, err := s.roleRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertToRoleResponses(roles), nil
+}
+
+// AssignRole grants a role to the user. Like SetUserRoles, it refuses to
+// change the actor's own roles.
+func (s *RBACService) AssignRole(ctx context.Context, actorID, userID uint, req *dto.AssignRoleRequest) ([]dto.RoleResponse, error) {
+	if actorID == userID {
+		return nil, apperror.Forbidden("you cannot change your own roles")
+	}
+
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	role, err := s.roleRepo.GetByName(req.Role)
+	if err != nil {
+		return nil, apperror.NotFound("role not found")
+	}
+
+	before, err := s.roleRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.AssignToUser(userID, role.ID); err != nil {
+		return nil, err
+	}
+
+	return s.auditRoleChange(ctx, userID, before)
+}
+
+// RemoveRole revokes a role from the user. Like SetUserRoles, it refuses to
+// change the actor's own roles.
+func (s *RBACService) RemoveRole(ctx context.Context, actorID, userID uint, roleName string) ([]dto.RoleResponse, error) {
+	if actorID == userID {
+		return nil, apperror.Forbidden("you cannot change your own roles")
+	}
+
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	role, err := s.roleRepo.GetByName(roleName)
+	if err != nil {
+		return nil, apperror.NotFound("role not found")
+	}
+
+	before, err := s.roleRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.RemoveFromUser(userID, role.ID); err != nil {
+		return nil, err
+	}
+
+	return s.auditRoleChange(ctx, userID, before)
+}
+
+// SetUserRoles replaces the user's roles. Staff cannot change their own roles
+// so that nobody can escalate their own privileges.
+func (s *RBACService) SetUserRoles(ctx context.Context, actorID, userID uint, req *dto.SetUserRolesRequest) ([]dto.RoleResponse, error) {
+	if actorID == userID {
+		return nil, apperror.Forbidden("you cannot change your own roles")
+	}
+
+	if _, err := s.userRepo.GetByID(userID); err != nil {
+		return nil, apperror.NotFound("user not found")
+	}
+
+	roleIDs := make([]uint, 0, len(req.Roles))
+	for _, name := range req.Roles {
+		role, err := s.roleRepo.GetByName(name)
+		if err != nil {
+			return nil, apperror.InvalidInput(fmt.Sprintf("role %q not found", name))
+		}
+		if !slices.Contains(roleIDs, role.ID) {
+			roleIDs = append(roleIDs, role.ID)
+		}
+	}
+
+	before, err := s.roleRepo.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.roleRepo.ReplaceUserRoles(userID, roleIDs); err != nil {
+		return nil, err
+	}
+
+	return s.auditRoleChange(ctx, userID, before)
+}
+
+// auditRoleChange records the user's roles before and after a change and
+// returns the new roles
+func (s *RBACService) auditRoleChange(ctx context.Context, userID uint, before []models.Role) ([]dto.RoleResponse, error) {
+	roles, err := s.GetUserRoles(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	beforeNames := make([]string, len(before))
+	for i, role := range before {
+		beforeNames[i] = role.Name
+	}
+
+	afterNames := make([]string, len(roles))
+	for i, role := range roles {
+		afterNames[i] = role.Name
+	}
+
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionUserRolesChange,
+		EntityType: models.AuditEntityUser,
+		EntityID:   userID,
+		Before:     map[string]any{"roles": beforeNames},
+		After:      map[string]any{"roles": afterNames},
+	})
+
+	return roles, nil
+}
+
+func convertToRoleResponses(roles []models.Role) []dto.RoleResponse {
+	response := make([]dto.RoleResponse, len(roles))
+	for i, role := range roles {
+		permissions := make([]string, len(role.Permissions))
+		for j, permission := range role.Permissions {
+			permissions[j] = permission.Name
+		}
+
+		response[i] = dto.RoleResponse{
+			ID:          role.ID,
+			Name:        role.Name,
+			Description: role.Description,
+			Permissions: permissions,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
+)
+
+var _ SearchAnalyticsServiceInterface = (*SearchAnalyticsService)(nil)
+
+const (
+	// searchAggregationInterval is how often recent stats are recomputed, so
+	// reports lag behind by at most this long
+	searchAggregationInterval = time.Hour
+
+	// searchLogRetention is how long individual searches are kept once they
+	// have been rolled up into daily stats
+	searchLogRetention = 90 * 24 * time.Hour
+
+	maxSearchQueryLength = 200
+
+	defaultSearchReportDays  = 30
+	defaultSearchReportLimit = 20
+	maxSearchReportLimit     = 100
+)
+
+type SearchAnalyticsService struct {
+	searchAnalyticsRepo repository.SearchAnalyticsRepositoryInterface
+}
+
+func NewSearchAnalyticsService(searchAnalyticsRepo repository.SearchAnalyticsRepositoryInterface) *SearchAnalyticsService {
+	return &SearchAnalyticsService{
+		searchAnalyticsRepo: searchAnalyticsRepo,
+	}
+}
+
+// RecordSearch logs a search by the user in ctx, if any, with the products on
+// its first page and returns its ID so clicks and cart additions can be
+// attributed to it. Failures are logged and return 0 so analytics never fail
+// the search itself.
+func (s *SearchAnalyticsService) RecordSearch(ctx context.Context, query string, resultCount int64, productIDs []uint) uint {
+	search := &models.SearchQuery{
+		Query:       normalizeSearchQuery(query),
+		ResultCount: resultCount,
+	}
+	if userID, ok := ctx.Value(utils.UserIDKey).(uint); ok && userID != 0 {
+		search.UserID = &userID
+	}
+
+	if err := s.searchAnalyticsRepo.CreateQuery(search, productIDs); err != nil {
+		log.Printf("Failed to record search: %v", err)
+		return 0
+	}
+
+	return search.ID
+}
+
+// RecordResults adds the products on a later page of a logged search to its
+// results, so they can be clicked too. It returns searchID, or 0 when no
+// search with that ID was made for the query.
+func (s *SearchAnalyticsService) RecordResults(searchID uint, query string, productIDs []uint) uint {
+	search, err := s.searchAnalyticsRepo.GetQuery(searchID)
+	if err != nil || search.Query != normalizeSearchQuery(query) {
+		return 0
+	}
+
+	if err := s.searchAnalyticsRepo.AddResults(searchID, productIDs); err != nil {
+		log.Printf("Failed to record search results: %v", err)
+		return 0
+	}
+
+	return searchID
+}
+
+// RecordEvent logs a click or cart addition of a product returned by a search
+func (s *SearchAnalyticsService) RecordEvent(searchID, productID uint, eventType string) error {
+	found, err := s.searchAnalyticsRepo.CreateEvent(searchID, productID, eventType)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return apperror.NotFound("search not found or did not return the product")
+	}
+
+	return nil
+}
+
+// Run keeps the daily stats up to date and removes expired searches until
+// ctx is done
+func (s *SearchAnalyticsService) Run(ctx context.Context) {
+	ticker := time.NewTicker(searchAggregationInterval)
+	defer ticker.Stop()
+
+	for {
+		s.aggregate(time.Now())
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// aggregate computes the daily stats of every day from the last one stored up
+// to today, so days missed while the service was not running are caught up
+// before their searches expire. The day before the last stored one is
+// recomputed too, so events on searches made just before midnight are still
+// counted.
+func (s *SearchAnalyticsService) aggregate(now time.Time) {
+	today := startOfDay(now)
+	for day := s.firstDayToAggregate(today); !day.After(today); day = day.AddDate(0, 0, 1) {
+		if err := s.searchAnalyticsRepo.Aggregate(day); err != nil {
+			log.Printf("Failed to aggregate searches for %s: %v", day.Format(time.DateOnly), err)
+			return
+		}
+	}
+
+	if _, err := s.searchAnalyticsRepo.DeleteQueriesBefore(today.Add(-searchLogRetention)); err != nil {
+		log.Printf("Failed to delete expired searches: %v", err)
+	}
+}
+
+// firstDayToAggregate returns the day before the last aggregated one, or the
+// day of the oldest logged search when nothing has been aggregated yet. It is
+// never later than yesterday.
+func (s *SearchAnalyticsService) firstDayToAggregate(today time.Time) time.Time {
+	yesterday := today.AddDate(0, 0, -1)
+
+	last, err := s.searchAnalyticsRepo.LastAggregatedDay()
+	if err != nil {
+		log.Printf("Failed to find the last aggregated day: %v", err)
+		return yesterday
+	}
+
+	from := yesterday
+	if last != nil {
+		from = startOfDay(*last).AddDate(0, 0, -1)
+	} else {
+		first, err := s.searchAnalyticsRepo.FirstQueryTime()
+		if err != nil {
+			log.Printf("Failed to find the oldest search: %v", err)
+			return yesterday
+		}
+
+		if first != nil {
+			from = startOfDay(*first)
+		}
+	}
+
+	if from.After(yesterday) {
+		return yesterday
+	}
+
+	return from
+}
+
+func (s *SearchAnalyticsService) GetSearchReport(req *dto.SearchReportRequest) (*dto.SearchReport, error) {
+	to := startOfDay(time.Now())
+	if req.To != nil {
+		to = startOfDay(*req.To)
+	}
+
+	from := to.AddDate(0, 0, 1-defaultSearchReportDays)
+	if req.From != nil {
+		from = startOfDay(*req.From)
+	}
+
+	if from.After(to) {
+		return nil, apperror.InvalidInput("from must not be after to")
+	}
+
+	limit := req.Limit
+	if limit < 1 {
+		limit = defaultSearchReportLimit
+	}
+
+	if limit > maxSearchReportLimit {
+		limit = maxSearchReportLimit
+	}
+
+	totals, err := s.searchAnalyticsRepo.SumStats(from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	topQueries, err := s.searchAnalyticsRepo.TopQueries(from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	zeroResultQueries, err := s.searchAnalyticsRepo.ZeroResultQueries(from, to, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.SearchReport{
+		From:              from,
+		To:                to,
+		Totals:            toSearchStats(totals),
+		TopQueries:        toSearchQueryStats(topQueries),
+		ZeroResultQueries: toSearchQueryStats(zeroResultQueries),
+	}, nil
+}
+
+// normalizeSearchQuery lowercases a query and collapses its whitespace so
+// equivalent searches are counted together
+func normalizeSearchQuery(query string) string {
+	normalized := strings.Join(strings.Fields(strings.ToLower(query)), " ")
+	if runes := []rune(normalized); len(runes) > maxSearchQueryLength {
+		normalized = string(runes[:maxSearchQueryLength])
+	}
+
+	return normalized
+}
+
+// startOfDay returns the start of the UTC day containing t
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func toSearchStats(stats *models.SearchQueryDailyStats) dto.SearchStats {
+	result := dto.SearchStats{
+		Searches:           stats.Searches,
+		ZeroResultSearches: stats.ZeroResultSearches,
+		ClickedSearches:    stats.ClickedSearches,
+		AddToCartSearches:  stats.AddToCartSearches,
+	}
+
+	if stats.Searches > 0 {
+		result.ClickThroughRate = float64(stats.ClickedSearches) / float64(stats.Searches)
+		result.AddToCartRate = float64(stats.AddToCartSearches) / float64(stats.Searches)
+	}
+
+	return result
+}
+
+func toSearchQueryStats(stats []models.SearchQueryDailyStats) []dto.SearchQueryStats {
+	result := make([]dto.SearchQueryStats, len(stats))
+	for i := range stats {
+		result[i] = dto.SearchQueryStats{
+			Query:       stats[i].Query,
+			SearchStats: toSearchStats(&stats[i]),
+		}
+	}
+
+	return result
+}
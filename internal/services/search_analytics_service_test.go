@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+)
+
+// fakeSearchAnalyticsRepository records the days aggregated and the cutoff
+// of deleted searches
+type fakeSearchAnalyticsRepository struct {
+	repository.SearchAnalyticsRepositoryInterface
+
+	lastAggregated *time.Time
+	firstQuery     *time.Time
+	aggregated     []string
+	deletedBefore  time.Time
+}
+
+func (r *fakeSearchAnalyticsRepository) Aggregate(day time.Time) error {
+	r.aggregated = append(r.aggregated, day.Format(time.DateOnly))
+	return nil
+}
+
+func (r *fakeSearchAnalyticsRepository) LastAggregatedDay() (*time.Time, error) {
+	return r.lastAggregated, nil
+}
+
+func (r *fakeSearchAnalyticsRepository) FirstQueryTime() (*time.Time, error) {
+	return r.firstQuery, nil
+}
+
+func (r *fakeSearchAnalyticsRepository) DeleteQueriesBefore(before time.Time) (int64, error) {
+	r.deletedBefore = before
+	return 0, nil
+}
+
+func TestSearchAnalyticsServiceAggregate(t *testing.T) {
+	now := time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC)
+	day := func(d int) *time.Time {
+		t := time.Date(2024, 3, d, 0, 0, 0, 0, time.UTC)
+		return &t
+	}
+	queryTime := func(d int) *time.Time {
+		t := time.Date(2024, 3, d, 18, 45, 0, 0, time.UTC)
+		return &t
+	}
+
+	tests := []struct {
+		name           string
+		lastAggregated *time.Time
+		firstQuery     *time.Time
+		want           []string
+	}{
+		{
+			name:           "running hourly",
+			lastAggregated: day(10),
+			want:           []string{"2024-03-09", "2024-03-10"},
+		},
+		{
+			name:           "first run of the day",
+			lastAggregated: day(9),
+			want:           []string{"2024-03-08", "2024-03-09", "2024-03-10"},
+		},
+		{
+			name:           "catching up after downtime",
+			lastAggregated: day(5),
+			want:           []string{"2024-03-04", "2024-03-05", "2024-03-06", "2024-03-07", "2024-03-08", "2024-03-09", "2024-03-10"},
+		},
+		{
+			name:       "nothing aggregated yet",
+			firstQuery: queryTime(7),
+			want:       []string{"2024-03-07", "2024-03-08", "2024-03-09", "2024-03-10"},
+		},
+		{
+			name:       "first search today",
+			firstQuery: queryTime(10),
+			want:       []string{"2024-03-09", "2024-03-10"},
+		},
+		{
+			name: "no searches",
+			want: []string{"2024-03-09", "2024-03-10"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSearchAnalyticsRepository{lastAggregated: tt.lastAggregated, firstQuery: tt.firstQuery}
+			NewSearchAnalyticsService(repo).aggregate(now)
+
+			if !slices.Equal(repo.aggregated, tt.want) {
+				t.Errorf("aggregated %v, want %v", repo.aggregated, tt.want)
+			}
+
+			if want := day(10).Add(-searchLogRetention); !repo.deletedBefore.Equal(want) {
+				t.Errorf("deleted searches before %v, want %v", repo.deletedBefore, want)
+			}
+		})
+	}
+}
+
+func TestSearchAnalyticsServiceRecordEvent(t *testing.T) {
+	db := openTestDB(t, &models.SearchQuery{}, &models.SearchResult{}, &models.SearchEvent{})
+	service := NewSearchAnalyticsService(repository.NewSearchAnalyticsRepository(db))
+
+	ctx := context.Background()
+	searchID := service.RecordSearch(ctx, "Running  Shoes", 30, []uint{1, 2})
+	if searchID == 0 {
+		t.Fatal("search not recorded")
+	}
+
+	if got := service.RecordResults(searchID, "running shoes", []uint{3}); got != searchID {
+		t.Errorf("later page recorded for search %d, want %d", got, searchID)
+	}
+
+	// A later page must repeat the query of the search it claims to be from
+	if got := service.RecordResults(searchID, "hats", []uint{4}); got != 0 {
+		t.Errorf("page of another query recorded for search %d", got)
+	}
+
+	tests := []struct {
+		name      string
+		searchID  uint
+		productID uint
+		wantErr   bool
+	}{
+		{name: "product on the first page", searchID: searchID, productID: 2},
+		{name: "product on a later page", searchID: searchID, productID: 3},
+		{name: "product the search did not return", searchID: searchID, productID: 4, wantErr: true},
+		{name: "unknown search", searchID: searchID + 1, productID: 1, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.RecordEvent(tt.searchID, tt.productID, models.SearchEventClick)
+			if !tt.wantErr {
+				if err != nil {
+					t.Errorf("RecordEvent: %v", err)
+				}
+				return
+			}
+
+			var appErr *apperror.Error
+			if !errors.As(err, &appErr) || appErr.Code != apperror.CodeNotFound {
+				t.Errorf("err = %v, want not found", err)
+			}
+		})
+	}
+
+	var events int64
+	db.Model(&models.SearchEvent{}).Count(&events)
+	if events != 2 {
+		t.Errorf("%d events recorded, want 2", events)
+	}
+}
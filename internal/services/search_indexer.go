@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"log"
+	"strconv"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/repository"
+	"gorm.io/gorm"
+)
+
+const indexBatchSize = 500
+
+// categoryAncestorsSQL pairs each of the given categories with itself and
+// each of its ancestors
+const categoryAncestorsSQL = `WITH RECURSIVE ancestors AS (
+	SELECT id AS category_id, id AS ancestor_id, parent_id FROM categories WHERE id IN ? AND deleted_at IS NULL
+	UNION
+	SELECT a.category_id, c.id, c.parent_id FROM categories c JOIN ancestors a ON c.id = a.parent_id WHERE c.deleted_at IS NULL
+) SELECT category_id, ancestor_id FROM ancestors`
+
+// SearchIndexer keeps a search index in sync with the products table. It
+// rebuilds the index when started, then applies the changes announced on the
+// products and categories topics. Pub/sub delivery is best effort, so an
+// index that missed changes is repaired by restarting the indexer.
+type SearchIndexer struct {
+	db     *gorm.DB
+	index  interfaces.SearchIndex
+	pubSub interfaces.PubSub
+}
+
+func NewSearchIndexer(db *gorm.DB, index interfaces.SearchIndex, pubSub interfaces.PubSub) *SearchIndexer {
+	return &SearchIndexer{
+		db:     db,
+		index:  index,
+		pubSub: pubSub,
+	}
+}
+
+// Run rebuilds the index and then keeps it in sync until ctx is done
+func (s *SearchIndexer) Run(ctx context.Context) error {
+	// Subscribe before rebuilding so changes made meanwhile are not missed
+	products, err := s.pubSub.Subscribe(ctx, productsTopic)
+	if err != nil {
+		return err
+	}
+
+	categories, err := s.pubSub.Subscribe(ctx, categoriesTopic)
+	if err != nil {
+		return err
+	}
+
+	if err := s.Reindex(ctx); err != nil {
+		log.Printf("Failed to rebuild search index: %v", err)
+	}
+
+	for {
+		select {
+		case payload, ok := <-products:
+			if !ok {
+				return nil
+			}
+
+			if id, err := strconv.ParseUint(string(payload), 10, 32); err == nil {
+				s.logFailure(s.sync(ctx, []uint{uint(id)}))
+			}
+
+		case payload, ok := <-categories:
+			if !ok {
+				return nil
+			}
+
+			if id, err := strconv.ParseUint(string(payload), 10, 32); err == nil {
+				s.logFailure(s.syncCategory(ctx, uint(id)))
+			}
+		}
+	}
+}
+
+// Reindex indexes every active product and removes the documents of
+// products that were deleted or deactivated while the indexer was not
+// running, or whose change notification was lost
+func (s *SearchIndexer) Reindex(ctx context.Context) error {
+	// Listed first, so documents added meanwhile by sync are not considered
+	indexed, err := s.index.IDs(ctx)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[uint]bool)
+	var products []models.Product
+	if err := s.db.WithContext(ctx).
+		Where("is_active = ?", true).
+		FindInBatches(&products, indexBatchSize, func(tx *gorm.DB, batch int) error {
+			documents, err := s.documents(ctx, products)
+			if err != nil {
+				return err
+			}
+
+			for _, product := range products {
+				active[product.ID] = true
+			}
+
+			return s.index.Index(ctx, documents)
+		}).Error; err != nil {
+		return err
+	}
+
+	var stale []uint
+	for _, id := range indexed {
+		if !active[id] {
+			stale = append(stale, id)
+		}
+	}
+
+	// sync checks the products again, in case one was reactivated since
+	for start := 0; start < len(stale); start += indexBatchSize {
+		if err := s.sync(ctx, stale[start:min(start+indexBatchSize, len(stale))]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// sync indexes the given products again, removing the ones that are no
+// longer active
+func (s *SearchIndexer) sync(ctx context.Context, ids []uint) error {
+	var products []models.Product
+	if err := s.db.WithContext(ctx).
+		Where("id IN ? AND is_active = ?", ids, true).
+		Find(&products).Error; err != nil {
+		return err
+	}
+
+	documents, err := s.documents(ctx, products)
+	if err != nil {
+		return err
+	}
+
+	if len(documents) > 0 {
+		if err := s.index.Index(ctx, documents); err != nil {
+			return err
+		}
+	}
+
+	active := make(map[uint]bool, len(products))
+	for _, product := range products {
+		active[product.ID] = true
+	}
+
+	var removed []uint
+	for _, id := range ids {
+		if !active[id] {
+			removed = append(removed, id)
+		}
+	}
+
+	if len(removed) == 0 {
+		return nil
+	}
+
+	return s.index.Delete(ctx, removed)
+}
+
+// syncCategory indexes the products of a category and its subcategories
+// again, since their documents include category names and ancestors
+func (s *SearchIndexer) syncCategory(ctx context.Context, categoryID uint) error {
+	var ids []uint
+	if err := s.db.WithContext(ctx).
+		Model(&models.Product{}).
+		Where("category_id = ? OR category_id IN ("+repository.CategorySubtreeSQL+")", categoryID, categoryID).
+		Pluck("id", &ids).Error; err != nil {
+		return err
+	}
+
+	for start := 0; start < len(ids); start += indexBatchSize {
+		if err := s.sync(ctx, ids[start:min(start+indexBatchSize, len(ids))]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// documents builds the search documents of products, looking up the
+// ancestors of their categories
+func (s *SearchIndexer) documents(ctx context.Context, products []models.Product) ([]dto.SearchDocument, error) {
+	if len(products) == 0 {
+		return nil, nil
+	}
+
+	categoryIDs := make([]uint, 0, len(products))
+	for _, product := range products {
+		categoryIDs = append(categoryIDs, product.CategoryID)
+	}
+
+	var rows []struct {
+		CategoryID uint
+		AncestorID uint
+	}
+	if err := s.db.WithContext(ctx).Raw(categoryAncestorsSQL, categoryIDs).Scan(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	ancestors := make(map[uint][]uint)
+	for _, row := range rows {
+		ancestors[row.CategoryID] = append(ancestors[row.CategoryID], row.AncestorID)
+	}
+
+	var categories []models.Category
+	if err := s.db.WithContext(ctx).Where("id IN ?", categoryIDs).Find(&categories).Error; err != nil {
+		return nil, err
+	}
+
+	names := make(map[uint]string, len(categories))
+	for _, category := range categories {
+		names[category.ID] = category.Name
+	}
+
+	documents := make([]dto.SearchDocument, len(products))
+	for i, product := range products {
+		documents[i] = dto.SearchDocument{
+			ID:           product.ID,
+			Name:         product.Name,
+			Description:  product.Description,
+			SKU:          product.SKU,
+			CategoryID:   product.CategoryID,
+			CategoryIDs:  ancestors[product.CategoryID],
+			CategoryName: names[product.CategoryID],
+			Price:        product.Price,
+			InStock:      product.Stock > 0,
+			Attributes:   product.Attributes,
+			Boost:        product.SearchBoost,
+			CreatedAt:    product.CreatedAt,
+		}
+	}
+
+	return documents, nil
+}
+
+func (s *SearchIndexer) logFailure(err error) {
+	if err != nil {
+		log.Printf("Failed to update search index: %v", err)
+	}
+}
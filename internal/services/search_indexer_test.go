@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/providers"
+	"gorm.io/gorm"
+)
+
+// newTestSearchIndexer returns an indexer over an in-memory database holding
+// the categories Clothing > Shoes > Trainers and Books
+func newTestSearchIndexer(t *testing.T) (*SearchIndexer, *gorm.DB, *providers.MemorySearchIndex) {
+	t.Helper()
+
+	db := openTestDB(t, &models.Category{}, &models.Product{})
+
+	clothing, shoes := uint(1), uint(2)
+	categories := []models.Category{
+		{ID: clothing, Name: "Clothing"},
+		{ID: shoes, ParentID: &clothing, Name: "Shoes"},
+		{ID: 3, ParentID: &shoes, Name: "Trainers"},
+		{ID: 4, Name: "Books"},
+	}
+	if err := db.Create(&categories).Error; err != nil {
+		t.Fatalf("create categories: %v", err)
+	}
+
+	index := providers.NewMemorySearchIndex()
+	return NewSearchIndexer(db, index, nil), db, index
+}
+
+func createTestProducts(t *testing.T, db *gorm.DB, products ...models.Product) {
+	t.Helper()
+
+	for _, product := range products {
+		active := product.IsActive
+		if product.Attributes == nil {
+			product.Attributes = map[string]string{}
+		}
+		if err := db.Create(&product).Error; err != nil {
+			t.Fatalf("create product %d: %v", product.ID, err)
+		}
+
+		// Create skips false in favour of the column default
+		if !active {
+			if err := db.Model(&product).Update("is_active", false).Error; err != nil {
+				t.Fatalf("deactivate product %d: %v", product.ID, err)
+			}
+		}
+	}
+}
+
+func indexedIDs(t *testing.T, index *providers.MemorySearchIndex) []uint {
+	t.Helper()
+
+	ids, err := index.IDs(context.Background())
+	if err != nil {
+		t.Fatalf("IDs: %v", err)
+	}
+
+	slices.Sort(ids)
+	return ids
+}
+
+func TestSearchIndexerSync(t *testing.T) {
+	tests := []struct {
+		name     string
+		products []models.Product
+		indexed  []dto.SearchDocument
+		sync     []uint
+		want     []uint
+	}{
+		{
+			name: "indexes active products",
+			products: []models.Product{
+				{ID: 1, CategoryID: 3, Name: "Runner", SKU: "RUN-1", Price: 80, IsActive: true},
+				{ID: 2, CategoryID: 4, Name: "Novel", SKU: "BOOK-1", Price: 15, IsActive: true},
+			},
+			sync: []uint{1, 2},
+			want: []uint{1, 2},
+		},
+		{
+			name: "only indexes the given products",
+			products: []models.Product{
+				{ID: 1, CategoryID: 3, Name: "Runner", SKU: "RUN-1", Price: 80, IsActive: true},
+				{ID: 2, CategoryID: 4, Name: "Novel", SKU: "BOOK-1", Price: 15, IsActive: true},
+			},
+			sync: []uint{2},
+			want: []uint{2},
+		},
+		{
+			name: "removes inactive products",
+			products: []models.Product{
+				{ID: 1, CategoryID: 3, Name: "Runner", SKU: "RUN-1", Price: 80, IsActive: false},
+				{ID: 2, CategoryID: 4, Name: "Novel", SKU: "BOOK-1", Price: 15, IsActive: true},
+			},
+			indexed: []dto.SearchDocument{{ID: 1}, {ID: 2}},
+			sync:    []uint{1},
+			want:    []uint{2},
+		},
+		{
+			name:    "removes deleted products",
+			indexed: []dto.SearchDocument{{ID: 1}, {ID: 2}},
+			sync:    []uint{1, 2},
+			want:    []uint{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer, db, index := newTestSearchIndexer(t)
+			createTestProducts(t, db, tt.products...)
+
+			ctx := context.Background()
+			if err := index.Index(ctx, tt.indexed); err != nil {
+				t.Fatalf("Index: %v", err)
+			}
+
+			if err := indexer.sync(ctx, tt.sync); err != nil {
+				t.Fatalf("sync: %v", err)
+			}
+
+			if ids := indexedIDs(t, index); !slices.Equal(ids, tt.want) {
+				t.Errorf("indexed = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchIndexerDocuments(t *testing.T) {
+	indexer, db, index := newTestSearchIndexer(t)
+	createTestProducts(t, db, models.Product{
+		ID: 1, CategoryID: 3, Name: "Runner", Description: "Light trainer", SKU: "RUN-1",
+		Price: 80, Stock: 5, IsActive: true, Attributes: map[string]string{"size": "42"}, SearchBoost: 1,
+	})
+
+	ctx := context.Background()
+	if err := indexer.sync(ctx, []uint{1}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	category := uint(1)
+	hits, err := index.Search(ctx, &dto.SearchQuery{
+		Text:   "trainers",
+		Filter: dto.ProductFilter{CategoryID: &category, InStock: true, Attributes: dto.Attributes{"size": "42"}},
+		Limit:  10,
+	})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	if len(hits.Hits) != 1 || hits.Hits[0].ID != 1 {
+		t.Errorf("hits = %+v, want product 1 found by category name, ancestor, stock and attribute", hits.Hits)
+	}
+}
+
+func TestSearchIndexerSyncCategory(t *testing.T) {
+	tests := []struct {
+		name     string
+		category uint
+		want     []uint
+	}{
+		{name: "category and descendants", category: 1, want: []uint{1, 2}},
+		{name: "leaf category", category: 3, want: []uint{2}},
+		{name: "unrelated category", category: 4, want: []uint{3, 4}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			indexer, db, index := newTestSearchIndexer(t)
+			createTestProducts(t, db,
+				models.Product{ID: 1, CategoryID: 2, Name: "Boot", SKU: "BOOT-1", Price: 90, IsActive: true},
+				models.Product{ID: 2, CategoryID: 3, Name: "Runner", SKU: "RUN-1", Price: 80, IsActive: true},
+				models.Product{ID: 3, CategoryID: 3, Name: "Old Runner", SKU: "RUN-0", Price: 60, IsActive: false},
+				models.Product{ID: 4, CategoryID: 4, Name: "Novel", SKU: "BOOK-1", Price: 15, IsActive: true},
+			)
+
+			// The inactive product's stale document goes when its category
+			// is synced
+			ctx := context.Background()
+			if err := index.Index(ctx, []dto.SearchDocument{{ID: 3}}); err != nil {
+				t.Fatalf("Index: %v", err)
+			}
+
+			if err := indexer.syncCategory(ctx, tt.category); err != nil {
+				t.Fatalf("syncCategory: %v", err)
+			}
+
+			if ids := indexedIDs(t, index); !slices.Equal(ids, tt.want) {
+				t.Errorf("indexed = %v, want %v", ids, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchIndexerSyncCategoryRename(t *testing.T) {
+	indexer, db, index := newTestSearchIndexer(t)
+	createTestProducts(t, db, models.Product{ID: 1, CategoryID: 3, Name: "Runner", SKU: "RUN-1", Price: 80, IsActive: true})
+
+	ctx := context.Background()
+	if err := indexer.sync(ctx, []uint{1}); err != nil {
+		t.Fatalf("sync: %v", err)
+	}
+
+	if err := db.Model(&models.Category{ID: 3}).Update("name", "Sneakers").Error; err != nil {
+		t.Fatalf("rename category: %v", err)
+	}
+
+	if err := indexer.syncCategory(ctx, 3); err != nil {
+		t.Fatalf("syncCategory: %v", err)
+	}
+
+	hits, err := index.Search(ctx, &dto.SearchQuery{Text: "sneakers", Limit: 10})
+	if err != nil {
+		t.Fatalf("Search: %v", err)
+	}
+
+	if hits.Total != 1 {
+		t.Errorf("total = %d, want the product found by its new category name", hits.Total)
+	}
+}
+
+func TestSearchIndexerReindex(t *testing.T) {
+	indexer, db, index := newTestSearchIndexer(t)
+	createTestProducts(t, db,
+		models.Product{ID: 1, CategoryID: 3, Name: "Runner", SKU: "RUN-1", Price: 80, IsActive: true},
+		models.Product{ID: 2, CategoryID: 3, Name: "Old Runner", SKU: "RUN-0", Price: 60, IsActive: false},
+		models.Product{ID: 3, CategoryID: 4, Name: "Novel", SKU: "BOOK-1", Price: 15, IsActive: true},
+	)
+
+	// Left behind by a product that was deactivated or deleted while the
+	// indexer was not running
+	ctx := context.Background()
+	if err := index.Index(ctx, []dto.SearchDocument{{ID: 2}, {ID: 9}}); err != nil {
+		t.Fatalf("Index: %v", err)
+	}
+
+	if err := indexer.Reindex(ctx); err != nil {
+		t.Fatalf("Reindex: %v", err)
+	}
+
+	if ids, want := indexedIDs(t, index), []uint{1, 3}; !slices.Equal(ids, want) {
+		t.Errorf("indexed = %v, want %v", ids, want)
+	}
+}
@@ -0,0 +1,289 @@
+package services
+
+import (
+	"context"
+	"slices"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxSearchBoost bounds product boosts, matching the check on
+// products.search_boost
+const maxSearchBoost = 10
+
+var _ SearchTuningServiceInterface = (*SearchTuningService)(nil)
+
+// SearchTuningService manages the synonyms, product boosts and pinned
+// results that adjust product search
+type SearchTuningService struct {
+	db           *gorm.DB
+	pubSub       interfaces.PubSub
+	auditService AuditServiceInterface
+}
+
+func NewSearchTuningService(db *gorm.DB, pubSub interfaces.PubSub, auditService AuditServiceInterface) *SearchTuningService {
+	return &SearchTuningService{
+		db:           db,
+		pubSub:       pubSub,
+		auditService: auditService,
+	}
+}
+
+func (s *SearchTuningService) ListSynonymSets() ([]dto.SynonymSetResponse, error) {
+	var sets []models.SearchSynonymSet
+	if err := s.db.Order("id").Find(&sets).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SynonymSetResponse, len(sets))
+	for i := range sets {
+		response[i] = convertToSynonymSetResponse(&sets[i])
+	}
+
+	return response, nil
+}
+
+func (s *SearchTuningService) CreateSynonymSet(ctx context.Context, req *dto.SynonymSetRequest) (*dto.SynonymSetResponse, error) {
+	terms, err := synonymTerms(req.Terms)
+	if err != nil {
+		return nil, err
+	}
+
+	set := models.SearchSynonymSet{Terms: terms}
+	if err := s.db.Create(&set).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToSynonymSetResponse(&set)
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionSynonymSetCreate,
+		EntityType: models.AuditEntitySynonymSet,
+		EntityID:   set.ID,
+		After:      response,
+	})
+
+	return &response, nil
+}
+
+func (s *SearchTuningService) UpdateSynonymSet(ctx context.Context, id uint, req *dto.SynonymSetRequest) (*dto.SynonymSetResponse, error) {
+	terms, err := synonymTerms(req.Terms)
+	if err != nil {
+		return nil, err
+	}
+
+	var set models.SearchSynonymSet
+	if err := s.db.First(&set, id).Error; err != nil {
+		return nil, err
+	}
+	before := convertToSynonymSetResponse(&set)
+
+	set.Terms = terms
+	if err := s.db.Save(&set).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToSynonymSetResponse(&set)
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionSynonymSetUpdate,
+		EntityType: models.AuditEntitySynonymSet,
+		EntityID:   id,
+		Before:     before,
+		After:      response,
+	})
+
+	return &response, nil
+}
+
+func (s *SearchTuningService) DeleteSynonymSet(ctx context.Context, id uint) error {
+	var set models.SearchSynonymSet
+	result := s.db.Clauses(clause.Returning{}).Delete(&set, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return apperror.NotFound("synonym set not found")
+	}
+
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionSynonymSetDelete,
+		EntityType: models.AuditEntitySynonymSet,
+		EntityID:   id,
+		Before:     convertToSynonymSetResponse(&set),
+	})
+
+	return nil
+}
+
+// ListProductBoosts returns the products whose search ranking is adjusted,
+// most boosted first
+func (s *SearchTuningService) ListProductBoosts() ([]dto.ProductBoostResponse, error) {
+	var products []models.Product
+	if err := s.db.Where("search_boost <> 0").Order("search_boost DESC, id").Find(&products).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.ProductBoostResponse, len(products))
+	for i := range products {
+		response[i] = convertToProductBoostResponse(&products[i])
+	}
+
+	return response, nil
+}
+
+// SetProductBoost changes how a product ranks in searches. External search
+// indexes pick the change up from the products topic.
+func (s *SearchTuningService) SetProductBoost(ctx context.Context, productID uint, req *dto.ProductBoostRequest) (*dto.ProductBoostResponse, error) {
+	if req.Boost == nil || *req.Boost < -maxSearchBoost || *req.Boost > maxSearchBoost {
+		return nil, apperror.InvalidInput("boost must be between -10 and 10")
+	}
+
+	var product models.Product
+	if err := s.db.First(&product, productID).Error; err != nil {
+		return nil, err
+	}
+	before := product.SearchBoost
+
+	product.SearchBoost = *req.Boost
+	if err := s.db.Model(&product).Update("search_boost", product.SearchBoost).Error; err != nil {
+		return nil, err
+	}
+	publishProductChange(s.pubSub, product.ID)
+
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionProductSearchBoost,
+		EntityType: models.AuditEntityProduct,
+		EntityID:   product.ID,
+		Before:     map[string]any{"search_boost": before},
+		After:      map[string]any{"search_boost": product.SearchBoost},
+	})
+
+	response := convertToProductBoostResponse(&product)
+	return &response, nil
+}
+
+// ListSearchPins returns the pins for a query, or every pin when it is
+// empty, grouped by query in the order they apply
+func (s *SearchTuningService) ListSearchPins(req *dto.SearchPinListRequest) ([]dto.SearchPinResponse, error) {
+	query := s.db.Preload("Product")
+	if req.Query != "" {
+		query = query.Where("query = ?", normalizeSearchQuery(req.Query))
+	}
+
+	var pins []models.SearchPin
+	if err := query.Order("query, position, id").Find(&pins).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.SearchPinResponse, len(pins))
+	for i := range pins {
+		response[i] = convertToSearchPinResponse(&pins[i])
+	}
+
+	return response, nil
+}
+
+func (s *SearchTuningService) CreateSearchPin(ctx context.Context, req *dto.SearchPinRequest) (*dto.SearchPinResponse, error) {
+	query := normalizeSearchQuery(req.Query)
+	if query == "" {
+		return nil, apperror.InvalidInput("query must not be blank")
+	}
+
+	var product models.Product
+	if err := s.db.First(&product, req.ProductID).Error; err != nil {
+		return nil, err
+	}
+
+	pin := models.SearchPin{
+		Query:     query,
+		ProductID: product.ID,
+		Position:  req.Position,
+		Product:   product,
+	}
+	if err := s.db.Omit(clause.Associations).Create(&pin).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToSearchPinResponse(&pin)
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionSearchPinCreate,
+		EntityType: models.AuditEntitySearchPin,
+		EntityID:   pin.ID,
+		After:      response,
+	})
+
+	return &response, nil
+}
+
+func (s *SearchTuningService) DeleteSearchPin(ctx context.Context, id uint) error {
+	var pin models.SearchPin
+	result := s.db.Clauses(clause.Returning{}).Delete(&pin, id)
+	if result.Error != nil {
+		return result.Error
+	}
+
+	if result.RowsAffected == 0 {
+		return apperror.NotFound("search pin not found")
+	}
+
+	s.auditService.Record(ctx, AuditEntry{
+		Action:     models.AuditActionSearchPinDelete,
+		EntityType: models.AuditEntitySearchPin,
+		EntityID:   id,
+		Before:     convertToSearchPinResponse(&pin),
+	})
+
+	return nil
+}
+
+// synonymTerms normalises terms the way search queries are, dropping
+// duplicates. A set needs at least two distinct terms to have any effect.
+func synonymTerms(terms []string) ([]string, error) {
+	var normalized []string
+	for _, term := range terms {
+		term = normalizeSearchQuery(term)
+		if term != "" && !slices.Contains(normalized, term) {
+			normalized = append(normalized, term)
+		}
+	}
+
+	if len(normalized) < 2 {
+		return nil, apperror.InvalidInput("a synonym set needs at least two distinct terms")
+	}
+
+	return normalized, nil
+}
+
+func convertToSynonymSetResponse(set *models.SearchSynonymSet) dto.SynonymSetResponse {
+	return dto.SynonymSetResponse{
+		ID:        set.ID,
+		Terms:     set.Terms,
+		CreatedAt: set.CreatedAt,
+		UpdatedAt: set.UpdatedAt,
+	}
+}
+
+func convertToProductBoostResponse(product *models.Product) dto.ProductBoostResponse {
+	return dto.ProductBoostResponse{
+		ProductID: product.ID,
+		Name:      product.Name,
+		SKU:       product.SKU,
+		Boost:     product.SearchBoost,
+	}
+}
+
+func convertToSearchPinResponse(pin *models.SearchPin) dto.SearchPinResponse {
+	return dto.SearchPinResponse{
+		ID:          pin.ID,
+		Query:       pin.Query,
+		ProductID:   pin.ProductID,
+		ProductName: pin.Product.Name,
+		Position:    pin.Position,
+		CreatedAt:   pin.CreatedAt,
+	}
+}
@@ -0,0 +1,48 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
+)
+
+// Pub/sub topics behind the GraphQL subscriptions and the search indexer.
+// Messages only signal that something changed; subscribers reload the
+// current state themselves.
+
+const (
+	// productsTopic carries the ID of a product whose searchable fields,
+	// stock or status changed
+	productsTopic = "products"
+
+	// categoriesTopic carries the ID of a category that was changed or
+	// deleted, which affects the search documents of its products
+	categoriesTopic = "categories"
+)
+
+func orderStatusTopic(orderID uint) string {
+	return fmt.Sprintf("order_status:%d", orderID)
+}
+
+func cartTopic(userID uint) string {
+	return fmt.Sprintf("cart:%d", userID)
+}
+
+func publishProductChange(pubSub interfaces.PubSub, productID uint) {
+	publish(pubSub, productsTopic, []byte(strconv.FormatUint(uint64(productID), 10)))
+}
+
+func publishCategoryChange(pubSub interfaces.PubSub, categoryID uint) {
+	publish(pubSub, categoriesTopic, []byte(strconv.FormatUint(uint64(categoryID), 10)))
+}
+
+// publish logs failures rather than returning them, since a missed
+// notification should not fail the change that caused it
+func publish(pubSub interfaces.PubSub, topic string, payload []byte) {
+	if err := pubSub.Publish(context.Background(), topic, payload); err != nil {
+		log.Printf("Failed to publish to %s: %v", topic, err)
+	}
+}
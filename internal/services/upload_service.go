@@ -2,10 +2,11 @@ package services
 
 import (
 	"fmt"
-	"mime/multipart"
+	"io"
 	"path/filepath"
 	"strings"
 
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/interfaces"
 	"github.com/google/uuid"
 )
@@ -20,11 +21,11 @@ func NewUploadService(provider interfaces.UploadProvider) *UploadService {
 	return &UploadService{provider: provider}
 }
 
-func (s *UploadService) UploadProductImage(productID uint, file *multipart.FileHeader) (string, error) {
+func (s *UploadService) UploadProductImage(productID uint, filename string, file io.Reader) (string, error) {
 
-	ext := strings.ToLower(filepath.Ext(file.Filename))
+	ext := strings.ToLower(filepath.Ext(filename))
 	if !isValidImageExt(ext) {
-		return "", fmt.Errorf("invalid file type: %s", ext)
+		return "", apperror.InvalidInput(fmt.Sprintf("invalid file type: %s", ext))
 	}
 
 	path := fmt.Sprintf("products/%d/%s%s", productID, uuid.New().String(), ext)
@@ -1,19 +1,27 @@
 package services
 
 import (
+	"context"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/dto"
 	"github.com/abhilashdk2016/golang-ecommerce/internal/models"
+	"github.com/abhilashdk2016/golang-ecommerce/internal/utils"
 	"gorm.io/gorm"
 )
 
 var _ UserServiceInterface = (*UserService)(nil)
 
 type UserService struct {
-	db *gorm.DB
+	db           *gorm.DB
+	auditService AuditServiceInterface
 }
 
-func NewUserService(db *gorm.DB) *UserService {
-	return &UserService{db: db}
+func NewUserService(db *gorm.DB, auditService AuditServiceInterface) *UserService {
+	return &UserService{
+		db:           db,
+		auditService: auditService,
+	}
 }
 
 func (s *UserService) GetProfile(userID uint) (*dto.UserResponse, error) {
@@ -22,17 +30,8 @@ func (s *UserService) GetProfile(userID uint) (*dto.UserResponse, error) {
 		return nil, err
 	}
 
-	return &dto.UserResponse{
-		ID:        user.ID,
-		Email:     user.Email,
-		FirstName: user.FirstName,
-		LastName:  user.LastName,
-		Phone:     user.Phone,
-		Role:      string(user.Role),
-		IsActive:  user.IsActive,
-		CreatedAt: user.CreatedAt,
-		UpdatedAt: user.UpdatedAt,
-	}, nil
+	response := convertToUserResponse(&user)
+	return &response, nil
 }
 
 func (s *UserService) UpdateProfile(userID uint, req *dto.UpdateProfileRequest) (*dto.UserResponse, error) {
@@ -51,3 +50,185 @@ func (s *UserService) UpdateProfile(userID uint, req *dto.UpdateProfileRequest)
 
 	return s.GetProfile(userID)
 }
+
+func (s *UserService) SearchUsers(req *dto.AdminUserSearchRequest) ([]dto.AdminUserResponse, *utils.PaginationMeta, error) {
+	page, limit := req.Page, req.Limit
+	if page < 1 {
+		page = 1
+	}
+
+	if limit < 1 {
+		limit = 10
+	}
+
+	query := s.db.Model(&models.User{})
+
+	if req.Query != "" {
+		pattern := "%" + req.Query + "%"
+		query = query.Where("email ILIKE ? OR first_name ILIKE ? OR last_name ILIKE ? OR phone ILIKE ?",
+			pattern, pattern, pattern, pattern)
+	}
+
+	if req.Role != "" {
+		query = query.Where(`EXISTS (
+			SELECT 1 FROM user_roles JOIN roles ON roles.id = user_roles.role_id
+			WHERE user_roles.user_id = users.id AND roles.name = ?)`, req.Role)
+	}
+
+	if req.IsActive != nil {
+		query = query.Where("is_active = ?", *req.IsActive)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, nil, err
+	}
+
+	var users []models.User
+	if err := query.Preload("Roles").
+		Order("created_at DESC").
+		Offset((page - 1) * limit).Limit(limit).
+		Find(&users).Error; err != nil {
+		return nil, nil, err
+	}
+
+	response := make([]dto.AdminUserResponse, len(users))
+	for i := range users {
+		response[i] = convertToAdminUserResponse(&users[i])
+	}
+
+	totalPages := int((total + int64(limit) - 1) / int64(limit))
+	meta := &utils.PaginationMeta{
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: totalPages,
+	}
+
+	return response, meta, nil
+}
+
+func (s *UserService) GetUser(id uint) (*dto.AdminUserResponse, error) {
+	var user models.User
+	if err := s.db.Preload("Roles").First(&user, id).Error; err != nil {
+		return nil, err
+	}
+
+	response := convertToAdminUserResponse(&user)
+	return &response, nil
+}
+
+// GetUsersByIDs returns the users with the given IDs in no particular order
+func (s *UserService) GetUsersByIDs(ids []uint) ([]dto.UserResponse, error) {
+	var users []models.User
+	if err := s.db.Where("id IN ?", ids).Find(&users).Error; err != nil {
+		return nil, err
+	}
+
+	response := make([]dto.UserResponse, len(users))
+	for i := range users {
+		response[i] = convertToUserResponse(&users[i])
+	}
+
+	return response, nil
+}
+
+// SetUserActive activates or deactivates an account. Deactivation also
+// revokes the user's refresh tokens so existing sessions cannot be renewed.
+func (s *UserService) SetUserActive(ctx context.Context, actorID, id uint, req *dto.UpdateUserStatusRequest) (*dto.AdminUserResponse, error) {
+	if actorID == id && !*req.IsActive {
+		return nil, apperror.InvalidInput("you cannot deactivate your own account")
+	}
+
+	var wasActive bool
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		var user models.User
+		if err := tx.First(&user, id).Error; err != nil {
+			return err
+		}
+		wasActive = user.IsActive
+
+		if err := tx.Model(&user).Update("is_active", *req.IsActive).Error; err != nil {
+			return err
+		}
+
+		if !*req.IsActive {
+			return tx.Where("user_id = ?", id).Delete(&models.RefreshToken{}).Error
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, AuditEntry{
+		ActorID:    &actorID,
+		Action:     models.AuditActionUserStatusChange,
+		EntityType: models.AuditEntityUser,
+		EntityID:   id,
+		Before:     map[string]any{"is_active": wasActive},
+		After:      map[string]any{"is_active": *req.IsActive},
+	})
+
+	return s.GetUser(id)
+}
+
+// SetServiceAccount marks or unmarks an account as a service account. Staff
+// cannot mark their own account.
+func (s *UserService) SetServiceAccount(ctx context.Context, actorID, id uint, req *dto.UpdateServiceAccountRequest) (*dto.AdminUserResponse, error) {
+	if actorID == id {
+		return nil, apperror.InvalidInput("you cannot change the type of your own account")
+	}
+
+	var user models.User
+	if err := s.db.First(&user, id).Error; err != nil {
+		return nil, err
+	}
+	wasServiceAccount := user.IsServiceAccount
+
+	if err := s.db.Model(&user).Update("is_service_account", *req.IsServiceAccount).Error; err != nil {
+		return nil, err
+	}
+
+	s.auditService.Record(ctx, AuditEntry{
+		ActorID:    &actorID,
+		Action:     models.AuditActionServiceAccount,
+		EntityType: models.AuditEntityUser,
+		EntityID:   id,
+		Before:     map[string]any{"is_service_account": wasServiceAccount},
+		After:      map[string]any{"is_service_account": *req.IsServiceAccount},
+	})
+
+	return s.GetUser(id)
+}
+
+func convertToAdminUserResponse(user *models.User) dto.AdminUserResponse {
+	roles := make([]string, len(user.Roles))
+	for i, role := range user.Roles {
+		roles[i] = role.Name
+	}
+
+	return dto.AdminUserResponse{
+		UserResponse:     convertToUserResponse(user),
+		Roles:            roles,
+		LockedUntil:      user.LockedUntil,
+		IsServiceAccount: user.IsServiceAccount,
+	}
+}
+
+func convertToUserResponse(user *models.User) dto.UserResponse {
+	return dto.UserResponse{
+		ID:               user.ID,
+		Email:            user.Email,
+		FirstName:        user.FirstName,
+		LastName:         user.LastName,
+		Phone:            user.Phone,
+		Role:             string(user.Role),
+		IsActive:         user.IsActive,
+		EmailVerifiedAt:  user.EmailVerifiedAt,
+		TwoFactorEnabled: user.TwoFactorEnabled,
+		CreatedAt:        user.CreatedAt,
+		UpdatedAt:        user.UpdatedAt,
+	}
+}
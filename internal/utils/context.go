@@ -7,4 +7,22 @@ const (
 	UserEmailKey  ContextKey = "user_email"
 	UserRoleKey   ContextKey = "user_role"
 	GinContextKey ContextKey = "gin_context"
+	RequestIDKey  ContextKey = "request_id"
+	ClientIPKey   ContextKey = "client_ip"
+	UserAgentKey  ContextKey = "user_agent"
+
+	// TwoFactorPendingKey is set when the session has not completed two-factor
+	// authentication and staff permissions must therefore not be honoured
+	TwoFactorPendingKey ContextKey = "two_factor_pending"
+
+	// APIKeyScopesKey holds the scopes of the API key used to authenticate,
+	// if any. Staff permissions outside these scopes are not honoured.
+	APIKeyScopesKey ContextKey = "api_key_scopes"
+
+	// ImpersonatorIDKey holds the staff user behind an impersonation token
+	ImpersonatorIDKey ContextKey = "impersonator_id"
+
+	// AuthTimeKey holds when the user behind the access token last signed in
+	// with their credentials. It is unset for API keys and older tokens.
+	AuthTimeKey ContextKey = "auth_time"
 )
@@ -0,0 +1,38 @@
+package utils
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
+)
+
+var ErrInvalidCursor = apperror.InvalidInput("invalid cursor")
+
+// Cursor is a position in a keyset-paginated list: the sort it was issued
+// for, the value of the sort column and the ID of the row it was taken from
+type Cursor struct {
+	Sort  string `json:"s,omitempty"`
+	Value any    `json:"v,omitempty"`
+	ID    uint   `json:"id"`
+}
+
+// EncodeCursor returns an opaque, URL-safe representation of c
+func EncodeCursor(c Cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (*Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == 0 {
+		return nil, ErrInvalidCursor
+	}
+
+	return &c, nil
+}
@@ -10,20 +10,31 @@ import (
 
 // Claims contains the data for the user
 type Claims struct {
-	UserID uint   `json:"user_id"`
-	Email  string `json:"email"`
-	Role   string `json:"role"`
+	UserID            uint   `json:"user_id"`
+	Email             string `json:"email"`
+	Role              string `json:"role"`
+	TwoFactorVerified bool   `json:"two_factor_verified,omitempty"`
+	ImpersonatorID    uint   `json:"impersonator_id,omitempty"`
+	// AuthTime is when the user last signed in with their credentials. It is
+	// carried over when tokens are refreshed.
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
 	jwt.RegisteredClaims
 }
 
 // GenerateTokenPair generates access and refresh token
-func GenerateTokenPair(cfg *config.JWTConfig, userID uint, email, role string) (accessToken, refreshToken string, err error) {
+func GenerateTokenPair(cfg *config.JWTConfig, userID uint, email, role string, twoFactorVerified bool, authTime time.Time) (accessToken, refreshToken string, err error) {
+	var authTimeClaim *jwt.NumericDate
+	if !authTime.IsZero() {
+		authTimeClaim = jwt.NewNumericDate(authTime)
+	}
 
 	// Access token
 	accessClaims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:            userID,
+		Email:             email,
+		Role:              role,
+		TwoFactorVerified: twoFactorVerified,
+		AuthTime:          authTimeClaim,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.ExpiresIn)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -38,9 +49,11 @@ func GenerateTokenPair(cfg *config.JWTConfig, userID uint, email, role string) (
 
 	// Refresh token
 	refreshClaims := &Claims{
-		UserID: userID,
-		Email:  email,
-		Role:   role,
+		UserID:            userID,
+		Email:             email,
+		Role:              role,
+		TwoFactorVerified: twoFactorVerified,
+		AuthTime:          authTimeClaim,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(cfg.RefreshTokenExpiresIn)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
@@ -55,6 +68,29 @@ func GenerateTokenPair(cfg *config.JWTConfig, userID uint, email, role string) (
 	return accessTokenString, refreshTokenString, nil
 }
 
+// GenerateImpersonationToken generates a short-lived access token for userID
+// on behalf of impersonatorID. No refresh token is issued.
+func GenerateImpersonationToken(cfg *config.JWTConfig, userID uint, email, role string, impersonatorID uint, expiresIn time.Duration) (string, time.Time, error) {
+	expiresAt := time.Now().Add(expiresIn)
+	claims := &Claims{
+		UserID:         userID,
+		Email:          email,
+		Role:           role,
+		ImpersonatorID: impersonatorID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.Secret))
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	return token, expiresAt, nil
+}
+
 // ValidateToken checks if jwt token is valid
 func ValidateToken(tokenString, secret string) (*Claims, error) {
 	token, err := jwt.ParseWithClaims(tokenString, &Claims{}, func(token *jwt.Token) (interface{}, error) {
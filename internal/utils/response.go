@@ -3,14 +3,16 @@ package utils
 import (
 	"net/http"
 
+	"github.com/abhilashdk2016/golang-ecommerce/internal/apperror"
 	"github.com/gin-gonic/gin"
 )
 
 type Response struct {
-	Success bool        `json:"success"`
-	Message string      `json:"message"`
-	Data    interface{} `json:"data"`
-	Error   string      `json:"error"`
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Data    interface{}   `json:"data"`
+	Error   string        `json:"error"`
+	Code    apperror.Code `json:"code,omitempty"`
 }
 
 type PaginatedResponse struct {
@@ -25,6 +27,31 @@ type PaginationMeta struct {
 	TotalPages int   `json:"total_pages"`
 }
 
+// FacetedResponse is a page of search results together with facet counts
+// over all of the results. SearchID identifies the logged search, for
+// attributing clicks and cart additions to it.
+type FacetedResponse struct {
+	PaginatedResponse
+	Facets   interface{} `json:"facets"`
+	SearchID uint        `json:"search_id,omitempty"`
+}
+
+// CursorMeta describes a page of a keyset-paginated list. Cursors are opaque
+// and only valid for the list and sort order they were issued for.
+type CursorMeta struct {
+	Limit           int    `json:"limit"`
+	Total           int64  `json:"total"`
+	HasNextPage     bool   `json:"has_next_page"`
+	HasPreviousPage bool   `json:"has_previous_page"`
+	StartCursor     string `json:"start_cursor,omitempty"`
+	EndCursor       string `json:"end_cursor,omitempty"`
+}
+
+type CursorPaginatedResponse struct {
+	Response
+	Meta CursorMeta `json:"meta"`
+}
+
 func SuccessResponse(c *gin.Context, message string, data interface{}) {
 	c.JSON(http.StatusOK, Response{
 		Success: true,
@@ -45,15 +72,27 @@ func ErrorResponse(c *gin.Context, statusCode int, message string, err error) {
 	response := Response{
 		Success: false,
 		Message: message,
+		Code:    apperror.CodeForStatus(statusCode),
 	}
 
-	if err != nil {
+	// Details of server-side failures belong in the logs, not the response
+	if err != nil && statusCode < http.StatusInternalServerError {
 		response.Error = err.Error()
 	}
 
 	c.JSON(statusCode, response)
 }
 
+// AppErrorResponse responds with the status and code of a domain error
+func AppErrorResponse(c *gin.Context, message string, err *apperror.Error) {
+	c.JSON(apperror.HTTPStatus(err.Code), Response{
+		Success: false,
+		Message: message,
+		Error:   err.Message,
+		Code:    err.Code,
+	})
+}
+
 func BadRequestResponse(c *gin.Context, message string, err error) {
 	ErrorResponse(c, http.StatusBadRequest, message, err)
 }
@@ -70,6 +109,10 @@ func NotFoundResponse(c *gin.Context, message string) {
 	ErrorResponse(c, http.StatusNotFound, message, nil)
 }
 
+func TooManyRequestsResponse(c *gin.Context, message string, err error) {
+	ErrorResponse(c, http.StatusTooManyRequests, message, err)
+}
+
 func InternalServerErrorResponse(c *gin.Context, message string, err error) {
 	ErrorResponse(c, http.StatusInternalServerError, message, err)
 }
@@ -84,3 +127,29 @@ func PaginatedSuccessResponse(c *gin.Context, message string, data interface{},
 		Meta: meta,
 	})
 }
+
+func CursorPaginatedSuccessResponse(c *gin.Context, message string, data interface{}, meta CursorMeta) {
+	c.JSON(http.StatusOK, CursorPaginatedResponse{
+		Response: Response{
+			Success: true,
+			Message: message,
+			Data:    data,
+		},
+		Meta: meta,
+	})
+}
+
+func FacetedSuccessResponse(c *gin.Context, message string, data interface{}, facets interface{}, searchID uint, meta PaginationMeta) {
+	c.JSON(http.StatusOK, FacetedResponse{
+		PaginatedResponse: PaginatedResponse{
+			Response: Response{
+				Success: true,
+				Message: message,
+				Data:    data,
+			},
+			Meta: meta,
+		},
+		Facets:   facets,
+		SearchID: searchID,
+	})
+}